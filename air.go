@@ -70,6 +70,13 @@ SOFTWARE.
 //	fmt.Printf("ID: %s\n", air.Identifier)        // 133005
 //	fmt.Printf("Version: %s\n", air.Version)      // v1.0
 //
+// # Parsing CivitAI URLs
+//
+// Convert a URL copied from the site into an AIR:
+//
+//	air, err := civitai.ParseCivitAIURL("https://civitai.com/models/4201/realistic-vision?modelVersionId=130072")
+//	model, err := client.GetModelByAIR(ctx, air)
+//
 // # AIR Collections
 //
 // Work with collections of AIR identifiers:
@@ -115,6 +122,11 @@ SOFTWARE.
 //	version := model.ModelVersions[0]
 //	versionAIR := civitai.ConvertVersionToAIR(version, "civitai")
 //
+// When no ecosystem is passed and none can be inferred, conversions fall back
+// to sdxl. Change the fallback once at startup:
+//
+//	civitai.SetDefaultAIREcosystem(civitai.AIREcosystemFlux)
+//
 // # Validation and Helper Methods
 //
 //	air := civitai.ParseAIR("air://civitai/model/133005/v1.0")
@@ -147,8 +159,11 @@ package civitai
 import (
 	"errors"
 	"fmt"
+	"net/url"
 	"regexp"
 	"strconv"
+	"strings"
+	"sync"
 )
 
 // AIR represents an AI Resource Identifier
@@ -191,6 +206,35 @@ const (
 	AIREcosystemFlux AIREcosystem = "flux"
 )
 
+// defaultAIREcosystem is the fallback ecosystem; see SetDefaultAIREcosystem
+var (
+	defaultAIREcosystemMu sync.RWMutex
+	defaultAIREcosystem   = AIREcosystemSDXL
+)
+
+// SetDefaultAIREcosystem sets the ecosystem ConvertModelToAIR,
+// ConvertVersionToAIR, and ParseCivitAIURL use when none is given and none can
+// be inferred. The out-of-box default is AIREcosystemSDXL, which an empty
+// ecosystem restores. The setting is process-wide because the conversion
+// functions have no client to consult, so set it during initialization.
+func SetDefaultAIREcosystem(ecosystem AIREcosystem) {
+	if ecosystem == "" {
+		ecosystem = AIREcosystemSDXL
+	}
+
+	defaultAIREcosystemMu.Lock()
+	defer defaultAIREcosystemMu.Unlock()
+	defaultAIREcosystem = ecosystem
+}
+
+// DefaultAIREcosystem returns the fallback ecosystem set by
+// SetDefaultAIREcosystem
+func DefaultAIREcosystem() AIREcosystem {
+	defaultAIREcosystemMu.RLock()
+	defer defaultAIREcosystemMu.RUnlock()
+	return defaultAIREcosystem
+}
+
 // AIRSource represents supported source platforms
 type AIRSource string
 
@@ -233,6 +277,90 @@ func ParseAIR(airString string) (*AIR, error) {
 	return air, nil
 }
 
+// ParseCivitAIURL converts a civitai.com model page URL into an AIR, including
+// the version when the URL selects one (e.g. ?modelVersionId=130072). The URL
+// carries no base model information, so the ecosystem is DefaultAIREcosystem.
+// URLs that identify only a version, such as download links, are rejected;
+// use ParseCivitAIURLIDs for those.
+func ParseCivitAIURL(rawURL string) (*AIR, error) {
+	modelID, versionID, err := ParseCivitAIURLIDs(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	if modelID == 0 {
+		return nil, fmt.Errorf("URL identifies version %d but not its model: %s", versionID, rawURL)
+	}
+
+	air := NewCivitAIModelAIR(string(DefaultAIREcosystem()), modelID, versionID)
+	air.Raw = air.String()
+	return air, nil
+}
+
+// ParseCivitAIURLIDs extracts the model ID and version ID from a CivitAI URL.
+// It accepts site pages (/models/{id}/{slug}?modelVersionId={vid}), REST API
+// URLs (/api/v1/models/{id}, /api/v1/model-versions/{vid}), and download links
+// (/api/download/models/{vid}). Either ID is 0 when the URL doesn't contain it.
+func ParseCivitAIURLIDs(rawURL string) (modelID, versionID int, err error) {
+	rawURL = strings.TrimSpace(rawURL)
+	if rawURL == "" {
+		return 0, 0, errors.New("URL cannot be empty")
+	}
+	if !strings.Contains(rawURL, "://") {
+		rawURL = "https://" + rawURL
+	}
+
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid URL: %w", err)
+	}
+
+	host := strings.ToLower(parsed.Hostname())
+	if host != "civitai.com" && !strings.HasSuffix(host, ".civitai.com") && host != "civitai.green" {
+		return 0, 0, fmt.Errorf("not a CivitAI URL: %s", parsed.Host)
+	}
+
+	segments := strings.Split(strings.Trim(parsed.Path, "/"), "/")
+	parseID := func(kind, value string) (int, error) {
+		id, err := strconv.Atoi(value)
+		if err != nil || id <= 0 {
+			return 0, fmt.Errorf("invalid %s ID %q in URL", kind, value)
+		}
+		return id, nil
+	}
+
+	switch {
+	case len(segments) >= 2 && segments[0] == "models":
+		// /models/{id}[/{slug}]
+		if modelID, err = parseID("model", segments[1]); err != nil {
+			return 0, 0, err
+		}
+	case len(segments) >= 4 && segments[0] == "api" && segments[1] == "v1" && segments[2] == "models":
+		if modelID, err = parseID("model", segments[3]); err != nil {
+			return 0, 0, err
+		}
+	case len(segments) >= 4 && segments[0] == "api" && segments[1] == "v1" && segments[2] == "model-versions":
+		if versionID, err = parseID("version", segments[3]); err != nil {
+			return 0, 0, err
+		}
+		return 0, versionID, nil
+	case len(segments) >= 4 && segments[0] == "api" && segments[1] == "download" && segments[2] == "models":
+		if versionID, err = parseID("version", segments[3]); err != nil {
+			return 0, 0, err
+		}
+		return 0, versionID, nil
+	default:
+		return 0, 0, fmt.Errorf("unrecognized CivitAI URL path: %s", parsed.Path)
+	}
+
+	if value := parsed.Query().Get("modelVersionId"); value != "" {
+		if versionID, err = parseID("version", value); err != nil {
+			return 0, 0, err
+		}
+	}
+
+	return modelID, versionID, nil
+}
+
 // NewAIR creates a new AIR with required components
 func NewAIR(ecosystem, resourceType, source, id string) *AIR {
 	return &AIR{
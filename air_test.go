@@ -623,3 +623,151 @@ func TestConvertVersionToAIR(t *testing.T) {
 		}
 	})
 }
+
+func TestSetDefaultAIREcosystem(t *testing.T) {
+	t.Cleanup(func() { SetDefaultAIREcosystem("") })
+
+	if got := DefaultAIREcosystem(); got != AIREcosystemSDXL {
+		t.Fatalf("Expected out-of-box default sdxl, got %s", got)
+	}
+
+	SetDefaultAIREcosystem(AIREcosystemFlux)
+
+	t.Run("Model without inferable tags", func(t *testing.T) {
+		air := ConvertModelToAIR(&Model{ID: 1, Type: ModelTypeLORA, Tags: []string{"anime"}}, "")
+		if air.Ecosystem != "flux" {
+			t.Errorf("Expected configured default flux, got %s", air.Ecosystem)
+		}
+	})
+
+	t.Run("Inference still wins", func(t *testing.T) {
+		air := ConvertModelToAIR(&Model{ID: 1, Tags: []string{"SD 1.5"}}, "")
+		if air.Ecosystem != "sd1" {
+			t.Errorf("Expected inferred sd1, got %s", air.Ecosystem)
+		}
+	})
+
+	t.Run("Explicit ecosystem still wins", func(t *testing.T) {
+		air := ConvertVersionToAIR(&ModelVersion{ID: 2, ModelID: 1}, "sd2")
+		if air.Ecosystem != "sd2" {
+			t.Errorf("Expected explicit sd2, got %s", air.Ecosystem)
+		}
+	})
+
+	t.Run("Version", func(t *testing.T) {
+		air := ConvertVersionToAIR(&ModelVersion{ID: 2, ModelID: 1}, "")
+		if air.Ecosystem != "flux" {
+			t.Errorf("Expected configured default flux, got %s", air.Ecosystem)
+		}
+	})
+
+	t.Run("Civitai URL", func(t *testing.T) {
+		air, err := ParseCivitAIURL("https://civitai.com/models/4201")
+		if err != nil {
+			t.Fatalf("ParseCivitAIURL failed: %v", err)
+		}
+		if air.Ecosystem != "flux" {
+			t.Errorf("Expected configured default flux, got %s", air.Ecosystem)
+		}
+	})
+
+	t.Run("Empty restores sdxl", func(t *testing.T) {
+		SetDefaultAIREcosystem("")
+		air := ConvertVersionToAIR(&ModelVersion{ID: 2, ModelID: 1}, "")
+		if air.Ecosystem != "sdxl" {
+			t.Errorf("Expected sdxl after reset, got %s", air.Ecosystem)
+		}
+	})
+}
+
+func TestParseCivitAIURL(t *testing.T) {
+	tests := []struct {
+		name              string
+		url               string
+		expectedModelID   int
+		expectedVersionID int
+		expectedAIR       string
+		expectError       bool
+	}{
+		{
+			name:            "Model page with slug",
+			url:             "https://civitai.com/models/4201/realistic-vision-v60-b1",
+			expectedModelID: 4201,
+			expectedAIR:     "urn:air:sdxl:model:civitai:4201",
+		},
+		{
+			name:              "Version query parameter",
+			url:               "https://civitai.com/models/4201/realistic-vision-v60-b1?modelVersionId=130072",
+			expectedModelID:   4201,
+			expectedVersionID: 130072,
+			expectedAIR:       "urn:air:sdxl:model:civitai:4201@130072",
+		},
+		{
+			name:              "Version query without slug",
+			url:               "https://www.civitai.com/models/4201?modelVersionId=130072",
+			expectedModelID:   4201,
+			expectedVersionID: 130072,
+			expectedAIR:       "urn:air:sdxl:model:civitai:4201@130072",
+		},
+		{
+			name:            "Missing scheme and trailing slash",
+			url:             "civitai.com/models/4201/",
+			expectedModelID: 4201,
+			expectedAIR:     "urn:air:sdxl:model:civitai:4201",
+		},
+		{
+			name:            "REST API model URL",
+			url:             "https://civitai.com/api/v1/models/4201",
+			expectedModelID: 4201,
+			expectedAIR:     "urn:air:sdxl:model:civitai:4201",
+		},
+		{
+			name:              "Download link has only a version",
+			url:               "https://civitai.com/api/download/models/130072?type=Model&format=SafeTensor",
+			expectedVersionID: 130072,
+			expectError:       true,
+		},
+		{
+			name:              "REST API version URL",
+			url:               "https://civitai.com/api/v1/model-versions/130072",
+			expectedVersionID: 130072,
+			expectError:       true,
+		},
+		{name: "Other host", url: "https://huggingface.co/models/4201", expectError: true},
+		{name: "Non-numeric model ID", url: "https://civitai.com/models/abc", expectError: true},
+		{name: "Invalid version ID", url: "https://civitai.com/models/4201?modelVersionId=x", expectError: true},
+		{name: "Unrecognized path", url: "https://civitai.com/images/12345", expectError: true},
+		{name: "Empty", url: "", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			modelID, versionID, idErr := ParseCivitAIURLIDs(tt.url)
+			if tt.expectedModelID != 0 || tt.expectedVersionID != 0 {
+				if idErr != nil {
+					t.Fatalf("ParseCivitAIURLIDs failed: %v", idErr)
+				}
+				if modelID != tt.expectedModelID || versionID != tt.expectedVersionID {
+					t.Errorf("Expected IDs %d/%d, got %d/%d", tt.expectedModelID, tt.expectedVersionID, modelID, versionID)
+				}
+			}
+
+			air, err := ParseCivitAIURL(tt.url)
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("Expected error for %q, got AIR %s", tt.url, air)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseCivitAIURL failed: %v", err)
+			}
+			if air.String() != tt.expectedAIR {
+				t.Errorf("Expected AIR %s, got %s", tt.expectedAIR, air.String())
+			}
+			if err := air.Validate(); err != nil {
+				t.Errorf("Expected valid AIR, got %v", err)
+			}
+		})
+	}
+}
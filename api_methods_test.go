@@ -24,10 +24,16 @@ package civitai
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
 func TestAPIMethodsWithMockServer(t *testing.T) {
@@ -431,3 +437,1158 @@ func TestExceptionTypes(t *testing.T) {
 		}
 	})
 }
+
+func TestGetModelVersionFile(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id": 456, "name": "v1", "createdAt": "2024-01-01T00:00:00Z", "updatedAt": "2024-01-01T00:00:00Z", "files": [
+			{"id": 1, "name": "model.ckpt", "primary": true, "metadata": {"format": "PickleTensor", "fp": "fp32"}},
+			{"id": 2, "name": "model.safetensors", "metadata": {"format": "SafeTensor", "fp": "fp16"}, "pickleScanResult": "Success", "virusScanResult": "Success"}
+		]}`))
+	}))
+	defer server.Close()
+
+	client := NewClientWithoutAuth(WithBaseURL(server.URL))
+	ctx := context.Background()
+
+	t.Run("Matching file", func(t *testing.T) {
+		version, file, err := client.GetModelVersionFile(ctx, 456, FilePreference{Format: FileFormatSafeTensors, CleanOnly: true})
+		if err != nil {
+			t.Fatalf("GetModelVersionFile failed: %v", err)
+		}
+		if version.ID != 456 {
+			t.Errorf("Expected version 456, got %d", version.ID)
+		}
+		if file.ID != 2 {
+			t.Errorf("Expected file 2, got %d", file.ID)
+		}
+	})
+
+	t.Run("No matching file", func(t *testing.T) {
+		_, file, err := client.GetModelVersionFile(ctx, 456, FilePreference{Format: FileFormatCKPT})
+		if !errors.Is(err, ErrNoMatchingFile) {
+			t.Fatalf("Expected ErrNoMatchingFile, got %v", err)
+		}
+		if file != nil {
+			t.Error("Expected nil file")
+		}
+		if !strings.Contains(err.Error(), "format=CKPT") {
+			t.Errorf("Expected error to describe the preference, got: %v", err)
+		}
+	})
+
+	t.Run("Invalid version ID", func(t *testing.T) {
+		if _, _, err := client.GetModelVersionFile(ctx, 0, FilePreference{}); err == nil {
+			t.Error("Expected error for invalid version ID")
+		}
+	})
+}
+
+func TestGetModelsForTopTags(t *testing.T) {
+	var inFlight, maxInFlight int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.Contains(r.URL.Path, "/tags"):
+			w.Write([]byte(`{"items": [{"name": "anime", "modelCount": 300}, {"name": "style", "modelCount": 200}, {"name": "broken", "modelCount": 100}], "metadata": {"totalItems": 3}}`))
+		case strings.Contains(r.URL.Path, "/models"):
+			current := atomic.AddInt32(&inFlight, 1)
+			defer atomic.AddInt32(&inFlight, -1)
+			for {
+				max := atomic.LoadInt32(&maxInFlight)
+				if current <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, current) {
+					break
+				}
+			}
+			time.Sleep(20 * time.Millisecond)
+
+			if r.URL.Query().Get("sort") != string(SortMostDownload) {
+				t.Errorf("Expected sort %q, got %q", SortMostDownload, r.URL.Query().Get("sort"))
+			}
+			switch r.URL.Query().Get("tag") {
+			case "anime":
+				w.Write([]byte(`{"items": [{"id": 1, "name": "A"}, {"id": 2, "name": "Shared"}], "metadata": {}}`))
+			case "style":
+				w.Write([]byte(`{"items": [{"id": 2, "name": "Shared"}, {"id": 3, "name": "C"}], "metadata": {}}`))
+			default:
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"error": "bad tag"}`))
+			}
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClientWithoutAuth(WithBaseURL(server.URL), WithRetryConfig(0, 0, 0))
+	ctx := context.Background()
+
+	t.Run("Partial failure", func(t *testing.T) {
+		byTag, err := client.GetModelsForTopTags(ctx, 3, 2)
+		if err == nil || !strings.Contains(err.Error(), `tag "broken"`) {
+			t.Errorf("Expected error for tag 'broken', got %v", err)
+		}
+		if len(byTag) != 2 {
+			t.Fatalf("Expected 2 tags with models, got %d", len(byTag))
+		}
+		if len(byTag["anime"]) != 2 || len(byTag["style"]) != 2 {
+			t.Errorf("Expected 2 models per tag, got anime=%d style=%d", len(byTag["anime"]), len(byTag["style"]))
+		}
+	})
+
+	t.Run("Dedupe and bounded concurrency", func(t *testing.T) {
+		atomic.StoreInt32(&maxInFlight, 0)
+		byTag, _ := client.GetModelsForTopTagsWithOptions(ctx, TopTagsOptions{
+			TagLimit:     3,
+			ModelsPerTag: 2,
+			Concurrency:  1,
+			DedupeModels: true,
+		})
+		if len(byTag["anime"]) != 2 {
+			t.Errorf("Expected shared model kept under top tag, got %d anime models", len(byTag["anime"]))
+		}
+		if len(byTag["style"]) != 1 || byTag["style"][0].ID != 3 {
+			t.Errorf("Expected only model 3 under style, got %+v", byTag["style"])
+		}
+		if max := atomic.LoadInt32(&maxInFlight); max != 1 {
+			t.Errorf("Expected at most 1 concurrent search, got %d", max)
+		}
+	})
+
+	t.Run("Invalid limits", func(t *testing.T) {
+		if _, err := client.GetModelsForTopTags(ctx, 0, 5); err == nil {
+			t.Error("Expected error for zero tag limit")
+		}
+		if _, err := client.GetModelsForTopTags(ctx, 5, 0); err == nil {
+			t.Error("Expected error for zero models per tag")
+		}
+	})
+}
+
+func TestRefreshVersionAvailability(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/model-versions/1":
+			w.Write([]byte(`{"id": 1, "availability": "Public", "model": {"name": "A", "mode": null}}`))
+		case "/model-versions/2":
+			w.Write([]byte(`{"id": 2, "availability": "EarlyAccess", "model": {"name": "B"}}`))
+		case "/model-versions/3":
+			w.Write([]byte(`{"id": 3, "availability": "Private", "model": {"name": "C"}}`))
+		case "/model-versions/4":
+			w.Write([]byte(`{"id": 4, "availability": "Public", "model": {"name": "D", "mode": "Archived"}}`))
+		case "/model-versions/5":
+			w.Write([]byte(`{"id": 5, "availability": "Public", "model": {"name": "E", "mode": "TakenDown"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error": "No version with id 6"}`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClientWithoutAuth(WithBaseURL(server.URL), WithStrictJSON())
+
+	tests := []struct {
+		versionID int
+		expected  string
+	}{
+		{1, VersionAvailabilityAvailable},
+		{2, VersionAvailabilityEarlyAccess},
+		{3, VersionAvailabilityPrivate},
+		{4, VersionAvailabilityArchived},
+		{5, VersionAvailabilityTakenDown},
+		{6, VersionAvailabilityDeleted},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			availability, err := client.RefreshVersionAvailability(context.Background(), tt.versionID)
+			if err != nil {
+				t.Fatalf("RefreshVersionAvailability failed: %v", err)
+			}
+			if availability != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, availability)
+			}
+		})
+	}
+
+	t.Run("Invalid version ID", func(t *testing.T) {
+		if _, err := client.RefreshVersionAvailability(context.Background(), 0); err == nil {
+			t.Error("Expected error for invalid version ID")
+		}
+	})
+}
+
+func TestGetVersionImages(t *testing.T) {
+	var lastQuery map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lastQuery = map[string]string{
+			"modelVersionId": r.URL.Query().Get("modelVersionId"),
+			"nsfw":           r.URL.Query().Get("nsfw"),
+			"limit":          r.URL.Query().Get("limit"),
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"items": [{"id": 1, "url": "https://image.civitai.com/1.jpeg"}], "metadata": {}}`))
+	}))
+	defer server.Close()
+
+	client := NewClientWithoutAuth(WithBaseURL(server.URL))
+	ctx := context.Background()
+
+	t.Run("Defaults to safe NSFW level", func(t *testing.T) {
+		images, err := client.GetVersionImages(ctx, 456, 12)
+		if err != nil {
+			t.Fatalf("GetVersionImages failed: %v", err)
+		}
+		if len(images) != 1 {
+			t.Errorf("Expected 1 image, got %d", len(images))
+		}
+		if lastQuery["modelVersionId"] != "456" {
+			t.Errorf("Expected modelVersionId=456, got %q", lastQuery["modelVersionId"])
+		}
+		if lastQuery["nsfw"] != string(NSFWLevelNone) {
+			t.Errorf("Expected nsfw=%s, got %q", NSFWLevelNone, lastQuery["nsfw"])
+		}
+		if lastQuery["limit"] != "12" {
+			t.Errorf("Expected limit=12, got %q", lastQuery["limit"])
+		}
+	})
+
+	t.Run("NSFW override", func(t *testing.T) {
+		if _, err := client.GetVersionImages(ctx, 456, 12, NSFWLevelMature); err != nil {
+			t.Fatalf("GetVersionImages failed: %v", err)
+		}
+		if lastQuery["nsfw"] != string(NSFWLevelMature) {
+			t.Errorf("Expected nsfw=%s, got %q", NSFWLevelMature, lastQuery["nsfw"])
+		}
+	})
+
+	t.Run("Invalid version ID", func(t *testing.T) {
+		if _, err := client.GetVersionImages(ctx, -1, 12); err == nil {
+			t.Error("Expected error for invalid version ID")
+		}
+	})
+}
+
+func TestFindModelByName(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Query().Get("query") {
+		case "Realistic Vision", "realistic-vision-v60-b1", "realistic-vision-v60":
+			w.Write([]byte(`{"items": [
+				{"id": 1, "name": "Realistic Vision V6.0 B1"},
+				{"id": 2, "name": "realistic vision"},
+				{"id": 3, "name": "Realistic Vision Inpainting"}
+			], "metadata": {}}`))
+		case "DreamShaper":
+			w.Write([]byte(`{"items": [{"id": 10, "name": "DreamShaper"}, {"id": 11, "name": "dreamshaper"}], "metadata": {}}`))
+		case "Juggernaut":
+			w.Write([]byte(`{"items": [{"id": 20, "name": "Juggernaut XL"}, {"id": 21, "name": "Juggernaut Reborn"}], "metadata": {}}`))
+		default:
+			w.Write([]byte(`{"items": [], "metadata": {}}`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClientWithoutAuth(WithBaseURL(server.URL))
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		query       string
+		expectedID  int
+		expectedErr error
+		expectError bool
+	}{
+		{name: "Exact case-insensitive match", query: "Realistic Vision", expectedID: 2},
+		{name: "Slug match", query: "realistic-vision-v60-b1", expectedID: 1},
+		{name: "Single partial match", query: "realistic-vision-v60", expectedID: 1},
+		{name: "Ambiguous exact matches", query: "DreamShaper", expectedErr: ErrAmbiguous, expectError: true},
+		{name: "Ambiguous partial matches", query: "Juggernaut", expectedErr: ErrAmbiguous, expectError: true},
+		{name: "No match", query: "Nonexistent Model", expectError: true},
+		{name: "Empty name", query: "  ", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model, err := client.FindModelByName(ctx, tt.query)
+			if tt.expectError {
+				if err == nil {
+					t.Fatalf("Expected error, got model %d", model.ID)
+				}
+				if tt.expectedErr != nil && !errors.Is(err, tt.expectedErr) {
+					t.Errorf("Expected %v, got %v", tt.expectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("FindModelByName failed: %v", err)
+			}
+			if model.ID != tt.expectedID {
+				t.Errorf("Expected model %d, got %d", tt.expectedID, model.ID)
+			}
+		})
+	}
+}
+
+func TestWithDefaultPeriod(t *testing.T) {
+	var lastPeriod string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lastPeriod = r.URL.Query().Get("period")
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"items": [{"id": 1, "name": "Old Model"}], "metadata": {}}`))
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	client := NewClientWithoutAuth(WithBaseURL(server.URL), WithDefaultPeriod(PeriodWeek))
+
+	t.Run("Applied to browsing helpers", func(t *testing.T) {
+		if _, err := client.GetPopularModels(ctx, 5); err != nil {
+			t.Fatalf("GetPopularModels failed: %v", err)
+		}
+		if lastPeriod != string(PeriodWeek) {
+			t.Errorf("Expected period %s, got %q", PeriodWeek, lastPeriod)
+		}
+
+		if _, err := client.GetSafeImages(ctx, 5); err != nil {
+			t.Fatalf("GetSafeImages failed: %v", err)
+		}
+		if lastPeriod != string(PeriodWeek) {
+			t.Errorf("Expected image period %s, got %q", PeriodWeek, lastPeriod)
+		}
+	})
+
+	t.Run("Per-call period takes precedence", func(t *testing.T) {
+		if _, _, err := client.SearchModels(ctx, SearchParams{Period: PeriodAllTime}); err != nil {
+			t.Fatalf("SearchModels failed: %v", err)
+		}
+		if lastPeriod != string(PeriodAllTime) {
+			t.Errorf("Expected period %s, got %q", PeriodAllTime, lastPeriod)
+		}
+
+		if _, _, err := client.GetImages(ctx, ImageParams{Period: PeriodDay}); err != nil {
+			t.Fatalf("GetImages failed: %v", err)
+		}
+		if lastPeriod != string(PeriodDay) {
+			t.Errorf("Expected image period %s, got %q", PeriodDay, lastPeriod)
+		}
+	})
+
+	t.Run("Ignored by lookups", func(t *testing.T) {
+		model, err := client.FindModelByName(ctx, "Old Model")
+		if err != nil {
+			t.Fatalf("FindModelByName failed: %v", err)
+		}
+		if model.ID != 1 {
+			t.Errorf("Expected model 1, got %d", model.ID)
+		}
+		if lastPeriod != "" {
+			t.Errorf("Expected FindModelByName to send no period, got %q", lastPeriod)
+		}
+
+		if _, err := client.GetVersionImages(ctx, 10, 5); err != nil {
+			t.Fatalf("GetVersionImages failed: %v", err)
+		}
+		if lastPeriod != "" {
+			t.Errorf("Expected GetVersionImages to send no period, got %q", lastPeriod)
+		}
+	})
+
+	t.Run("No period without default", func(t *testing.T) {
+		plain := NewClientWithoutAuth(WithBaseURL(server.URL))
+		if _, err := plain.GetPopularModels(ctx, 5); err != nil {
+			t.Fatalf("GetPopularModels failed: %v", err)
+		}
+		if lastPeriod != "" {
+			t.Errorf("Expected no period, got %q", lastPeriod)
+		}
+	})
+}
+
+func TestGetCreatorsWithModels(t *testing.T) {
+	var creatorCalls, inFlight, maxInFlight int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.Contains(r.URL.Path, "/creators"):
+			// Simulate the flaky creators endpoint failing once
+			if atomic.AddInt32(&creatorCalls, 1) == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.Write([]byte(`{"items": [{"username": "alice"}, {"username": "bob"}, {"username": "carol"},
+				{"username": "dave"}, {"username": "erin"}, {"username": "broken"}, {"username": ""}], "metadata": {}}`))
+		case strings.Contains(r.URL.Path, "/models"):
+			current := atomic.AddInt32(&inFlight, 1)
+			defer atomic.AddInt32(&inFlight, -1)
+			for {
+				max := atomic.LoadInt32(&maxInFlight)
+				if current <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, current) {
+					break
+				}
+			}
+			time.Sleep(20 * time.Millisecond)
+
+			username := r.URL.Query().Get("username")
+			if username == "broken" {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"error": "bad user"}`))
+				return
+			}
+			if r.URL.Query().Get("limit") != "2" {
+				t.Errorf("Expected limit 2, got %q", r.URL.Query().Get("limit"))
+			}
+			fmt.Fprintf(w, `{"items": [{"id": 1, "name": "%s model", "creator": {"username": "%s"}}], "metadata": {}}`, username, username)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClientWithoutAuth(WithBaseURL(server.URL), WithRetryConfig(1, time.Millisecond, time.Millisecond))
+
+	byCreator, err := client.GetCreatorsWithModels(context.Background(), CreatorParams{Limit: 10}, 2)
+	if err == nil || !strings.Contains(err.Error(), `creator "broken"`) {
+		t.Errorf("Expected error for creator 'broken', got %v", err)
+	}
+	if len(byCreator) != 5 {
+		t.Fatalf("Expected 5 creators with models, got %d", len(byCreator))
+	}
+	if models := byCreator["alice"]; len(models) != 1 || models[0].Name != "alice model" {
+		t.Errorf("Expected alice's model, got %+v", models)
+	}
+	if _, ok := byCreator["broken"]; ok {
+		t.Error("Expected failed creator to be omitted")
+	}
+	if creatorCalls != 2 {
+		t.Errorf("Expected creators request to be retried once, got %d calls", creatorCalls)
+	}
+	if maxInFlight > creatorModelsConcurrency {
+		t.Errorf("Expected at most %d concurrent model searches, got %d", creatorModelsConcurrency, maxInFlight)
+	}
+
+	t.Run("Reports progress per creator", func(t *testing.T) {
+		var mu sync.Mutex
+		var events []ProgressEvent
+		reporting := NewClientWithoutAuth(WithBaseURL(server.URL), WithRetryConfig(0, 0, 0), WithProgressReporter(func(event ProgressEvent) {
+			mu.Lock()
+			defer mu.Unlock()
+			events = append(events, event)
+		}))
+
+		reporting.GetCreatorsWithModels(context.Background(), CreatorParams{Limit: 10}, 2)
+
+		if len(events) != 6 {
+			t.Fatalf("Expected 6 progress events, got %d", len(events))
+		}
+		maxProcessed := 0
+		for _, event := range events {
+			if event.Operation != "GetCreatorsWithModels" || event.Total != 6 {
+				t.Errorf("Expected GetCreatorsWithModels events with total 6, got %+v", event)
+			}
+			if event.Processed > maxProcessed {
+				maxProcessed = event.Processed
+			}
+		}
+		if maxProcessed != 6 {
+			t.Errorf("Expected 6 creators processed, got %d", maxProcessed)
+		}
+	})
+
+	t.Run("Invalid models per creator", func(t *testing.T) {
+		if _, err := client.GetCreatorsWithModels(context.Background(), CreatorParams{}, 0); err == nil {
+			t.Error("Expected error for zero models per creator")
+		}
+	})
+}
+
+func TestGetCreator(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/creators" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Query().Get("query") {
+		case "alice":
+			w.Write([]byte(`{"items": [{"username": "alice_art", "modelCount": 9}, {"username": "Alice", "modelCount": 1},
+				{"username": "alice", "modelCount": 4, "link": "https://civitai.com/api/v1/models?username=alice"}], "metadata": {}}`))
+		case "BOB":
+			w.Write([]byte(`{"items": [{"username": "bobby"}, {"username": "bob", "modelCount": 2}], "metadata": {}}`))
+		case "dave":
+			if r.URL.Query().Get("page") == "2" {
+				w.Write([]byte(`{"items": [{"username": "dave", "modelCount": 3}], "metadata": {"currentPage": 2, "totalPages": 2}}`))
+				return
+			}
+			w.Write([]byte(`{"items": [{"username": "Dave"}, {"username": "dave_art"}], "metadata": {"currentPage": 1, "totalPages": 2}}`))
+		case "erin":
+			if r.URL.Query().Get("cursor") == "next" {
+				w.Write([]byte(`{"items": [{"username": "erin", "modelCount": 5}], "metadata": {}}`))
+				return
+			}
+			w.Write([]byte(`{"items": [{"username": "erin_ai"}], "metadata": {"nextCursor": "next"}}`))
+		default:
+			w.Write([]byte(`{"items": [{"username": "carolina"}], "metadata": {}}`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClientWithoutAuth(WithBaseURL(server.URL))
+	ctx := context.Background()
+
+	t.Run("Exact match among partial matches", func(t *testing.T) {
+		creator, err := client.GetCreator(ctx, "alice")
+		if err != nil {
+			t.Fatalf("GetCreator failed: %v", err)
+		}
+		if creator.Username != "alice" || creator.ModelCount != 4 {
+			t.Errorf("Expected alice with 4 models, got %s with %d", creator.Username, creator.ModelCount)
+		}
+	})
+
+	t.Run("Falls back to a case-insensitive match", func(t *testing.T) {
+		creator, err := client.GetCreator(ctx, "BOB")
+		if err != nil {
+			t.Fatalf("GetCreator failed: %v", err)
+		}
+		if creator.Username != "bob" {
+			t.Errorf("Expected bob, got %s", creator.Username)
+		}
+	})
+
+	t.Run("Exact match on a later page", func(t *testing.T) {
+		creator, err := client.GetCreator(ctx, "dave")
+		if err != nil {
+			t.Fatalf("GetCreator failed: %v", err)
+		}
+		if creator.Username != "dave" || creator.ModelCount != 3 {
+			t.Errorf("Expected dave with 3 models from page 2, got %s with %d", creator.Username, creator.ModelCount)
+		}
+	})
+
+	t.Run("Follows cursors", func(t *testing.T) {
+		creator, err := client.GetCreator(ctx, "erin")
+		if err != nil {
+			t.Fatalf("GetCreator failed: %v", err)
+		}
+		if creator.Username != "erin" || creator.ModelCount != 5 {
+			t.Errorf("Expected erin with 5 models, got %s with %d", creator.Username, creator.ModelCount)
+		}
+	})
+
+	t.Run("Not found", func(t *testing.T) {
+		_, err := client.GetCreator(ctx, "carol")
+		if !errors.Is(err, ErrNotFound) {
+			t.Errorf("Expected ErrNotFound, got %v", err)
+		}
+	})
+
+	t.Run("Empty username", func(t *testing.T) {
+		if _, err := client.GetCreator(ctx, " "); err == nil || errors.Is(err, ErrNotFound) {
+			t.Errorf("Expected a validation error, got %v", err)
+		}
+	})
+}
+
+func TestWithBaseModelAlias(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/models":
+			w.Write([]byte(`{"items": [{"id": 1, "modelVersions": [{"id": 10, "baseModel": "XL Base"},
+				{"id": 11, "baseModel": "SD 1.5"}]}], "metadata": {}}`))
+		case "/model-versions/20":
+			w.Write([]byte(`{"id": 20, "baseModel": "sdxl"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	client := NewClientWithoutAuth(WithBaseURL(server.URL),
+		WithBaseModelAlias("xl-base", BaseModelSDXL),
+		WithBaseModelAlias("SDXL", "SDXL Family"),
+		WithBaseModelFilterClientSide())
+
+	t.Run("Applied to search results", func(t *testing.T) {
+		models, _, err := client.SearchModels(ctx, SearchParams{BaseModels: []BaseModel{"XL Base"}})
+		if err != nil {
+			t.Fatalf("SearchModels failed: %v", err)
+		}
+		if len(models) != 1 || len(models[0].ModelVersions) != 1 {
+			t.Fatalf("Expected 1 model with 1 matching version, got %+v", models)
+		}
+		if got := models[0].ModelVersions[0].BaseModel; got != BaseModelSDXL {
+			t.Errorf("Expected %q, got %q", BaseModelSDXL, got)
+		}
+	})
+
+	t.Run("Covers every spelling of a built-in base model", func(t *testing.T) {
+		version, err := client.GetModelVersion(ctx, 20)
+		if err != nil {
+			t.Fatalf("GetModelVersion failed: %v", err)
+		}
+		if version.BaseModel != "SDXL Family" {
+			t.Errorf("Expected SDXL Family, got %q", version.BaseModel)
+		}
+	})
+
+	t.Run("Scoped to the client", func(t *testing.T) {
+		plain := NewClientWithoutAuth(WithBaseURL(server.URL))
+		version, err := plain.GetModelVersion(ctx, 20)
+		if err != nil {
+			t.Fatalf("GetModelVersion failed: %v", err)
+		}
+		if version.BaseModel != BaseModelSDXL {
+			t.Errorf("Expected %q, got %q", BaseModelSDXL, version.BaseModel)
+		}
+		if got := NormalizeBaseModel("XL Base"); got != "XL Base" {
+			t.Errorf("Expected package normalization to ignore client aliases, got %q", got)
+		}
+	})
+
+	t.Run("Same result on every path", func(t *testing.T) {
+		version := `{"id": 30, "baseModel": "SDXL 1.0"}`
+		everywhere := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			switch {
+			case r.URL.Path == "/models":
+				w.Write([]byte(`{"items": [{"id": 3, "modelVersions": [` + version + `]}], "metadata": {}}`))
+			case r.URL.Path == "/models/3":
+				w.Write([]byte(`{"id": 3, "modelVersions": [` + version + `]}`))
+			case r.URL.Path == "/models/3/versions":
+				w.Write([]byte(`[` + version + `]`))
+			case strings.HasPrefix(r.URL.Path, "/model-versions/"):
+				w.Write([]byte(version))
+			default:
+				http.NotFound(w, r)
+			}
+		}))
+		defer everywhere.Close()
+
+		for _, tc := range []struct {
+			name     string
+			opts     []ClientOption
+			expected BaseModel
+		}{
+			{"Without aliases", nil, BaseModelSDXL},
+			{"With aliases", []ClientOption{WithBaseModelAlias("SDXL", "SDXL Family")}, "SDXL Family"},
+		} {
+			c := NewClientWithoutAuth(append([]ClientOption{WithBaseURL(everywhere.URL)}, tc.opts...)...)
+			got := map[string]BaseModel{}
+
+			if models, _, err := c.SearchModels(ctx, SearchParams{}); err == nil && len(models) == 1 && len(models[0].ModelVersions) == 1 {
+				got["SearchModels"] = models[0].ModelVersions[0].BaseModel
+			}
+			if model, err := c.GetModel(ctx, 3); err == nil && len(model.ModelVersions) == 1 {
+				got["GetModel"] = model.ModelVersions[0].BaseModel
+			}
+			if versions, err := c.GetModelVersionsByModelID(ctx, 3); err == nil && len(versions) == 1 {
+				got["GetModelVersionsByModelID"] = versions[0].BaseModel
+			}
+			if v, err := c.GetModelVersion(ctx, 30); err == nil {
+				got["GetModelVersion"] = v.BaseModel
+			}
+			if v, err := c.GetModelVersionByHash(ctx, "ABCDEF0123456789"); err == nil {
+				got["GetModelVersionByHash"] = v.BaseModel
+			}
+
+			if len(got) != 5 {
+				t.Fatalf("%s: expected 5 results, got %v", tc.name, got)
+			}
+			for path, baseModel := range got {
+				if baseModel != tc.expected {
+					t.Errorf("%s: expected %s to return %q, got %q", tc.name, path, tc.expected, baseModel)
+				}
+			}
+		}
+	})
+}
+
+func TestWithDefaultTypes(t *testing.T) {
+	var lastQuery url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lastQuery = r.URL.Query()
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"items": [{"id": 7, "name": "Base Checkpoint", "type": "Checkpoint"}], "metadata": {}}`))
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	client := NewClientWithoutAuth(WithBaseURL(server.URL), WithDefaultTypes(ModelTypeCheckpoint, ModelTypeLORA))
+
+	t.Run("Defaults used when types unset", func(t *testing.T) {
+		if _, _, err := client.SearchModels(ctx, SearchParams{Query: "anime"}); err != nil {
+			t.Fatalf("SearchModels failed: %v", err)
+		}
+		if got := lastQuery.Get("types"); got != "Checkpoint,LORA" {
+			t.Errorf("Expected types Checkpoint,LORA, got %q", got)
+		}
+	})
+
+	t.Run("Helpers use defaults", func(t *testing.T) {
+		if _, err := client.GetPopularModels(ctx, 5); err != nil {
+			t.Fatalf("GetPopularModels failed: %v", err)
+		}
+		if got := lastQuery.Get("types"); got != "Checkpoint,LORA" {
+			t.Errorf("Expected types Checkpoint,LORA, got %q", got)
+		}
+	})
+
+	t.Run("Per-call types override", func(t *testing.T) {
+		if _, _, err := client.SearchModels(ctx, SearchParams{Types: []ModelType{ModelTypeVAE}}); err != nil {
+			t.Fatalf("SearchModels failed: %v", err)
+		}
+		if got := lastQuery.Get("types"); got != "VAE" {
+			t.Errorf("Expected types VAE, got %q", got)
+		}
+	})
+
+	t.Run("Ignored by lookups", func(t *testing.T) {
+		loraOnly := NewClientWithoutAuth(WithBaseURL(server.URL), WithDefaultTypes(ModelTypeLORA))
+		model, err := loraOnly.FindModelByName(ctx, "Base Checkpoint")
+		if err != nil {
+			t.Fatalf("FindModelByName failed: %v", err)
+		}
+		if model.ID != 7 {
+			t.Errorf("Expected model 7, got %d", model.ID)
+		}
+		if got := lastQuery.Get("types"); got != "" {
+			t.Errorf("Expected FindModelByName to send no types, got %q", got)
+		}
+	})
+
+	t.Run("No types without default", func(t *testing.T) {
+		plain := NewClientWithoutAuth(WithBaseURL(server.URL))
+		if _, _, err := plain.SearchModels(ctx, SearchParams{}); err != nil {
+			t.Fatalf("SearchModels failed: %v", err)
+		}
+		if got := lastQuery.Get("types"); got != "" {
+			t.Errorf("Expected no types, got %q", got)
+		}
+	})
+
+	t.Run("Unknown default type", func(t *testing.T) {
+		lastQuery = nil
+		bad := NewClientWithoutAuth(WithBaseURL(server.URL), WithDefaultTypes(ModelTypeLORA, "Lora"))
+		_, _, err := bad.SearchModels(ctx, SearchParams{})
+		if err == nil || !strings.Contains(err.Error(), "unknown model type") {
+			t.Errorf("Expected unknown model type error, got %v", err)
+		}
+		if lastQuery != nil {
+			t.Error("Expected no request to be made")
+		}
+
+		if _, _, err := bad.SearchModels(ctx, SearchParams{Types: []ModelType{ModelTypeLORA}}); err != nil {
+			t.Errorf("Expected per-call types to bypass the invalid default, got %v", err)
+		}
+	})
+}
+
+func TestWithDefaultLimit(t *testing.T) {
+	var lastLimit string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lastLimit = r.URL.Query().Get("limit")
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"items": [], "metadata": {}}`))
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	client := NewClientWithoutAuth(WithBaseURL(server.URL), WithDefaultLimit(25))
+
+	endpoints := map[string]func(limit int) error{
+		"SearchModels": func(limit int) error {
+			_, _, err := client.SearchModels(ctx, SearchParams{Limit: limit})
+			return err
+		},
+		"GetImages": func(limit int) error {
+			_, _, err := client.GetImages(ctx, ImageParams{Limit: limit})
+			return err
+		},
+		"GetCreators": func(limit int) error {
+			_, _, err := client.GetCreators(ctx, CreatorParams{Limit: limit})
+			return err
+		},
+		"GetTags": func(limit int) error {
+			_, _, err := client.GetTags(ctx, TagParams{Limit: limit})
+			return err
+		},
+	}
+
+	for name, call := range endpoints {
+		t.Run(name, func(t *testing.T) {
+			if err := call(0); err != nil {
+				t.Fatalf("%s failed: %v", name, err)
+			}
+			if lastLimit != "25" {
+				t.Errorf("Expected default limit 25, got %q", lastLimit)
+			}
+
+			if err := call(5); err != nil {
+				t.Fatalf("%s failed: %v", name, err)
+			}
+			if lastLimit != "5" {
+				t.Errorf("Expected per-call limit 5, got %q", lastLimit)
+			}
+		})
+	}
+
+	t.Run("Rejected above MaxLimit", func(t *testing.T) {
+		oversized := NewClientWithoutAuth(WithBaseURL(server.URL), WithDefaultLimit(500))
+		calls := map[string]func(limit int) error{
+			"SearchModels": func(limit int) error {
+				_, _, err := oversized.SearchModels(ctx, SearchParams{Limit: limit})
+				return err
+			},
+			"GetImages": func(limit int) error {
+				_, _, err := oversized.GetImages(ctx, ImageParams{Limit: limit})
+				return err
+			},
+			"GetCreators": func(limit int) error {
+				_, _, err := oversized.GetCreators(ctx, CreatorParams{Limit: limit})
+				return err
+			},
+			"GetTags": func(limit int) error {
+				_, _, err := oversized.GetTags(ctx, TagParams{Limit: limit})
+				return err
+			},
+		}
+
+		for name, call := range calls {
+			lastLimit = "unset"
+			if err := call(0); err == nil || !strings.Contains(err.Error(), "invalid default limit") {
+				t.Errorf("%s: expected invalid default limit error, got %v", name, err)
+			}
+			if lastLimit != "unset" {
+				t.Errorf("%s: expected no request, got limit %q", name, lastLimit)
+			}
+
+			if err := call(10); err != nil {
+				t.Errorf("%s: expected per-call limit to bypass the default, got %v", name, err)
+			}
+			if lastLimit != "10" {
+				t.Errorf("%s: expected limit 10, got %q", name, lastLimit)
+			}
+		}
+	})
+
+	t.Run("No limit without default", func(t *testing.T) {
+		plain := NewClientWithoutAuth(WithBaseURL(server.URL))
+		if _, _, err := plain.GetTags(ctx, TagParams{}); err != nil {
+			t.Fatalf("GetTags failed: %v", err)
+		}
+		if lastLimit != "" {
+			t.Errorf("Expected no limit, got %q", lastLimit)
+		}
+	})
+}
+
+func TestGetGenerationModels(t *testing.T) {
+	var lastQuery url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lastQuery = r.URL.Query()
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"items": [{"id": 1, "name": "Gen Model"}], "metadata": {}}`))
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	client := NewClientWithoutAuth(WithBaseURL(server.URL))
+
+	t.Run("Sets supportsGeneration", func(t *testing.T) {
+		models, _, err := client.GetGenerationModels(ctx, SearchParams{Query: "anime", Limit: 5})
+		if err != nil {
+			t.Fatalf("GetGenerationModels failed: %v", err)
+		}
+		if len(models) != 1 {
+			t.Errorf("Expected 1 model, got %d", len(models))
+		}
+		if got := lastQuery.Get("supportsGeneration"); got != "true" {
+			t.Errorf("Expected supportsGeneration=true, got %q", got)
+		}
+		if got := lastQuery.Get("query"); got != "anime" {
+			t.Errorf("Expected query anime, got %q", got)
+		}
+	})
+
+	t.Run("Rejects SupportsGeneration=false", func(t *testing.T) {
+		lastQuery = nil
+		no := false
+		if _, _, err := client.GetGenerationModels(ctx, SearchParams{SupportsGeneration: &no}); err == nil {
+			t.Error("Expected error for SupportsGeneration=false")
+		}
+		if lastQuery != nil {
+			t.Error("Expected no request to be made")
+		}
+	})
+}
+
+func TestWithBaseModelFilterClientSide(t *testing.T) {
+	var lastQuery url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lastQuery = r.URL.Query()
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"items": [
+			{"id": 1, "name": "Mixed", "modelVersions": [
+				{"id": 11, "baseModel": "SD 1.5"},
+				{"id": 12, "baseModel": "SDXL 1.0"}
+			]},
+			{"id": 2, "name": "SD only", "modelVersions": [{"id": 21, "baseModel": "SD 1.5"}]},
+			{"id": 3, "name": "XL only", "modelVersions": [{"id": 31, "baseModel": "sdxl"}]}
+		], "metadata": {"nextPage": "next"}}`))
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	params := SearchParams{BaseModels: []BaseModel{"SDXL"}}
+
+	t.Run("Prunes non-matching versions and models", func(t *testing.T) {
+		client := NewClientWithoutAuth(WithBaseURL(server.URL), WithBaseModelFilterClientSide())
+		models, metadata, err := client.SearchModels(ctx, params)
+		if err != nil {
+			t.Fatalf("SearchModels failed: %v", err)
+		}
+		if got := lastQuery.Get("baseModels"); got != "SDXL" {
+			t.Errorf("Expected baseModels=SDXL, got %q", got)
+		}
+		if len(models) != 2 {
+			t.Fatalf("Expected 2 models, got %d", len(models))
+		}
+		if models[0].ID != 1 || len(models[0].ModelVersions) != 1 || models[0].ModelVersions[0].ID != 12 {
+			t.Errorf("Expected model 1 with only version 12, got %+v", models[0].ModelVersions)
+		}
+		if models[1].ID != 3 {
+			t.Errorf("Expected model 3, got %d", models[1].ID)
+		}
+		if metadata == nil || metadata.NextPage != "next" {
+			t.Error("Expected metadata to be preserved")
+		}
+	})
+
+	t.Run("Disabled by default", func(t *testing.T) {
+		client := NewClientWithoutAuth(WithBaseURL(server.URL))
+		models, _, err := client.SearchModels(ctx, params)
+		if err != nil {
+			t.Fatalf("SearchModels failed: %v", err)
+		}
+		if len(models) != 3 {
+			t.Errorf("Expected 3 unfiltered models, got %d", len(models))
+		}
+	})
+
+	t.Run("No base models leaves results alone", func(t *testing.T) {
+		client := NewClientWithoutAuth(WithBaseURL(server.URL), WithBaseModelFilterClientSide())
+		models, _, err := client.SearchModels(ctx, SearchParams{})
+		if err != nil {
+			t.Fatalf("SearchModels failed: %v", err)
+		}
+		if len(models) != 3 || len(models[0].ModelVersions) != 2 {
+			t.Errorf("Expected unfiltered results, got %d models", len(models))
+		}
+	})
+}
+
+func TestSearchModelsPrev(t *testing.T) {
+	var lastQuery url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lastQuery = r.URL.Query()
+		page := 1
+		if cursor := lastQuery.Get("cursor"); cursor != "" {
+			fmt.Sscanf(cursor, "p%d", &page)
+		} else if p := lastQuery.Get("page"); p != "" {
+			fmt.Sscanf(p, "%d", &page)
+		}
+
+		meta := fmt.Sprintf(`"currentPage": %d`, page)
+		if page < 3 {
+			meta += fmt.Sprintf(`, "nextCursor": "p%d"`, page+1)
+		}
+		if page > 1 {
+			meta += fmt.Sprintf(`, "prevCursor": "p%d"`, page-1)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"items": [{"id": %d}, {"id": %d}], "metadata": {%s}}`, page*2-1, page*2, meta)
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	client := NewClientWithoutAuth(WithBaseURL(server.URL))
+	params := SearchParams{Query: "anime", Limit: 2}
+
+	t.Run("Forward then backward", func(t *testing.T) {
+		_, meta, err := client.SearchModels(ctx, params)
+		if err != nil {
+			t.Fatalf("SearchModels failed: %v", err)
+		}
+		for meta.NextCursor != "" {
+			next := params
+			next.Cursor = meta.NextCursor
+			if _, meta, err = client.SearchModels(ctx, next); err != nil {
+				t.Fatalf("SearchModels failed: %v", err)
+			}
+		}
+		if meta.CurrentPage != 3 {
+			t.Fatalf("Expected to reach page 3, got %d", meta.CurrentPage)
+		}
+
+		for _, expectedFirstID := range []int{3, 1} {
+			var models []Model
+			models, meta, err = client.SearchModelsPrev(ctx, params, meta)
+			if err != nil {
+				t.Fatalf("SearchModelsPrev failed: %v", err)
+			}
+			if len(models) != 2 || models[0].ID != expectedFirstID {
+				t.Errorf("Expected page starting at model %d, got %+v", expectedFirstID, models)
+			}
+			if got := lastQuery.Get("query"); got != "anime" {
+				t.Errorf("Expected query anime to be kept, got %q", got)
+			}
+		}
+
+		if _, _, err := client.SearchModelsPrev(ctx, params, meta); !errors.Is(err, ErrNoPreviousPage) {
+			t.Errorf("Expected ErrNoPreviousPage on the first page, got %v", err)
+		}
+	})
+
+	t.Run("Falls back to page numbers", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			meta     *Metadata
+			expected string
+		}{
+			{"Prev page URL", &Metadata{CurrentPage: 9, PrevPage: server.URL + "/models?page=2&limit=2"}, "2"},
+			{"Current page", &Metadata{CurrentPage: 3}, "2"},
+		}
+		for _, tt := range tests {
+			lastQuery = nil
+			if _, _, err := client.SearchModelsPrev(ctx, SearchParams{Cursor: "stale"}, tt.meta); err != nil {
+				t.Fatalf("%s: SearchModelsPrev failed: %v", tt.name, err)
+			}
+			if got := lastQuery.Get("page"); got != tt.expected {
+				t.Errorf("%s: Expected page %s, got %q", tt.name, tt.expected, got)
+			}
+			if got := lastQuery.Get("cursor"); got != "" {
+				t.Errorf("%s: Expected no cursor, got %q", tt.name, got)
+			}
+		}
+	})
+
+	t.Run("No previous page", func(t *testing.T) {
+		for _, meta := range []*Metadata{nil, {}, {CurrentPage: 1}} {
+			lastQuery = nil
+			if _, _, err := client.SearchModelsPrev(ctx, params, meta); !errors.Is(err, ErrNoPreviousPage) {
+				t.Errorf("Expected ErrNoPreviousPage for %+v, got %v", meta, err)
+			}
+			if lastQuery != nil {
+				t.Error("Expected no request to be made")
+			}
+		}
+	})
+}
+
+func TestGetLatestVersion(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/models/1/versions":
+			w.Write([]byte(`[
+				{"id": 11, "name": "v1.0", "createdAt": "2024-01-10T00:00:00Z"},
+				{"id": 13, "name": "v3.0", "createdAt": "2024-06-01T00:00:00Z"},
+				{"id": 12, "name": "v2.0", "createdAt": "2024-03-15T00:00:00Z"}
+			]`))
+		case "/models/2/versions":
+			w.Write([]byte(`[]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error": "Model not found"}`))
+		}
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	client := NewClientWithoutAuth(WithBaseURL(server.URL))
+
+	t.Run("Returns newest version", func(t *testing.T) {
+		version, err := client.GetLatestVersion(ctx, 1)
+		if err != nil {
+			t.Fatalf("GetLatestVersion failed: %v", err)
+		}
+		if version.ID != 13 || version.Name != "v3.0" {
+			t.Errorf("Expected version 13 (v3.0), got %d (%s)", version.ID, version.Name)
+		}
+	})
+
+	t.Run("No versions", func(t *testing.T) {
+		_, err := client.GetLatestVersion(ctx, 2)
+		if !errors.Is(err, ErrNotFound) {
+			t.Errorf("Expected ErrNotFound, got %v", err)
+		}
+	})
+
+	t.Run("Unknown model", func(t *testing.T) {
+		_, err := client.GetLatestVersion(ctx, 3)
+		if !errors.Is(err, ErrNotFound) {
+			t.Errorf("Expected ErrNotFound for a 404, got %v", err)
+		}
+	})
+
+	t.Run("Invalid ID", func(t *testing.T) {
+		_, err := client.GetLatestVersion(ctx, 0)
+		if err == nil || errors.Is(err, ErrNotFound) {
+			t.Errorf("Expected validation error, got %v", err)
+		}
+	})
+}
+
+func TestSearchModelsAuthRequired(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("favorites") == "true" || r.URL.Query().Get("hidden") == "true" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"error": "Unauthorized"}`))
+			return
+		}
+		if r.URL.Query().Get("query") == "forbidden" {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"error": "Forbidden"}`))
+			return
+		}
+		w.Write([]byte(`{"items": [], "metadata": {}}`))
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+
+	t.Run("Unauthenticated favorites search", func(t *testing.T) {
+		client := NewClientWithoutAuth(WithBaseURL(server.URL), WithRetryConfig(0, 0, 0))
+		_, _, err := client.SearchModels(ctx, SearchParams{Favorites: true})
+		if !errors.Is(err, ErrAuthRequired) {
+			t.Fatalf("Expected ErrAuthRequired, got %v", err)
+		}
+		if !strings.Contains(err.Error(), "NewClient(token)") {
+			t.Errorf("Expected guidance to configure a token, got %v", err)
+		}
+		if responseStatus(err) != http.StatusUnauthorized {
+			t.Errorf("Expected wrapped 401 status, got %d", responseStatus(err))
+		}
+	})
+
+	t.Run("Rejected token on hidden search", func(t *testing.T) {
+		client := NewClient("bad-token", WithBaseURL(server.URL), WithRetryConfig(0, 0, 0))
+		_, _, err := client.SearchModels(ctx, SearchParams{Hidden: true})
+		if !errors.Is(err, ErrAuthRequired) || !strings.Contains(err.Error(), "valid API token") {
+			t.Errorf("Expected invalid token guidance, got %v", err)
+		}
+	})
+
+	t.Run("Other searches unchanged", func(t *testing.T) {
+		client := NewClientWithoutAuth(WithBaseURL(server.URL), WithRetryConfig(0, 0, 0))
+		_, _, err := client.SearchModels(ctx, SearchParams{Query: "forbidden"})
+		if err == nil || errors.Is(err, ErrAuthRequired) {
+			t.Errorf("Expected plain 403 error, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,336 @@
+/*
+Copyright (c) 2025 Regi Ellis
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+// Package civitai - Response Caching
+//
+// This file provides an optional in-memory cache for API GET requests. Cached
+// responses are served without a request while fresh; once stale, responses
+// that carried an ETag are revalidated with If-None-Match so an unchanged
+// resource costs only a 304 Not Modified round trip.
+//
+// # Enabling the Cache
+//
+//	// Serve repeated requests from memory for five minutes
+//	client := civitai.NewClientWithoutAuth(civitai.WithCache(5 * time.Minute))
+//
+//	// Revalidate every request, relying on ETags to avoid re-downloading
+//	client = civitai.NewClientWithoutAuth(civitai.WithCache(0))
+//
+// When the server sends no ETag, entries simply expire after the TTL and the
+// next request fetches the resource again. File downloads are never cached.
+//
+// # Hash Lookups
+//
+// With the cache enabled, GetModelVersionByHash results are also cached by
+// hash, case-insensitively, so re-identifying a library of local files only
+// hits the API for new hashes. Because a file hash always identifies the same
+// version, found results are kept for 24 hours; hashes the API doesn't know are
+// remembered for 5 minutes. Both can be tuned:
+//
+//	client := civitai.NewClientWithoutAuth(
+//		civitai.WithCache(5*time.Minute),
+//		civitai.WithHashCacheTTL(7*24*time.Hour, time.Minute),
+//	)
+//
+// # Cache Statistics
+//
+// CacheStats reports how many lookups were served from the cache and how many
+// went to the API, which helps when tuning TTLs. A stale entry revalidated
+// with a 304 counts as a hit. With WithResponseMetrics the same counts appear
+// in ResponseMetrics.CacheHits and CacheMisses.
+//
+//	hits, misses := client.CacheStats()
+//	if total := hits + misses; total > 0 {
+//		fmt.Printf("cache hit rate: %.1f%%\n", float64(hits)/float64(total)*100)
+//	}
+
+package civitai
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// defaultCacheMaxEntries bounds the number of cached responses
+const defaultCacheMaxEntries = 1000
+
+const (
+	// DefaultHashCacheTTL is how long GetModelVersionByHash results are cached
+	DefaultHashCacheTTL = 24 * time.Hour
+
+	// DefaultHashNotFoundCacheTTL is how long unknown hashes are remembered
+	DefaultHashNotFoundCacheTTL = 5 * time.Minute
+)
+
+// responseCache stores successful GET response bodies keyed by URL
+type responseCache struct {
+	ttl        time.Duration
+	maxEntries int
+
+	mu      sync.Mutex
+	entries map[string]*cacheEntry
+	hashes  map[string]*hashCacheEntry
+
+	hits   atomic.Int64
+	misses atomic.Int64
+}
+
+// cacheEntry is a cached response body with its validator
+type cacheEntry struct {
+	header   http.Header
+	body     []byte
+	etag     string
+	storedAt time.Time
+}
+
+// hashCacheEntry is a cached GetModelVersionByHash result; version is nil for
+// hashes the API reported as not found, in which case err holds that error
+type hashCacheEntry struct {
+	version  *ModelVersionByHashResponse
+	err      error
+	storedAt time.Time
+}
+
+// WithCache enables in-memory caching of API GET responses for ttl. Stale
+// entries with an ETag are revalidated with a conditional request; a ttl of
+// zero revalidates on every request.
+func WithCache(ttl time.Duration) ClientOption {
+	return func(c *Client) {
+		c.cache = &responseCache{
+			ttl:        ttl,
+			maxEntries: defaultCacheMaxEntries,
+			entries:    make(map[string]*cacheEntry),
+			hashes:     make(map[string]*hashCacheEntry),
+		}
+	}
+}
+
+// WithHashCacheTTL sets how long WithCache keeps GetModelVersionByHash results
+// for known hashes (found) and for hashes the API doesn't know (notFound).
+// The defaults are DefaultHashCacheTTL and DefaultHashNotFoundCacheTTL.
+func WithHashCacheTTL(found, notFound time.Duration) ClientOption {
+	return func(c *Client) {
+		c.hashCacheTTL = found
+		c.hashNotFoundCacheTTL = notFound
+	}
+}
+
+// ClearCache removes all cached responses
+func (c *Client) ClearCache() {
+	if c.cache == nil {
+		return
+	}
+	c.cache.mu.Lock()
+	defer c.cache.mu.Unlock()
+	c.cache.entries = make(map[string]*cacheEntry)
+	c.cache.hashes = make(map[string]*hashCacheEntry)
+}
+
+// CacheStats returns the number of cache hits and misses since the client was
+// created; both are zero when WithCache isn't enabled
+func (c *Client) CacheStats() (hits, misses int64) {
+	if c.cache == nil {
+		return 0, 0
+	}
+	return c.cache.hits.Load(), c.cache.misses.Load()
+}
+
+// recordCacheLookup counts a cache hit or miss, mirroring it into the
+// WithResponseMetrics metrics when enabled
+func (c *Client) recordCacheLookup(hit bool) {
+	if hit {
+		c.cache.hits.Add(1)
+	} else {
+		c.cache.misses.Add(1)
+	}
+	if c.metrics != nil {
+		c.metrics.recordCacheLookup(hit)
+	}
+}
+
+// doCachedRequest performs a GET request through the response cache
+func (c *Client) doCachedRequest(ctx context.Context, url string) (*http.Response, error) {
+	entry, found := c.cache.get(url)
+	if found && time.Since(entry.storedAt) < c.cache.ttl {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
+		c.recordCacheLookup(true)
+		return entry.response(req), nil
+	}
+
+	var opts requestOptions
+	if found && entry.etag != "" {
+		opts.headers = http.Header{"If-None-Match": []string{entry.etag}}
+	}
+
+	resp, err := c.doRequestWithOptions(ctx, "GET", url, nil, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusNotModified && found {
+		resp.Body.Close()
+		c.cache.touch(url, entry)
+		c.recordCacheLookup(true)
+		return entry.response(resp.Request), nil
+	}
+	c.recordCacheLookup(false)
+	if resp.StatusCode != http.StatusOK {
+		return resp, nil
+	}
+
+	maxSize := c.responseLimit(resp)
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxSize+1))
+	if err != nil {
+		resp.Body.Close()
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	// Leave oversized responses to handleResponse, which reports the limit
+	if int64(len(body)) > maxSize {
+		resp.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(body), resp.Body), resp.Body}
+		return resp, nil
+	}
+	resp.Body.Close()
+
+	entry = &cacheEntry{
+		header:   resp.Header.Clone(),
+		body:     body,
+		etag:     resp.Header.Get("ETag"),
+		storedAt: time.Now(),
+	}
+	c.cache.put(url, entry)
+
+	return entry.response(resp.Request), nil
+}
+
+// cachedVersionByHash returns a fresh cached GetModelVersionByHash result,
+// reporting found as false when there is none
+func (c *Client) cachedVersionByHash(hash string) (version *ModelVersionByHashResponse, found bool, err error) {
+	c.cache.mu.Lock()
+	entry, ok := c.cache.hashes[strings.ToUpper(hash)]
+	c.cache.mu.Unlock()
+	if !ok {
+		return nil, false, nil
+	}
+
+	ttl := c.hashCacheTTL
+	if entry.version == nil {
+		ttl = c.hashNotFoundCacheTTL
+	}
+	if time.Since(entry.storedAt) >= ttl {
+		return nil, false, nil
+	}
+
+	if entry.version == nil {
+		return nil, true, entry.err
+	}
+	stored := *entry.version
+	return &stored, true, nil
+}
+
+// storeVersionByHash caches a GetModelVersionByHash result, or a not-found
+// error when version is nil
+func (c *Client) storeVersionByHash(hash string, version *ModelVersionByHashResponse, err error) {
+	entry := &hashCacheEntry{err: err, storedAt: time.Now()}
+	if version != nil {
+		stored := *version
+		entry.version = &stored
+	}
+
+	c.cache.mu.Lock()
+	defer c.cache.mu.Unlock()
+
+	key := strings.ToUpper(hash)
+	if _, exists := c.cache.hashes[key]; !exists && len(c.cache.hashes) >= c.cache.maxEntries {
+		var oldestKey string
+		var oldest time.Time
+		for k, e := range c.cache.hashes {
+			if oldestKey == "" || e.storedAt.Before(oldest) {
+				oldestKey, oldest = k, e.storedAt
+			}
+		}
+		delete(c.cache.hashes, oldestKey)
+	}
+
+	c.cache.hashes[key] = entry
+}
+
+// get returns the entry for key, if any
+func (rc *responseCache) get(key string) (*cacheEntry, bool) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	entry, ok := rc.entries[key]
+	return entry, ok
+}
+
+// put stores an entry, evicting the oldest one when the cache is full
+func (rc *responseCache) put(key string, entry *cacheEntry) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	if _, exists := rc.entries[key]; !exists && len(rc.entries) >= rc.maxEntries {
+		var oldestKey string
+		var oldest time.Time
+		for k, e := range rc.entries {
+			if oldestKey == "" || e.storedAt.Before(oldest) {
+				oldestKey, oldest = k, e.storedAt
+			}
+		}
+		delete(rc.entries, oldestKey)
+	}
+
+	rc.entries[key] = entry
+}
+
+// touch marks a revalidated entry as fresh again
+func (rc *responseCache) touch(key string, entry *cacheEntry) {
+	refreshed := *entry
+	refreshed.storedAt = time.Now()
+	rc.put(key, &refreshed)
+}
+
+// response builds a fresh *http.Response serving the cached body as the
+// answer to req, so request-based settings such as WithEndpointResponseLimit
+// still apply
+func (e *cacheEntry) response(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Header:        e.header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(e.body)),
+		ContentLength: int64(len(e.body)),
+		Request:       req,
+	}
+}
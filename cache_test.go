@@ -0,0 +1,364 @@
+/*
+Copyright (c) 2025 Regi Ellis
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package civitai
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestResponseCache(t *testing.T) {
+	const etag = `"v1"`
+	body := `{"id": 123, "name": "Cached Model"}`
+
+	t.Run("ETag revalidation returns stored body on 304", func(t *testing.T) {
+		var requests, notModified int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&requests, 1)
+			if r.Header.Get("If-None-Match") == etag {
+				atomic.AddInt32(&notModified, 1)
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("ETag", etag)
+			w.Write([]byte(body))
+		}))
+		defer server.Close()
+
+		client := NewClientWithoutAuth(WithBaseURL(server.URL), WithCache(0))
+		ctx := context.Background()
+
+		for i := 0; i < 3; i++ {
+			model, err := client.GetModel(ctx, 123)
+			if err != nil {
+				t.Fatalf("GetModel %d failed: %v", i, err)
+			}
+			if model.Name != "Cached Model" {
+				t.Errorf("Request %d: expected cached model name, got %q", i, model.Name)
+			}
+		}
+
+		if requests != 3 {
+			t.Errorf("Expected every request to revalidate, got %d requests", requests)
+		}
+		if notModified != 2 {
+			t.Errorf("Expected 2 conditional 304 responses, got %d", notModified)
+		}
+	})
+
+	t.Run("Fresh entries skip the network", func(t *testing.T) {
+		var requests int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&requests, 1)
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(body))
+		}))
+		defer server.Close()
+
+		client := NewClientWithoutAuth(WithBaseURL(server.URL), WithCache(time.Hour))
+		ctx := context.Background()
+
+		for i := 0; i < 3; i++ {
+			if _, err := client.GetModel(ctx, 123); err != nil {
+				t.Fatalf("GetModel failed: %v", err)
+			}
+		}
+		if requests != 1 {
+			t.Errorf("Expected 1 request within TTL, got %d", requests)
+		}
+
+		client.ClearCache()
+		if _, err := client.GetModel(ctx, 123); err != nil {
+			t.Fatalf("GetModel failed: %v", err)
+		}
+		if requests != 2 {
+			t.Errorf("Expected a request after ClearCache, got %d total", requests)
+		}
+	})
+
+	t.Run("Falls back to TTL without ETag", func(t *testing.T) {
+		var requests, conditional int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&requests, 1)
+			if r.Header.Get("If-None-Match") != "" {
+				atomic.AddInt32(&conditional, 1)
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(body))
+		}))
+		defer server.Close()
+
+		client := NewClientWithoutAuth(WithBaseURL(server.URL), WithCache(20*time.Millisecond))
+		ctx := context.Background()
+
+		client.GetModel(ctx, 123)
+		client.GetModel(ctx, 123)
+		time.Sleep(30 * time.Millisecond)
+		if _, err := client.GetModel(ctx, 123); err != nil {
+			t.Fatalf("GetModel failed: %v", err)
+		}
+
+		if requests != 2 {
+			t.Errorf("Expected a refetch after TTL expiry, got %d requests", requests)
+		}
+		if conditional != 0 {
+			t.Errorf("Expected no conditional requests without an ETag, got %d", conditional)
+		}
+	})
+
+	t.Run("Errors are not cached", func(t *testing.T) {
+		var requests int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&requests, 1)
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error": "not found"}`))
+		}))
+		defer server.Close()
+
+		client := NewClientWithoutAuth(WithBaseURL(server.URL), WithCache(time.Hour))
+		for i := 0; i < 2; i++ {
+			if _, err := client.GetModel(context.Background(), 123); err == nil {
+				t.Error("Expected error for 404 response")
+			}
+		}
+		if requests != 2 {
+			t.Errorf("Expected error responses not to be cached, got %d requests", requests)
+		}
+	})
+
+	t.Run("Evicts oldest entry when full", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(body))
+		}))
+		defer server.Close()
+
+		client := NewClientWithoutAuth(WithBaseURL(server.URL), WithCache(time.Hour))
+		client.cache.maxEntries = 2
+		ctx := context.Background()
+
+		client.GetModel(ctx, 1)
+		client.GetModel(ctx, 2)
+		client.GetModel(ctx, 3)
+
+		if len(client.cache.entries) != 2 {
+			t.Errorf("Expected 2 cached entries, got %d", len(client.cache.entries))
+		}
+		if _, ok := client.cache.get(client.buildURL("models/1")); ok {
+			t.Error("Expected oldest entry to be evicted")
+		}
+	})
+}
+
+func TestModelVersionByHashCache(t *testing.T) {
+	const knownHash = "ABCDEF0123456789"
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		if !strings.HasSuffix(r.URL.Path, "/"+knownHash) && !strings.HasSuffix(r.URL.Path, "/"+strings.ToLower(knownHash)) {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error": "Model not found"}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id": 42, "name": "v1", "model": {"name": "Known Model"}}`))
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+
+	t.Run("Second lookup served from cache", func(t *testing.T) {
+		atomic.StoreInt32(&requests, 0)
+		client := NewClientWithoutAuth(WithBaseURL(server.URL), WithCache(0))
+
+		for _, hash := range []string{knownHash, strings.ToLower(knownHash)} {
+			version, err := client.GetModelVersionByHash(ctx, hash)
+			if err != nil {
+				t.Fatalf("GetModelVersionByHash(%s) failed: %v", hash, err)
+			}
+			if version.ID != 42 || version.Model.Name != "Known Model" {
+				t.Errorf("Expected version 42 of Known Model, got %d of %q", version.ID, version.Model.Name)
+			}
+			version.Name = "modified by caller"
+		}
+
+		if requests != 1 {
+			t.Errorf("Expected 1 request, got %d", requests)
+		}
+
+		version, _ := client.GetModelVersionByHash(ctx, knownHash)
+		if version.Name != "v1" {
+			t.Errorf("Expected cached version to be isolated from callers, got name %q", version.Name)
+		}
+	})
+
+	t.Run("Not found results are cached", func(t *testing.T) {
+		atomic.StoreInt32(&requests, 0)
+		client := NewClientWithoutAuth(WithBaseURL(server.URL), WithCache(0), WithRetryConfig(0, 0, 0))
+
+		for i := 0; i < 2; i++ {
+			if _, err := client.GetModelVersionByHash(ctx, "0000000000000000"); err == nil {
+				t.Fatal("Expected error for unknown hash")
+			}
+		}
+		if requests != 1 {
+			t.Errorf("Expected 1 request, got %d", requests)
+		}
+	})
+
+	t.Run("Not found TTL expires", func(t *testing.T) {
+		atomic.StoreInt32(&requests, 0)
+		client := NewClientWithoutAuth(WithBaseURL(server.URL), WithCache(0), WithHashCacheTTL(time.Hour, 0), WithRetryConfig(0, 0, 0))
+
+		for i := 0; i < 2; i++ {
+			client.GetModelVersionByHash(ctx, "0000000000000000")
+		}
+		if requests != 2 {
+			t.Errorf("Expected 2 requests, got %d", requests)
+		}
+	})
+
+	t.Run("ClearCache drops hash results", func(t *testing.T) {
+		atomic.StoreInt32(&requests, 0)
+		client := NewClientWithoutAuth(WithBaseURL(server.URL), WithCache(0))
+
+		client.GetModelVersionByHash(ctx, knownHash)
+		client.ClearCache()
+		client.GetModelVersionByHash(ctx, knownHash)
+		if requests != 2 {
+			t.Errorf("Expected 2 requests, got %d", requests)
+		}
+	})
+
+	t.Run("Disabled without WithCache", func(t *testing.T) {
+		atomic.StoreInt32(&requests, 0)
+		client := NewClientWithoutAuth(WithBaseURL(server.URL))
+
+		client.GetModelVersionByHash(ctx, knownHash)
+		client.GetModelVersionByHash(ctx, knownHash)
+		if requests != 2 {
+			t.Errorf("Expected 2 requests, got %d", requests)
+		}
+	})
+}
+
+func TestCacheStats(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id": 123, "name": "Cached Model"}`))
+	}))
+	defer server.Close()
+
+	t.Run("Counts hits and misses", func(t *testing.T) {
+		metrics := &ResponseMetrics{}
+		client := NewClientWithoutAuth(
+			WithBaseURL(server.URL),
+			WithCache(time.Hour),
+			WithResponseMetrics(metrics),
+		)
+		ctx := context.Background()
+
+		for i := 0; i < 3; i++ {
+			if _, err := client.GetModel(ctx, 123); err != nil {
+				t.Fatalf("GetModel failed: %v", err)
+			}
+		}
+		if _, err := client.GetModel(ctx, 456); err != nil {
+			t.Fatalf("GetModel failed: %v", err)
+		}
+
+		hits, misses := client.CacheStats()
+		if hits != 2 {
+			t.Errorf("Expected 2 hits, got %d", hits)
+		}
+		if misses != 2 {
+			t.Errorf("Expected 2 misses, got %d", misses)
+		}
+		if metrics.CacheHits != 2 || metrics.CacheMisses != 2 {
+			t.Errorf("Expected metrics 2 hits/2 misses, got %d/%d", metrics.CacheHits, metrics.CacheMisses)
+		}
+	})
+
+	t.Run("Hash lookups count one miss each", func(t *testing.T) {
+		hashServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"id": 42, "name": "v1"}`))
+		}))
+		defer hashServer.Close()
+
+		metrics := &ResponseMetrics{}
+		client := NewClientWithoutAuth(
+			WithBaseURL(hashServer.URL),
+			WithCache(time.Hour),
+			WithResponseMetrics(metrics),
+		)
+
+		for i := 0; i < 2; i++ {
+			if _, err := client.GetModelVersionByHash(context.Background(), "ABCDEF0123456789"); err != nil {
+				t.Fatalf("GetModelVersionByHash failed: %v", err)
+			}
+		}
+
+		hits, misses := client.CacheStats()
+		if hits != 1 || misses != 1 {
+			t.Errorf("Expected 1 hit/1 miss, got %d/%d", hits, misses)
+		}
+		if metrics.CacheHits != 1 || metrics.CacheMisses != 1 {
+			t.Errorf("Expected metrics 1 hit/1 miss, got %d/%d", metrics.CacheHits, metrics.CacheMisses)
+		}
+	})
+
+	t.Run("UpdateMetrics counts cached responses", func(t *testing.T) {
+		metrics := &ResponseMetrics{}
+		metrics.UpdateMetrics(&ResponseInfo{Cached: true}, nil)
+		metrics.UpdateMetrics(&ResponseInfo{}, nil)
+		metrics.UpdateMetrics(&ResponseInfo{}, nil)
+
+		if metrics.CacheHits != 1 || metrics.CacheMisses != 2 {
+			t.Errorf("Expected 1 hit/2 misses, got %d/%d", metrics.CacheHits, metrics.CacheMisses)
+		}
+	})
+
+	t.Run("Zero without cache", func(t *testing.T) {
+		metrics := &ResponseMetrics{}
+		client := NewClientWithoutAuth(WithBaseURL(server.URL), WithResponseMetrics(metrics))
+
+		if _, err := client.GetModel(context.Background(), 123); err != nil {
+			t.Fatalf("GetModel failed: %v", err)
+		}
+
+		hits, misses := client.CacheStats()
+		if hits != 0 || misses != 0 {
+			t.Errorf("Expected 0 hits/0 misses, got %d/%d", hits, misses)
+		}
+		if metrics.CacheMisses != 0 {
+			t.Errorf("Expected no cache misses without a cache, got %d", metrics.CacheMisses)
+		}
+	})
+}
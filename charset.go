@@ -0,0 +1,162 @@
+/*
+Copyright (c) 2025 Regi Ellis
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+// Package civitai - Response Charset Handling
+//
+// This file transcodes API responses that arrive in a character set other
+// than UTF-8, which some transcoding proxies produce. It is only used when
+// the client is created with WithCharsetDetection.
+//
+// # Supported Encodings
+//
+// The charset is taken from a byte order mark, then from the Content-Type
+// charset parameter:
+//   - UTF-8 (a leading byte order mark is stripped)
+//   - UTF-16, big or little endian, with or without a byte order mark
+//   - ISO-8859-1 (Latin-1) and Windows-1252
+//
+// Without either, a body that is valid UTF-8 is used as-is and one that looks
+// like UTF-16 JSON is transcoded. Anything else fails with an error wrapping
+// ErrUnsupportedCharset:
+//
+//	client := civitai.NewClientWithoutAuth(civitai.WithCharsetDetection())
+//	model, err := client.GetModel(ctx, 4201)
+//	if errors.Is(err, civitai.ErrUnsupportedCharset) {
+//		// the proxy sent something we can't read
+//	}
+
+package civitai
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"mime"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// windows1252 maps bytes 0x80-0x9F to their Windows-1252 code points; the
+// five unassigned bytes map to the matching C1 control, as browsers do
+var windows1252 = [32]rune{
+	0x20AC, 0x0081, 0x201A, 0x0192, 0x201E, 0x2026, 0x2020, 0x2021,
+	0x02C6, 0x2030, 0x0160, 0x2039, 0x0152, 0x008D, 0x017D, 0x008F,
+	0x0090, 0x2018, 0x2019, 0x201C, 0x201D, 0x2022, 0x2013, 0x2014,
+	0x02DC, 0x2122, 0x0161, 0x203A, 0x0153, 0x009D, 0x017E, 0x0178,
+}
+
+// transcodeToUTF8 converts a response body to UTF-8 using its byte order mark
+// or the charset in contentType
+func transcodeToUTF8(data []byte, contentType string) ([]byte, error) {
+	switch {
+	case bytes.HasPrefix(data, []byte{0xEF, 0xBB, 0xBF}):
+		return validUTF8(data[3:], "utf-8")
+	case bytes.HasPrefix(data, []byte{0xFF, 0xFE}):
+		return decodeUTF16(data[2:], binary.LittleEndian)
+	case bytes.HasPrefix(data, []byte{0xFE, 0xFF}):
+		return decodeUTF16(data[2:], binary.BigEndian)
+	}
+
+	charset := ""
+	if _, params, err := mime.ParseMediaType(contentType); err == nil {
+		charset = strings.ToLower(strings.TrimSpace(params["charset"]))
+	}
+
+	switch charset {
+	case "":
+		if utf8.Valid(data) {
+			return data, nil
+		}
+		if order, ok := sniffUTF16(data); ok {
+			return decodeUTF16(data, order)
+		}
+		return nil, fmt.Errorf("%w: body is not valid UTF-8 and has no charset", ErrUnsupportedCharset)
+	case "utf-8", "utf8", "us-ascii":
+		return validUTF8(data, charset)
+	case "utf-16":
+		if order, ok := sniffUTF16(data); ok {
+			return decodeUTF16(data, order)
+		}
+		return decodeUTF16(data, binary.BigEndian)
+	case "utf-16le":
+		return decodeUTF16(data, binary.LittleEndian)
+	case "utf-16be":
+		return decodeUTF16(data, binary.BigEndian)
+	case "iso-8859-1", "latin1", "latin-1", "l1":
+		return decodeSingleByte(data, false), nil
+	case "windows-1252", "cp1252":
+		return decodeSingleByte(data, true), nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedCharset, charset)
+	}
+}
+
+// validUTF8 returns data if it is valid UTF-8 and an error naming charset otherwise
+func validUTF8(data []byte, charset string) ([]byte, error) {
+	if !utf8.Valid(data) {
+		return nil, fmt.Errorf("%w: body declared as %s is not valid UTF-8", ErrUnsupportedCharset, charset)
+	}
+	return data, nil
+}
+
+// sniffUTF16 detects UTF-16 JSON from the zero byte that accompanies its
+// first ASCII character
+func sniffUTF16(data []byte) (binary.ByteOrder, bool) {
+	if len(data) < 2 {
+		return nil, false
+	}
+	switch {
+	case data[0] == 0 && data[1] != 0:
+		return binary.BigEndian, true
+	case data[0] != 0 && data[1] == 0:
+		return binary.LittleEndian, true
+	default:
+		return nil, false
+	}
+}
+
+// decodeUTF16 converts UTF-16 in the given byte order to UTF-8
+func decodeUTF16(data []byte, order binary.ByteOrder) ([]byte, error) {
+	if len(data)%2 != 0 {
+		return nil, fmt.Errorf("%w: UTF-16 body has an odd number of bytes", ErrUnsupportedCharset)
+	}
+
+	units := make([]uint16, len(data)/2)
+	for i := range units {
+		units[i] = order.Uint16(data[2*i:])
+	}
+	return []byte(string(utf16.Decode(units))), nil
+}
+
+// decodeSingleByte converts ISO-8859-1, or Windows-1252 when cp1252 is set, to UTF-8
+func decodeSingleByte(data []byte, cp1252 bool) []byte {
+	out := make([]byte, 0, len(data))
+	for _, b := range data {
+		r := rune(b)
+		if cp1252 && b >= 0x80 && b <= 0x9F {
+			r = windows1252[b-0x80]
+		}
+		out = utf8.AppendRune(out, r)
+	}
+	return out
+}
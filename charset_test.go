@@ -0,0 +1,131 @@
+/*
+Copyright (c) 2025 Regi Ellis
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package civitai
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"unicode/utf16"
+)
+
+func TestWithCharsetDetection(t *testing.T) {
+	utf16LE := func(s string) []byte {
+		out := []byte{0xFF, 0xFE}
+		for _, unit := range utf16.Encode([]rune(s)) {
+			out = binary.LittleEndian.AppendUint16(out, unit)
+		}
+		return out
+	}
+	utf16BENoBOM := func(s string) []byte {
+		var out []byte
+		for _, unit := range utf16.Encode([]rune(s)) {
+			out = binary.BigEndian.AppendUint16(out, unit)
+		}
+		return out
+	}
+
+	tests := []struct {
+		name        string
+		contentType string
+		body        []byte
+		expected    string
+		wantErr     error
+	}{
+		{"UTF-8", "application/json; charset=utf-8", []byte(`{"id": 1, "name": "Café"}`), "Café", nil},
+		{"UTF-8 BOM", "application/json", append([]byte{0xEF, 0xBB, 0xBF}, `{"id": 1, "name": "Café"}`...), "Café", nil},
+		{"Latin-1", "application/json; charset=ISO-8859-1", []byte("{\"id\": 1, \"name\": \"Caf\xe9\"}"), "Café", nil},
+		{"Windows-1252", "application/json; charset=windows-1252", []byte("{\"id\": 1, \"name\": \"\x93Quoted\x94 \x80\"}"), "“Quoted” €", nil},
+		{"UTF-16LE BOM", "application/json", utf16LE(`{"id": 1, "name": "日本"}`), "日本", nil},
+		{"UTF-16BE sniffed", "application/json", utf16BENoBOM(`{"id": 1, "name": "Café"}`), "Café", nil},
+		{"Invalid UTF-8 without charset", "application/json", []byte("{\"id\": 1, \"name\": \"Caf\xe9\"}"), "", ErrUnsupportedCharset},
+		{"Unsupported charset", "application/json; charset=shift_jis", []byte(`{"id": 1}`), "", ErrUnsupportedCharset},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", tt.contentType)
+				w.Write(tt.body)
+			}))
+			defer server.Close()
+
+			client := NewClientWithoutAuth(WithBaseURL(server.URL), WithCharsetDetection(), WithRetryConfig(0, 0, 0))
+			model, err := client.GetModel(context.Background(), 1)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("Expected %v, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetModel failed: %v", err)
+			}
+			if model.Name != tt.expected {
+				t.Errorf("Expected name %q, got %q", tt.expected, model.Name)
+			}
+		})
+	}
+
+	t.Run("Error responses keep their status", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/html; charset=shift_jis")
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte("\x82\xa0"))
+		}))
+		defer server.Close()
+
+		client := NewClientWithoutAuth(WithBaseURL(server.URL), WithCharsetDetection())
+		if _, err := client.GetModel(context.Background(), 1); !errors.Is(err, ErrNotFound) {
+			t.Errorf("Expected ErrNotFound, got %v", err)
+		}
+	})
+
+	t.Run("With an error classifier", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json; charset=ISO-8859-1")
+			w.Write([]byte("{\"id\": 1, \"name\": \"Caf\xe9\"}"))
+		}))
+		defer server.Close()
+
+		var classified int
+		client := NewClientWithoutAuth(WithBaseURL(server.URL), WithCharsetDetection(),
+			WithErrorClassifier(func(resp *http.Response) error {
+				classified++
+				return nil
+			}))
+		model, err := client.GetModel(context.Background(), 1)
+		if err != nil {
+			t.Fatalf("GetModel failed: %v", err)
+		}
+		if model.Name != "Café" {
+			t.Errorf("Expected name %q, got %q", "Café", model.Name)
+		}
+		if classified != 1 {
+			t.Errorf("Expected the classifier to run once, got %d", classified)
+		}
+	})
+}
@@ -0,0 +1,180 @@
+/*
+Copyright (c) 2025 Regi Ellis
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+// Package civitaiotel provides OpenTelemetry tracing and metrics for the CivitAI SDK.
+//
+// It lives in its own module so the core SDK stays free of external
+// dependencies. The integration is built on the client's request and
+// response interceptors and creates one client span per HTTP attempt. It
+// needs an SDK version with civitai.WithRequestInterceptor and
+// civitai.EndpointFromContext, which go.mod requires as a minimum.
+//
+// # Usage
+//
+//	client := civitai.NewClientWithoutAuth(
+//		civitaiotel.WithObservability(),
+//	)
+//
+// By default the global tracer and meter providers are used. Custom providers
+// can be supplied with WithTracerProvider and WithMeterProvider.
+//
+// # Span Attributes
+//
+//   - http.request.method: HTTP method of the attempt
+//   - url.full: Requested URL
+//   - civitai.endpoint: Logical endpoint from civitai.EndpointFromContext
+//     (models, images, creators, tags, downloads, ...), matching the SDK's own
+//     response metrics
+//   - civitai.retry_count: Zero-based attempt number (0 for the first try)
+//   - http.response.status_code: Response status, when a response was received
+//   - http.request.header.x-request-id: Correlation ID, when civitai.WithRequestID is set
+//
+// # Metrics
+//
+//   - civitai.client.request.duration: Histogram of attempt latency in seconds
+//   - civitai.client.request.errors: Counter of failed attempts (transport errors and 4xx/5xx)
+package civitaiotel
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	civitai "github.com/regiellis/go-civitai-sdk"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentationName identifies this package to OpenTelemetry
+const instrumentationName = "github.com/regiellis/go-civitai-sdk/civitaiotel"
+
+// config holds the providers used by the integration
+type config struct {
+	tracerProvider trace.TracerProvider
+	meterProvider  metric.MeterProvider
+}
+
+// Option configures the observability integration
+type Option func(*config)
+
+// WithTracerProvider sets the tracer provider (defaults to the global provider)
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(c *config) {
+		c.tracerProvider = provider
+	}
+}
+
+// WithMeterProvider sets the meter provider (defaults to the global provider)
+func WithMeterProvider(provider metric.MeterProvider) Option {
+	return func(c *config) {
+		c.meterProvider = provider
+	}
+}
+
+// startTimeKey stores the attempt start time in the request context
+type startTimeKey struct{}
+
+// WithObservability returns a client option that traces and measures every HTTP attempt
+func WithObservability(opts ...Option) civitai.ClientOption {
+	cfg := &config{
+		tracerProvider: otel.GetTracerProvider(),
+		meterProvider:  otel.GetMeterProvider(),
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	tracer := cfg.tracerProvider.Tracer(instrumentationName)
+	meter := cfg.meterProvider.Meter(instrumentationName)
+
+	// Instrument creation only fails on invalid names; fall back to no-op instruments
+	duration, err := meter.Float64Histogram("civitai.client.request.duration",
+		metric.WithDescription("Duration of CivitAI API requests"),
+		metric.WithUnit("s"))
+	if err != nil {
+		otel.Handle(err)
+	}
+	errorCount, err := meter.Int64Counter("civitai.client.request.errors",
+		metric.WithDescription("Number of failed CivitAI API requests"))
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	requestInterceptor := func(req *http.Request, attempt int) *http.Request {
+		endpoint := civitai.EndpointFromContext(req.Context())
+		ctx, span := tracer.Start(req.Context(), "civitai "+endpoint,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(
+				attribute.String("http.request.method", req.Method),
+				attribute.String("url.full", req.URL.String()),
+				attribute.String("civitai.endpoint", endpoint),
+				attribute.Int("civitai.retry_count", attempt),
+			))
+		if id := req.Header.Get(civitai.RequestIDHeader); id != "" {
+			span.SetAttributes(attribute.String("http.request.header.x-request-id", id))
+		}
+		ctx = context.WithValue(ctx, startTimeKey{}, time.Now())
+		return req.WithContext(ctx)
+	}
+
+	responseInterceptor := func(req *http.Request, resp *http.Response, err error, attempt int) {
+		ctx := req.Context()
+		span := trace.SpanFromContext(ctx)
+		defer span.End()
+
+		attrs := []attribute.KeyValue{
+			attribute.String("civitai.endpoint", civitai.EndpointFromContext(ctx)),
+			attribute.String("http.request.method", req.Method),
+		}
+
+		failed := false
+		switch {
+		case err != nil:
+			failed = true
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		case resp.StatusCode >= 400:
+			failed = true
+			span.SetStatus(codes.Error, resp.Status)
+		}
+		if resp != nil {
+			status := attribute.Int("http.response.status_code", resp.StatusCode)
+			span.SetAttributes(status)
+			attrs = append(attrs, status)
+		}
+
+		if start, ok := ctx.Value(startTimeKey{}).(time.Time); ok && duration != nil {
+			duration.Record(ctx, time.Since(start).Seconds(), metric.WithAttributes(attrs...))
+		}
+		if failed && errorCount != nil {
+			errorCount.Add(ctx, 1, metric.WithAttributes(attrs...))
+		}
+	}
+
+	return func(c *civitai.Client) {
+		civitai.WithRequestInterceptor(requestInterceptor)(c)
+		civitai.WithResponseInterceptor(responseInterceptor)(c)
+	}
+}
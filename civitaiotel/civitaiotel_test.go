@@ -0,0 +1,157 @@
+/*
+Copyright (c) 2025 Regi Ellis
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package civitaiotel
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	civitai "github.com/regiellis/go-civitai-sdk"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestWithObservability(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/models/404" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error": "not found"}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id": 1, "name": "Model"}`))
+	}))
+	defer server.Close()
+
+	spans := tracetest.NewSpanRecorder()
+	tracerProvider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans))
+	reader := sdkmetric.NewManualReader()
+	meterProvider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	client := civitai.NewClientWithoutAuth(
+		civitai.WithBaseURL(server.URL),
+		civitai.WithRequestID(func() string { return "req-1" }),
+		WithObservability(WithTracerProvider(tracerProvider), WithMeterProvider(meterProvider)),
+	)
+	ctx := context.Background()
+
+	if _, err := client.GetModel(ctx, 1); err != nil {
+		t.Fatalf("GetModel failed: %v", err)
+	}
+	if _, err := client.GetModel(ctx, 404); err == nil {
+		t.Fatal("Expected an error for a 404 response")
+	}
+
+	t.Run("Spans", func(t *testing.T) {
+		ended := spans.Ended()
+		if len(ended) != 2 {
+			t.Fatalf("Expected 2 spans, got %d", len(ended))
+		}
+
+		ok, failed := ended[0], ended[1]
+		if ok.Name() != "civitai models" {
+			t.Errorf("Expected span name 'civitai models', got %q", ok.Name())
+		}
+		attrs := attribute.NewSet(ok.Attributes()...)
+		expected := map[attribute.Key]attribute.Value{
+			"http.request.method":              attribute.StringValue("GET"),
+			"civitai.endpoint":                 attribute.StringValue("models"),
+			"civitai.retry_count":              attribute.IntValue(0),
+			"http.response.status_code":        attribute.IntValue(200),
+			"http.request.header.x-request-id": attribute.StringValue("req-1"),
+		}
+		for key, want := range expected {
+			if got, found := attrs.Value(key); !found || got != want {
+				t.Errorf("Expected %s=%v, got %v", key, want.Emit(), got.Emit())
+			}
+		}
+		if ok.Status().Code == codes.Error {
+			t.Errorf("Expected successful span status, got %v", ok.Status())
+		}
+		if failed.Status().Code != codes.Error {
+			t.Errorf("Expected error span status for a 404, got %v", failed.Status())
+		}
+	})
+
+	t.Run("Metrics", func(t *testing.T) {
+		var data metricdata.ResourceMetrics
+		if err := reader.Collect(ctx, &data); err != nil {
+			t.Fatalf("Collect failed: %v", err)
+		}
+
+		var requests uint64
+		var errors int64
+		for _, scope := range data.ScopeMetrics {
+			for _, m := range scope.Metrics {
+				switch agg := m.Data.(type) {
+				case metricdata.Histogram[float64]:
+					for _, point := range agg.DataPoints {
+						requests += point.Count
+					}
+				case metricdata.Sum[int64]:
+					for _, point := range agg.DataPoints {
+						errors += point.Value
+					}
+				}
+			}
+		}
+		if requests != 2 {
+			t.Errorf("Expected 2 recorded durations, got %d", requests)
+		}
+		if errors != 1 {
+			t.Errorf("Expected 1 error, got %d", errors)
+		}
+	})
+}
+
+func TestEndpointPathOverride(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id": 1, "name": "Model"}`))
+	}))
+	defer server.Close()
+
+	spans := tracetest.NewSpanRecorder()
+	client := civitai.NewClientWithoutAuth(
+		civitai.WithBaseURL(server.URL),
+		civitai.WithEndpointPath(civitai.EndpointModels, "v2/catalog"),
+		WithObservability(WithTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans)))),
+	)
+	if _, err := client.GetModel(context.Background(), 1); err != nil {
+		t.Fatalf("GetModel failed: %v", err)
+	}
+
+	ended := spans.Ended()
+	if len(ended) != 1 {
+		t.Fatalf("Expected 1 span, got %d", len(ended))
+	}
+	if ended[0].Name() != "civitai models" {
+		t.Errorf("Expected span name 'civitai models', got %q", ended[0].Name())
+	}
+}
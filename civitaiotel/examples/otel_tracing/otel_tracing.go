@@ -0,0 +1,70 @@
+/*
+Copyright (c) 2025 Regi Ellis
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+// Example: tracing CivitAI API calls with OpenTelemetry.
+//
+// Spans are printed to stdout using the stdout trace exporter. In production,
+// replace the exporter with your collector (OTLP, Jaeger, etc.).
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	civitai "github.com/regiellis/go-civitai-sdk"
+	"github.com/regiellis/go-civitai-sdk/civitaiotel"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+func main() {
+	exporter, err := stdouttrace.New(stdouttrace.WithPrettyPrint())
+	if err != nil {
+		log.Fatalf("Failed to create exporter: %v", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(sdktrace.WithBatcher(exporter))
+	defer func() {
+		if err := provider.Shutdown(context.Background()); err != nil {
+			log.Printf("Failed to shut down tracer provider: %v", err)
+		}
+	}()
+
+	client := civitai.NewClientWithoutAuth(
+		civitai.WithTimeout(30*time.Second),
+		civitaiotel.WithObservability(civitaiotel.WithTracerProvider(provider)),
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	models, _, err := client.SearchModels(ctx, civitai.SearchParams{Tag: "anime", Limit: 3})
+	if err != nil {
+		log.Fatalf("Search failed: %v", err)
+	}
+
+	for _, model := range models {
+		fmt.Printf("- %s (%d downloads)\n", model.Name, model.Stats.DownloadCount)
+	}
+}
@@ -0,0 +1,26 @@
+module github.com/regiellis/go-civitai-sdk/civitaiotel
+
+go 1.21
+
+require (
+	github.com/regiellis/go-civitai-sdk v0.0.0-20261016020022-886813a4072d
+	go.opentelemetry.io/otel v1.28.0
+	go.opentelemetry.io/otel/exporters/stdout/stdouttrace v1.28.0
+	go.opentelemetry.io/otel/metric v1.28.0
+	go.opentelemetry.io/otel/sdk v1.28.0
+	go.opentelemetry.io/otel/sdk/metric v1.28.0
+	go.opentelemetry.io/otel/trace v1.28.0
+)
+
+require (
+	github.com/go-logr/logr v1.4.2 // indirect
+	github.com/go-logr/stdr v1.2.2 // indirect
+	github.com/google/uuid v1.6.0 // indirect
+	golang.org/x/sys v0.21.0 // indirect
+)
+
+// Build against the SDK in this repository during development. Replace
+// directives only apply to the main module, so users of civitaiotel get the
+// SDK version required above: the first commit with the interceptor API and
+// civitai.EndpointFromContext. Raise it to a tagged release once one exists.
+replace github.com/regiellis/go-civitai-sdk => ../
@@ -98,6 +98,12 @@ SOFTWARE.
 // Tags:
 //   - GetTags: Explore available tags for categorizing models
 //
+// Search:
+//   - Search: Query models, images, creators, and tags in one call
+//
+// Downloads:
+//   - DownloadFile: Download a model file with optional parallel segments
+//
 // # Error Handling
 //
 // The SDK provides comprehensive error handling with typed errors:
@@ -116,7 +122,8 @@ SOFTWARE.
 //
 // Based on extensive testing, be aware of these API behaviors:
 //   - Tag-based search returns 2-5x more results than query-based search
-//   - Creators endpoint has ~20% timeout rate under load
+//   - Creators endpoint has ~20% timeout rate under load (GetCreators gets a
+//     longer timeout and extra retries by default)
 //   - Version-by-hash endpoint is currently non-functional
 //   - Page-based pagination is unreliable; use cursor-based pagination
 //
@@ -125,20 +132,24 @@ SOFTWARE.
 package civitai
 
 import (
+	"bytes"
 	"compress/gzip"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"log"
 	"math"
 	"math/rand"
 	"net/http"
 	"net/url"
 	"regexp"
+	"runtime/debug"
 	"strconv"
 	"strings"
 	"time"
+	"unicode"
 )
 
 const (
@@ -151,6 +162,9 @@ const (
 	// DefaultUserAgent is the default user agent string
 	DefaultUserAgent = "go-civitai-sdk/1.0.0"
 
+	// modulePath is the SDK's module path, used to find its version in build info
+	modulePath = "github.com/regiellis/go-civitai-sdk"
+
 	// DefaultMaxResponseSize is the default maximum response size (10MB)
 	DefaultMaxResponseSize = 10 * 1024 * 1024 // 10MB
 
@@ -162,23 +176,139 @@ const (
 
 	// DefaultMaxRetryDelay is the maximum delay between retries
 	DefaultMaxRetryDelay = 30 * time.Second
+
+	// MaxLimit is the largest page size accepted by the API
+	MaxLimit = 200
+
+	// DefaultCreatorsTimeoutFactor multiplies the client timeout for creators
+	// requests, which time out about 20% of the time under load
+	DefaultCreatorsTimeoutFactor = 2
+
+	// DefaultCreatorsExtraRetries is added to the client's retry count for
+	// creators requests
+	DefaultCreatorsExtraRetries = 2
+)
+
+// Logical endpoint names accepted by WithEndpointPath. Each one is the path
+// prefix of a group of API calls relative to the base URL.
+const (
+	// EndpointModels covers SearchModels, GetModel, and GetModelVersions
+	EndpointModels = "models"
+
+	// EndpointModelVersions covers GetModelVersion and GetModelVersionByHash
+	EndpointModelVersions = "model-versions"
+
+	// EndpointImages covers GetImages
+	EndpointImages = "images"
+
+	// EndpointCreators covers GetCreators
+	EndpointCreators = "creators"
+
+	// EndpointTags covers GetTags
+	EndpointTags = "tags"
 )
 
-// Client represents a CivitAI API client
+// EndpointDownloads is the ResponseMetrics.PerEndpointMetrics key for file
+// downloads, which use URLs from the API rather than the base URL
+const EndpointDownloads = "downloads"
+
+// Client represents a CivitAI API client.
+//
+// A Client is safe for concurrent use by multiple goroutines, and one client
+// should be shared rather than created per request so connections, the cache,
+// and request slots are shared too. Its configuration is fixed by NewClient;
+// the state it changes afterwards (the cache, ResponseMetrics, the request
+// semaphore) is synchronized internally. Callbacks and interceptors passed as
+// options may be called from several goroutines at once and must synchronize
+// any state of their own. Iterators are not safe for concurrent use; give each
+// goroutine its own.
 type Client struct {
 	baseURL         string
 	apiToken        string
 	httpClient      *http.Client
+	transport       transportSettings
 	userAgent       string
+	language        string
 	maxResponseSize int64
 	maxRetries      int
 	retryDelay      time.Duration
 	maxRetryDelay   time.Duration
+	disableJitter   bool
+
+	endpointResponseLimits map[string]int64
+	endpointPaths          map[string]string
+	endpointTimeouts       map[string]time.Duration
+	endpointRetries        map[string]int
+
+	downloadConcurrency  int
+	strictJSON           bool
+	defaultPeriod        Period
+	defaultLimit         int
+	defaultTypes         []ModelType
+	cache                *responseCache
+	hashCacheTTL         time.Duration
+	hashNotFoundCacheTTL time.Duration
+	compatibilityGraph   map[BaseModel][]BaseModel
+	retryCallback        func(attempt int, err error, delay time.Duration)
+	requestIDGenerator   func() string
+	logger               Logger
+	slowRequestThreshold time.Duration
+	headerCallback       func(endpoint string, h http.Header)
+	deadlineWarnings     bool
+	autoCursorRecovery   bool
+	strictValidation     bool
+	filterBaseModels     bool
+	baseModelAliases     map[string]BaseModel
+	maxInlineVersions    int
+	retryOnDecodeError   bool
+	progressReporter     func(ProgressEvent)
+	metrics              *ResponseMetrics
+	errorClassifier      func(*http.Response) error
+	errorSnapshotBytes   int
+	charsetDetection     bool
+
+	requestSlots            chan struct{}
+	semaphoreAcquireTimeout time.Duration
+
+	requestInterceptors  []RequestInterceptor
+	responseInterceptors []ResponseInterceptor
 }
 
 // ClientOption represents a function that configures the client
 type ClientOption func(*Client)
 
+// Logger receives diagnostic messages from the client. *log.Logger satisfies it.
+type Logger interface {
+	Printf(format string, v ...interface{})
+}
+
+// ProgressEvent reports the progress of a long-running operation such as an
+// iterator or a bulk helper. Events are delivered via WithProgressReporter.
+type ProgressEvent struct {
+	// Operation names the SDK method that emitted the event, e.g. "ModelsIterator"
+	Operation string
+
+	// Processed is the number of items processed so far
+	Processed int
+
+	// Total is the estimated number of items, or -1 when unknown
+	Total int
+}
+
+// RequestIDHeader is the header carrying the ID from WithRequestID
+const RequestIDHeader = "X-Request-ID"
+
+// RequestInterceptor is called before each HTTP attempt, including retries.
+// It may return a replacement request (e.g. one carrying a derived context);
+// returning nil keeps the original request.
+type RequestInterceptor func(req *http.Request, attempt int) *http.Request
+
+// ResponseInterceptor is called after each HTTP attempt with either the response
+// or the transport error, including an attempt that never got a slot under
+// WithMaxConcurrentRequests. It receives the request returned by the request
+// interceptors and must not consume or close the response body.
+type ResponseInterceptor func(req *http.Request, resp *http.Response, err error, attempt int)
+
 // WithBaseURL sets a custom base URL for the API
 func WithBaseURL(baseURL string) ClientOption {
 	return func(c *Client) {
@@ -186,10 +316,12 @@ func WithBaseURL(baseURL string) ClientOption {
 	}
 }
 
-// WithTimeout sets a custom timeout for HTTP requests
+// WithTimeout sets a custom timeout for HTTP requests. It takes precedence
+// over the timeout of a WithHTTPClient client in either option order.
 func WithTimeout(timeout time.Duration) ClientOption {
 	return func(c *Client) {
-		c.httpClient.Timeout = timeout
+		c.transport.timeout = timeout
+		c.transport.timeoutSet = true
 	}
 }
 
@@ -200,13 +332,96 @@ func WithUserAgent(userAgent string) ClientOption {
 	}
 }
 
-// WithHTTPClient sets a custom HTTP client
+// WithUserAgentAutoVersion sets the user agent to "go-civitai-sdk/<version>"
+// using the SDK version recorded in the program's build info, so the user
+// agent reports the release actually in use. It falls back to DefaultUserAgent
+// when the build info or the SDK's version is unavailable, as in tests and
+// binaries built from a local checkout.
+func WithUserAgentAutoVersion() ClientOption {
+	return func(c *Client) {
+		info, _ := debug.ReadBuildInfo()
+		c.userAgent = userAgentFromBuildInfo(info)
+	}
+}
+
+// userAgentFromBuildInfo builds the WithUserAgentAutoVersion user agent from
+// info, which may be nil
+func userAgentFromBuildInfo(info *debug.BuildInfo) string {
+	if info == nil {
+		return DefaultUserAgent
+	}
+
+	module := &info.Main
+	for _, dep := range info.Deps {
+		if dep.Path == modulePath {
+			module = dep
+			break
+		}
+	}
+	if module.Path != modulePath {
+		return DefaultUserAgent
+	}
+	if module.Replace != nil {
+		module = module.Replace
+	}
+
+	version := strings.TrimPrefix(module.Version, "v")
+	if version == "" || version == "(devel)" {
+		return DefaultUserAgent
+	}
+	return "go-civitai-sdk/" + version
+}
+
+// WithLanguage sets the Accept-Language header sent with every request, e.g.
+// "de-DE" or "fr;q=0.9, en;q=0.8". CivitAI doesn't localize API responses
+// today, but proxies may route on the header. Characters that can't appear
+// in a language range are dropped; an empty value sends no header, which is
+// the default.
+func WithLanguage(lang string) ClientOption {
+	return func(c *Client) {
+		c.language = sanitizeLanguage(lang)
+	}
+}
+
+// sanitizeLanguage keeps only characters valid in an Accept-Language value,
+// which also rules out header injection through CR or LF
+func sanitizeLanguage(lang string) string {
+	cleaned := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			return r
+		case strings.ContainsRune("-,;=.* ", r):
+			return r
+		default:
+			return -1
+		}
+	}, lang)
+	return strings.TrimSpace(cleaned)
+}
+
+// WithHTTPClient sets a custom HTTP client. The client may be shared: options
+// that change HTTP settings, such as WithTimeout, WithConnectionPooling,
+// WithMinTLSVersion, WithPreferIPv4, and WithCookieJar, apply them to a private
+// copy of the client and its transport, so other users of httpClient are
+// unaffected. Those options are applied on top of httpClient whichever order
+// they are given in; see transport.go for the composition order.
 func WithHTTPClient(httpClient *http.Client) ClientOption {
 	return func(c *Client) {
 		c.httpClient = httpClient
 	}
 }
 
+// WithCookieJar sends and stores cookies through jar, for advanced flows
+// authenticated by a session cookie rather than an API token. It is applied
+// after all other options, so it composes with WithHTTPClient in any order and,
+// like the other HTTP settings, never modifies a shared client. Most users
+// should prefer NewClient with an API token.
+func WithCookieJar(jar http.CookieJar) ClientOption {
+	return func(c *Client) {
+		c.transport.cookieJar = jar
+	}
+}
+
 // WithMaxResponseSize sets the maximum allowed response size in bytes
 func WithMaxResponseSize(size int64) ClientOption {
 	return func(c *Client) {
@@ -214,6 +429,64 @@ func WithMaxResponseSize(size int64) ClientOption {
 	}
 }
 
+// WithEndpointResponseLimit overrides the maximum response size for one endpoint.
+// The endpoint is a path relative to the API base URL such as "models" or
+// "model-versions/by-hash"; it also covers sub-paths (so "models" applies to
+// "models/123"), and the most specific matching endpoint wins. Endpoints without
+// an override use the WithMaxResponseSize limit.
+func WithEndpointResponseLimit(endpoint string, size int64) ClientOption {
+	return func(c *Client) {
+		if c.endpointResponseLimits == nil {
+			c.endpointResponseLimits = make(map[string]int64)
+		}
+		c.endpointResponseLimits[strings.Trim(endpoint, "/")] = size
+	}
+}
+
+// WithEndpointPath points a logical endpoint (EndpointModels, EndpointImages,
+// etc.) at a different path relative to the base URL, for example
+// WithEndpointPath(civitai.EndpointModels, "v2/models"). Sub-paths follow the
+// override, so GetModel(ctx, 1) then requests "v2/models/1". It lets callers
+// keep working when CivitAI renames or versions an endpoint before the SDK is
+// updated. WithEndpointResponseLimit still matches the path actually requested.
+func WithEndpointPath(endpoint, path string) ClientOption {
+	return func(c *Client) {
+		if c.endpointPaths == nil {
+			c.endpointPaths = make(map[string]string)
+		}
+		c.endpointPaths[strings.Trim(endpoint, "/")] = strings.Trim(path, "/")
+	}
+}
+
+// WithRequestTimeoutPerEndpoint sets the per-attempt timeout for requests to
+// one logical endpoint (EndpointModels, EndpointCreators, etc.), in place of
+// the WithTimeout value; zero disables the client timeout for it. The context
+// deadline still bounds the whole call, retries included. EndpointCreators
+// defaults to DefaultCreatorsTimeoutFactor times the client timeout, because
+// that endpoint is much slower than the others; this option overrides it.
+func WithRequestTimeoutPerEndpoint(endpoint string, timeout time.Duration) ClientOption {
+	return func(c *Client) {
+		if c.endpointTimeouts == nil {
+			c.endpointTimeouts = make(map[string]time.Duration)
+		}
+		c.endpointTimeouts[endpoint] = max(timeout, 0)
+	}
+}
+
+// WithMaxRetriesPerEndpoint sets how many times requests to one logical
+// endpoint are retried, in place of the WithRetryConfig count; the backoff
+// delays are shared. EndpointCreators defaults to DefaultCreatorsExtraRetries
+// more than the client's count, so even WithRetryConfig(0, ...) retries it
+// twice unless this option is used to set it explicitly.
+func WithMaxRetriesPerEndpoint(endpoint string, maxRetries int) ClientOption {
+	return func(c *Client) {
+		if c.endpointRetries == nil {
+			c.endpointRetries = make(map[string]int)
+		}
+		c.endpointRetries[endpoint] = max(maxRetries, 0)
+	}
+}
+
 // WithRetryConfig sets the retry configuration for failed requests
 func WithRetryConfig(maxRetries int, baseDelay, maxDelay time.Duration) ClientOption {
 	return func(c *Client) {
@@ -223,16 +496,432 @@ func WithRetryConfig(maxRetries int, baseDelay, maxDelay time.Duration) ClientOp
 	}
 }
 
-// WithConnectionPooling configures the HTTP client for connection pooling and compression
+// WithoutJitter removes the ±25% random variation from retry backoff, so the
+// delay before retry n is exactly the base delay times 2^(n-1), capped at the
+// maximum delay. This makes retry timing reproducible in tests; leave jitter on
+// in production so that clients failing together don't retry in lockstep.
+func WithoutJitter() ClientOption {
+	return func(c *Client) {
+		c.disableJitter = true
+	}
+}
+
+// WithConnectionPooling sets the transport's idle connection limits and a 90
+// second idle connection timeout. Like the other transport options it is
+// applied to a copy of the transport after all other options, so it keeps the
+// rest of a WithHTTPClient transport's configuration in any option order.
+// Custom transports that are not *http.Transport are left untouched.
 func WithConnectionPooling(maxIdleConns, maxIdleConnsPerHost int) ClientOption {
 	return func(c *Client) {
-		transport := &http.Transport{
-			MaxIdleConns:        maxIdleConns,
-			MaxIdleConnsPerHost: maxIdleConnsPerHost,
-			IdleConnTimeout:     90 * time.Second,
-			DisableCompression:  false, // Enable compression
+		c.transport.pooling = true
+		c.transport.maxIdleConns = maxIdleConns
+		c.transport.maxIdleConnsPerHost = maxIdleConnsPerHost
+	}
+}
+
+// WithLogger logs one line per HTTP attempt with the method, URL, attempt
+// number, outcome, duration, and request ID (when WithRequestID is set)
+func WithLogger(logger Logger) ClientOption {
+	return func(c *Client) {
+		c.logger = logger
+	}
+}
+
+// WithDeadlineWarnings logs a warning when a request is made with a context that
+// has no deadline while retries are enabled. Such requests can keep retrying with
+// backoff far longer than expected; wrap the context with context.WithTimeout to
+// bound them. Warnings go to the WithLogger logger, or the standard log package
+// when none is set.
+func WithDeadlineWarnings() ClientOption {
+	return func(c *Client) {
+		c.deadlineWarnings = true
+	}
+}
+
+// WithResponseHeaderCallback calls fn with the headers of every HTTP response,
+// including error responses and each retried attempt. The endpoint is the
+// request path relative to the base URL, such as "models" or "models/123".
+// It exposes rate limit, caching, and request ID headers for debugging
+// without wrapping the HTTP client. fn must not modify the headers.
+func WithResponseHeaderCallback(fn func(endpoint string, h http.Header)) ClientOption {
+	return func(c *Client) {
+		c.headerCallback = fn
+	}
+}
+
+// WithSlowRequestThreshold logs a warning for every HTTP attempt whose round
+// trip takes longer than d, with the method, endpoint path, and duration. It
+// makes the slow creators and tags endpoints easy to spot in production logs.
+// Warnings go to the WithLogger logger, or the standard log package when none
+// is set. A zero threshold disables the check.
+func WithSlowRequestThreshold(d time.Duration) ClientOption {
+	return func(c *Client) {
+		c.slowRequestThreshold = d
+	}
+}
+
+// WithAutoCursorRecovery lets the pagination iterators survive a rejected
+// cursor. When a page request made with a cursor fails with a client error
+// (such as 400 for a stale cursor), the iterator logs a warning and re-requests
+// the same position by page number instead, then continues with whatever the
+// API returns. Recovery is best effort: items added or removed while scanning
+// shift page boundaries, so some items may be skipped or repeated, and
+// searches the API only paginates by cursor may stop early. Iterators started
+// from a Cursor in their params are never recovered, since there is no page
+// number for the position that cursor points to.
+func WithAutoCursorRecovery() ClientOption {
+	return func(c *Client) {
+		c.autoCursorRecovery = true
+	}
+}
+
+// WithStrictValidation adds cross-field checks to parameter validation,
+// rejecting combinations the API would otherwise resolve in its own
+// unpredictable way:
+//   - SearchParams with both Query and Tag
+//   - SearchParams, ImageParams, CreatorParams, or TagParams with both Page
+//     and Cursor
+//   - SearchParams with Favorites or Hidden on a client without an API token
+//   - ImageParams with both ModelID and ModelVersionID, or PostID combined
+//     with either of them
+//
+// These fail before any request is sent. Validation stays lenient by default,
+// in which case a Cursor takes precedence and Page isn't sent.
+func WithStrictValidation() ClientOption {
+	return func(c *Client) {
+		c.strictValidation = true
+	}
+}
+
+// WithBaseModelFilterClientSide makes SearchModels enforce SearchParams.BaseModels
+// itself instead of trusting the API to honor it. After each page is fetched,
+// versions whose base model isn't listed are removed from every model, and
+// models left with no versions are dropped. Base models are compared after
+// NormalizeBaseModel, so "SDXL" matches "SDXL 1.0".
+//
+// The filter runs on one page at a time, so a page can come back with fewer
+// models than Limit, or none at all, while Metadata still points at the next
+// page. Collecting a given number of matches may therefore take several more
+// requests than with a server-side filter; the iterators handle this, since
+// they keep paging until the API runs out.
+func WithBaseModelFilterClientSide() ClientOption {
+	return func(c *Client) {
+		c.filterBaseModels = true
+	}
+}
+
+// WithBaseModelAlias makes the client report alias as model in the BaseModel
+// of versions it returns, for example to fold a community spelling or a newer
+// base model into a name the application already handles. It may be given
+// more than once. Aliases apply after NormalizeBaseModel, so matching ignores
+// case, spaces, and punctuation, and aliasing one spelling of a built-in base
+// model covers all of its spellings. WithBaseModelFilterClientSide compares
+// base models after aliasing.
+func WithBaseModelAlias(alias string, model BaseModel) ClientOption {
+	return func(c *Client) {
+		if c.baseModelAliases == nil {
+			c.baseModelAliases = make(map[string]BaseModel)
 		}
-		c.httpClient.Transport = transport
+		c.baseModelAliases[baseModelAliasKey(string(NormalizeBaseModel(alias)))] = model
+	}
+}
+
+// WithMaxModelVersionsInline makes SearchModels and GetModel keep only the n
+// most recently created versions of each model (see Model.TrimVersions); the
+// full set stays available from GetModelVersionsByModelID. Trimming happens
+// after decoding, so it reduces the memory held by returned models, not the
+// bandwidth or parsing cost of the response. Helpers that need every version,
+// such as GetVersionByName, only see the kept ones. Zero or negative keeps
+// every version, which is the default.
+func WithMaxModelVersionsInline(n int) ClientOption {
+	return func(c *Client) {
+		c.maxInlineVersions = max(n, 0)
+	}
+}
+
+// WithResponseMetrics records every HTTP attempt the client makes into m,
+// overall and per logical endpoint (see ResponseMetrics.PerEndpointMetrics).
+// Retries count as separate requests, and responses served from the cache
+// aren't recorded. One ResponseMetrics can be shared by several clients.
+func WithResponseMetrics(m *ResponseMetrics) ClientOption {
+	return func(c *Client) {
+		c.metrics = m
+	}
+}
+
+// WithErrorClassifier lets classifier decide how API responses are treated
+// before the default handling, which fails non-2xx responses and decodes the
+// rest. It is called once per API call with the final response, after
+// retries, and can return:
+//   - nil to keep the default handling
+//   - an error, which the API call returns as-is, for example to fail a 200
+//     response whose body reports an error
+//   - ErrAcceptResponse to decode the body as a success whatever the status
+//     code, for example to tolerate a nonstandard code from a proxy
+//
+// The classifier may read resp.Body, which holds the decompressed body cut
+// off at the response size limit; it is rewound before default handling.
+// File downloads are not classified.
+func WithErrorClassifier(classifier func(*http.Response) error) ClientOption {
+	return func(c *Client) {
+		c.errorClassifier = classifier
+	}
+}
+
+// WithErrorResponseSnapshot attaches up to maxBytes of the raw response body
+// to errors for non-2xx responses and bodies that fail to decode, so bug
+// reports can show what the server actually returned. The returned error
+// wraps an *APIError whose Details holds the snapshot; read it with
+// errors.As. Bearer tokens, the client's API token, and values of token, key,
+// password, and secret fields are redacted before the snapshot is cut to
+// maxBytes. Retryable statuses that exhaust their retries report
+// ErrRetriesExhausted without a snapshot. Zero or negative disables
+// snapshots, which is the default.
+func WithErrorResponseSnapshot(maxBytes int) ClientOption {
+	return func(c *Client) {
+		c.errorSnapshotBytes = max(maxBytes, 0)
+	}
+}
+
+// WithCharsetDetection makes the client transcode API responses that aren't
+// UTF-8, as some transcoding proxies send, before decoding them. The charset
+// comes from a byte order mark or the Content-Type header; UTF-16,
+// ISO-8859-1, and Windows-1252 are supported. Successful responses in any
+// other charset, or invalid UTF-8 without one, fail with an error wrapping
+// ErrUnsupportedCharset instead of an obscure JSON syntax error. Detection
+// buffers each response body, so it is off by default. File downloads are
+// not affected.
+func WithCharsetDetection() ClientOption {
+	return func(c *Client) {
+		c.charsetDetection = true
+	}
+}
+
+// WithProgressReporter registers a function that receives ProgressEvents from
+// long-running operations: the pagination iterators (ModelsIterator,
+// ImagesIterator, CreatorsIterator, TagsIterator) after each page, and
+// GetModelsForTopTags and GetCreatorsWithModels as each item completes. It lets
+// CLIs render progress uniformly; events from bulk helpers may be delivered
+// concurrently. When unset, no events are built.
+func WithProgressReporter(reporter func(ProgressEvent)) ClientOption {
+	return func(c *Client) {
+		c.progressReporter = reporter
+	}
+}
+
+// WithRequestID sets an X-Request-ID header generated by generator on each
+// request for log correlation. Retries of a request reuse its ID. The ID is
+// visible to interceptors via the request header and included in log lines.
+func WithRequestID(generator func() string) ClientOption {
+	return func(c *Client) {
+		c.requestIDGenerator = generator
+	}
+}
+
+// WithMinTLSVersion enforces a minimum TLS version (e.g. tls.VersionTLS12) for
+// API connections. It is applied after all other options, so it preserves any
+// pooling configuration regardless of option order. Custom transports that are
+// not *http.Transport are left untouched.
+func WithMinTLSVersion(version uint16) ClientOption {
+	return func(c *Client) {
+		c.transport.minTLSVersion = version
+	}
+}
+
+// WithPreferIPv4 dials IPv4 addresses first and only falls back to the OS
+// default (which may pick IPv6) when no IPv4 connection can be made. Some
+// dual-stack networks have slow or broken IPv6 routes to CivitAI, which shows
+// up as sporadic connection stalls; this sidesteps them. Like WithMinTLSVersion
+// it is applied to a copy of the transport after all other options, so pooling
+// settings are preserved. Custom transports that are not *http.Transport are
+// left untouched.
+func WithPreferIPv4() ClientOption {
+	return func(c *Client) {
+		c.transport.preferIPv4 = true
+	}
+}
+
+// WithMaxResponseHeaderBytes limits the size of response headers the transport
+// will read, guarding against servers sending oversized headers. When unset,
+// Go's default limit applies. Like WithMinTLSVersion it is applied to a copy
+// of the transport after all other options, so pooling settings are preserved.
+func WithMaxResponseHeaderBytes(n int) ClientOption {
+	return func(c *Client) {
+		c.transport.maxHeaderBytes = int64(n)
+	}
+}
+
+// WithMaxConnsPerHost caps the total number of connections per host,
+// including those in use, so bursts of concurrent requests queue for a
+// connection instead of opening more. WithConnectionPooling only bounds idle
+// connections. Zero or negative leaves Go's default of no limit. Like
+// WithMinTLSVersion it is applied to a copy of the transport after all other
+// options, so pooling settings are preserved.
+func WithMaxConnsPerHost(n int) ClientOption {
+	return func(c *Client) {
+		c.transport.maxConnsPerHost = max(n, 0)
+	}
+}
+
+// WithDialTimeout limits how long establishing a TCP connection may take,
+// separately from the overall request timeout set by WithTimeout. Slow
+// connection setup is one cause of the creators endpoint's timeouts, so a short
+// dial timeout lets retries start sooner. Zero or negative keeps Go's default
+// of 30 seconds. The dialer is applied to a copy of the transport after all
+// other options, so pooling settings are preserved; it replaces any DialContext
+// of a transport supplied with WithHTTPClient.
+func WithDialTimeout(d time.Duration) ClientOption {
+	return func(c *Client) {
+		c.transport.dialTimeout = max(d, 0)
+	}
+}
+
+// WithKeepAlive sets the interval between TCP keep-alive probes on API
+// connections. Zero keeps Go's default of 30 seconds and a negative value
+// disables keep-alive probes. Like WithDialTimeout it replaces the
+// transport's dialer on a copy of the transport.
+func WithKeepAlive(d time.Duration) ClientOption {
+	return func(c *Client) {
+		c.transport.keepAlive = d
+	}
+}
+
+// WithCompression controls response compression. It is enabled by default;
+// passing false sets Transport.DisableCompression and stops the client from
+// advertising gzip in Accept-Encoding, so responses arrive uncompressed. Use it
+// behind proxies that double-compress and corrupt responses. Like
+// WithMinTLSVersion it is applied to a copy of the transport after all other
+// options, so pooling settings are preserved.
+func WithCompression(enabled bool) ClientOption {
+	return func(c *Client) {
+		c.transport.disableCompression = !enabled
+	}
+}
+
+// WithDefaultPeriod sets the period used by SearchModels and GetImages, and the
+// helpers built on them such as GetPopularModels, when a call leaves Period
+// unset. A Period set on the request params always takes precedence. Lookups
+// that search on the caller's behalf, such as FindModelByName,
+// GetCreatorsWithModels, GetModelsForTopTags, and GetVersionImages, ignore it
+// so a short period can't hide the resources they look for.
+func WithDefaultPeriod(period Period) ClientOption {
+	return func(c *Client) {
+		c.defaultPeriod = period
+	}
+}
+
+// WithDefaultLimit sets the page size used by SearchModels, GetImages, GetCreators,
+// and GetTags, and the helpers built on them, when a call leaves Limit unset. A
+// Limit set on the request params always takes precedence. Values above MaxLimit
+// above MaxLimit make those calls fail with a validation error whenever they
+// would use the default, and values of 0 or less restore the API default.
+func WithDefaultLimit(n int) ClientOption {
+	return func(c *Client) {
+		if n > 0 {
+			c.defaultLimit = n
+		} else {
+			c.defaultLimit = 0
+		}
+	}
+}
+
+// WithDefaultTypes sets the model types SearchModels, and the helpers built on
+// it, filter by when a call leaves Types empty. Types set on the request
+// params always take precedence. The types must be ModelType constants;
+// searches relying on the defaults fail if any is unknown, rather than
+// silently searching every type. Calling it with no types clears the default.
+// Like WithDefaultPeriod, the types don't apply to lookups such as
+// FindModelByName, which must find a model whatever its type.
+func WithDefaultTypes(types ...ModelType) ClientOption {
+	return func(c *Client) {
+		c.defaultTypes = append([]ModelType(nil), types...)
+	}
+}
+
+// WithCompatibilityGraph replaces the base model compatibility graph used by
+// Client.CompatibleBaseModels. The graph is copied; see DefaultCompatibilityGraph
+// for the default relationships.
+func WithCompatibilityGraph(graph map[BaseModel][]BaseModel) ClientOption {
+	return func(c *Client) {
+		c.compatibilityGraph = copyCompatibilityGraph(graph)
+	}
+}
+
+// WithMaxConcurrentRequests limits the number of HTTP attempts the client has
+// in flight at once. Further requests wait for a free slot until their context
+// is done, or until the WithSemaphoreAcquireTimeout duration elapses. A slot is
+// held for a single attempt, so requests sleeping between retries don't hold one.
+func WithMaxConcurrentRequests(n int) ClientOption {
+	return func(c *Client) {
+		if n > 0 {
+			c.requestSlots = make(chan struct{}, n)
+		} else {
+			c.requestSlots = nil
+		}
+	}
+}
+
+// WithSemaphoreAcquireTimeout bounds how long a request waits for a slot when
+// WithMaxConcurrentRequests is set. Requests that can't get a slot in time fail
+// with ErrTooBusy so callers can shed load instead of queueing.
+func WithSemaphoreAcquireTimeout(timeout time.Duration) ClientOption {
+	return func(c *Client) {
+		c.semaphoreAcquireTimeout = timeout
+	}
+}
+
+// WithDownloadConcurrency enables parallel ranged downloads using up to n segments.
+// It only takes effect when the server advertises Accept-Ranges and the destination
+// writer implements io.WriterAt (e.g. *os.File); otherwise downloads are sequential.
+func WithDownloadConcurrency(n int) ClientOption {
+	return func(c *Client) {
+		c.downloadConcurrency = n
+	}
+}
+
+// WithStrictJSON makes response decoding reject fields the SDK types don't model.
+// This is useful for detecting API drift, but note that strict mode will return
+// an error whenever the API adds a new field. Lenient decoding is the default.
+func WithStrictJSON() ClientOption {
+	return func(c *Client) {
+		c.strictJSON = true
+	}
+}
+
+// WithRetryOnDecodeError retries requests whose response body is cut short,
+// for example when a flaky connection drops mid-body. Successful API responses
+// are read into memory before being returned, and a body that ends early (an
+// io.ErrUnexpectedEOF while reading or decoding it) is retried within the
+// WithRetryConfig budget like any other transient failure. File downloads are
+// not affected.
+func WithRetryOnDecodeError() ClientOption {
+	return func(c *Client) {
+		c.retryOnDecodeError = true
+	}
+}
+
+// WithRetryCallback registers a function invoked before each retry sleep with the
+// upcoming retry number (1 for the first retry), the error that triggered it, and
+// the backoff delay. It fires for both retryable status codes and network errors.
+func WithRetryCallback(callback func(attempt int, err error, delay time.Duration)) ClientOption {
+	return func(c *Client) {
+		c.retryCallback = callback
+	}
+}
+
+// WithRequestInterceptor registers a hook invoked before every HTTP attempt.
+// Multiple interceptors run in registration order.
+func WithRequestInterceptor(interceptor RequestInterceptor) ClientOption {
+	return func(c *Client) {
+		c.requestInterceptors = append(c.requestInterceptors, interceptor)
+	}
+}
+
+// WithResponseInterceptor registers a hook invoked after every HTTP attempt.
+// Multiple interceptors run in registration order.
+func WithResponseInterceptor(interceptor ResponseInterceptor) ClientOption {
+	return func(c *Client) {
+		c.responseInterceptors = append(c.responseInterceptors, interceptor)
 	}
 }
 
@@ -249,6 +938,9 @@ func NewClient(apiToken string, options ...ClientOption) *Client {
 		maxRetries:      DefaultMaxRetries,
 		retryDelay:      DefaultRetryDelay,
 		maxRetryDelay:   DefaultMaxRetryDelay,
+
+		hashCacheTTL:         DefaultHashCacheTTL,
+		hashNotFoundCacheTTL: DefaultHashNotFoundCacheTTL,
 	}
 
 	// Apply options
@@ -256,20 +948,172 @@ func NewClient(apiToken string, options ...ClientOption) *Client {
 		option(client)
 	}
 
+	client.httpClient = client.transport.buildHTTPClient(client.httpClient)
+	client.applyCreatorsDefaults()
+
 	return client
 }
 
+// applyCreatorsDefaults gives the creators endpoint a longer timeout and more
+// retries than the rest of the API, unless the caller configured them
+func (c *Client) applyCreatorsDefaults() {
+	if _, ok := c.endpointTimeouts[EndpointCreators]; !ok && c.httpClient.Timeout > 0 {
+		if c.endpointTimeouts == nil {
+			c.endpointTimeouts = make(map[string]time.Duration)
+		}
+		c.endpointTimeouts[EndpointCreators] = DefaultCreatorsTimeoutFactor * c.httpClient.Timeout
+	}
+	if _, ok := c.endpointRetries[EndpointCreators]; !ok {
+		if c.endpointRetries == nil {
+			c.endpointRetries = make(map[string]int)
+		}
+		c.endpointRetries[EndpointCreators] = max(c.maxRetries, 0) + DefaultCreatorsExtraRetries
+	}
+}
+
+// requestEndpoint returns the logical endpoint of a request to rawURL, which
+// opts can set explicitly
+func (c *Client) requestEndpoint(rawURL string, opts requestOptions) string {
+	if opts.endpoint != "" {
+		return opts.endpoint
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "other"
+	}
+	return c.endpointName(u)
+}
+
+// endpointContextKey holds the logical endpoint in request contexts
+type endpointContextKey struct{}
+
+// EndpointFromContext returns the logical endpoint (EndpointModels,
+// EndpointDownloads, "other", etc.) of the request whose context is ctx, as
+// reported to WithResponseMetrics and after any WithEndpointPath override is
+// resolved. Request and response interceptors can use it to label requests
+// consistently with the SDK. It returns "" for contexts the client didn't
+// create.
+func EndpointFromContext(ctx context.Context) string {
+	endpoint, _ := ctx.Value(endpointContextKey{}).(string)
+	return endpoint
+}
+
+// endpointPolicy returns the per-attempt timeout and retry count for a request
+// to rawURL, applying WithRequestTimeoutPerEndpoint and WithMaxRetriesPerEndpoint
+func (c *Client) endpointPolicy(rawURL string, opts requestOptions) (time.Duration, int) {
+	timeout, maxRetries := c.httpClient.Timeout, c.maxRetries
+
+	endpoint := c.requestEndpoint(rawURL, opts)
+	if t, ok := c.endpointTimeouts[endpoint]; ok {
+		timeout = t
+	}
+	if n, ok := c.endpointRetries[endpoint]; ok {
+		maxRetries = n
+	}
+	return timeout, maxRetries
+}
+
 // NewClientWithoutAuth creates a new CivitAI API client without authentication
 // This can be used for public endpoints that don't require an API token
 func NewClientWithoutAuth(options ...ClientOption) *Client {
 	return NewClient("", options...)
 }
 
+// responseLimit returns the maximum response size for a response, applying the
+// most specific WithEndpointResponseLimit override for its request URL
+func (c *Client) responseLimit(resp *http.Response) int64 {
+	if len(c.endpointResponseLimits) == 0 || resp.Request == nil || resp.Request.URL == nil {
+		return c.maxResponseSize
+	}
+
+	path := c.relativePath(resp.Request.URL)
+	limit, matched := c.maxResponseSize, -1
+	for endpoint, size := range c.endpointResponseLimits {
+		if (path == endpoint || strings.HasPrefix(path, endpoint+"/")) && len(endpoint) > matched {
+			limit, matched = size, len(endpoint)
+		}
+	}
+
+	return limit
+}
+
+// recordMetrics adds one request attempt to the WithResponseMetrics metrics
+func (c *Client) recordMetrics(req *http.Request, resp *http.Response, err error, duration time.Duration, endpoint string) {
+	info := &ResponseInfo{ResponseTime: duration}
+	if resp != nil {
+		info.StatusCode = resp.StatusCode
+		info.Headers = resp.Header
+		info.Size = max(resp.ContentLength, 0)
+	}
+	if endpoint == "" {
+		endpoint = c.endpointName(req.URL)
+	}
+	c.metrics.UpdateEndpointMetrics(endpoint, info, err)
+}
+
+// endpointName maps a request URL to the logical endpoint whose path, after
+// any WithEndpointPath override, is its longest prefix. URLs outside every
+// endpoint are reported as "other".
+func (c *Client) endpointName(u *url.URL) string {
+	path := c.relativePath(u)
+	name, matched := "other", -1
+	for _, endpoint := range []string{EndpointModels, EndpointModelVersions, EndpointImages, EndpointCreators, EndpointTags} {
+		prefix := endpoint
+		if override, ok := c.endpointPaths[endpoint]; ok {
+			prefix = override
+		}
+		if (path == prefix || strings.HasPrefix(path, prefix+"/")) && len(prefix) > matched {
+			name, matched = endpoint, len(prefix)
+		}
+	}
+	return name
+}
+
+// relativePath returns the path of u relative to the base URL, without
+// surrounding slashes
+func (c *Client) relativePath(u *url.URL) string {
+	path := strings.Trim(u.Path, "/")
+	if base, err := url.Parse(c.baseURL); err == nil {
+		path = strings.Trim(strings.TrimPrefix(path, strings.Trim(base.Path, "/")), "/")
+	}
+	return path
+}
+
+// validateDefaultLimit rejects a WithDefaultLimit value above MaxLimit when a
+// call leaves limit unset and would use it
+func (c *Client) validateDefaultLimit(limit int) error {
+	if limit == 0 && c.defaultLimit > MaxLimit {
+		return fmt.Errorf("invalid default limit: %d exceeds the maximum of %d", c.defaultLimit, MaxLimit)
+	}
+	return nil
+}
+
+// effectiveLimit returns the per-call limit, or the WithDefaultLimit value when unset
+func (c *Client) effectiveLimit(limit int) int {
+	if limit > 0 {
+		return limit
+	}
+	return c.defaultLimit
+}
+
 // buildURL constructs a full URL from the base URL and path
 func (c *Client) buildURL(path string) string {
 	return fmt.Sprintf("%s/%s", c.baseURL, strings.TrimPrefix(path, "/"))
 }
 
+// endpointURL builds the URL of a logical endpoint, applying any
+// WithEndpointPath override, followed by sub-path segments
+func (c *Client) endpointURL(endpoint string, segments ...string) string {
+	path := endpoint
+	if override, ok := c.endpointPaths[endpoint]; ok {
+		path = override
+	}
+	for _, segment := range segments {
+		path += "/" + segment
+	}
+	return c.buildURL(path)
+}
+
 // addQueryParams adds query parameters to a URL
 func (c *Client) addQueryParams(baseURL string, params map[string]string) string {
 	if len(params) == 0 {
@@ -361,11 +1205,43 @@ func validateSearchParams(params SearchParams) error {
 	return nil
 }
 
+// validateModelTypes checks that every type is a ModelType constant
+func validateModelTypes(types []ModelType) error {
+	for _, t := range types {
+		known := false
+		for _, k := range knownModelTypes {
+			if t == k {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return fmt.Errorf("unknown model type %q", t)
+		}
+	}
+	return nil
+}
+
+// validateStrictSearchParams applies the cross-field checks enabled by
+// WithStrictValidation
+func (c *Client) validateStrictSearchParams(params SearchParams) error {
+	if params.Query != "" && params.Tag != "" {
+		return errors.New("query and tag cannot be used together")
+	}
+	if (params.Favorites || params.Hidden) && c.apiToken == "" {
+		return errors.New("favorites and hidden filters require an API token")
+	}
+	return nil
+}
+
 // validateImageParams validates image search parameters
 func (c *Client) validateImageParams(params ImageParams) error {
 	if params.Limit < 0 || params.Limit > 200 {
 		return errors.New("limit must be between 0 and 200")
 	}
+	if err := c.validateDefaultLimit(params.Limit); err != nil {
+		return err
+	}
 	if params.Page < 0 {
 		return errors.New("page cannot be negative")
 	}
@@ -381,7 +1257,54 @@ func (c *Client) validateImageParams(params ImageParams) error {
 	if len(params.Username) > 100 {
 		return errors.New("username parameter too long (max 100 characters)")
 	}
-	return nil
+	if _, ok := imageNSFWValue(params.NSFW); !ok {
+		return fmt.Errorf("unknown NSFW level %q (expected None, Soft, Mature, X, true, or false)", params.NSFW)
+	}
+	if _, ok := imageSortValue(params.Sort); !ok {
+		return fmt.Errorf("unknown image sort %q (expected Most Reactions, Most Comments, or Newest)", params.Sort)
+	}
+	if c.strictValidation {
+		if params.ModelID > 0 && params.ModelVersionID > 0 {
+			return errors.New("model ID and model version ID cannot be used together")
+		}
+		if params.PostID > 0 && (params.ModelID > 0 || params.ModelVersionID > 0) {
+			return errors.New("post ID cannot be combined with a model or model version ID")
+		}
+	}
+	return validateCursor(params.Cursor, params.Page, c.strictValidation)
+}
+
+// imageNSFWValue returns the canonical spelling of an ImageParams NSFW value,
+// matching NSFWLevel constants and the booleans the images endpoint also
+// accepts case-insensitively. An empty value is valid and stays empty.
+func imageNSFWValue(nsfw string) (string, bool) {
+	switch {
+	case nsfw == "":
+		return "", true
+	case strings.EqualFold(nsfw, "true"), strings.EqualFold(nsfw, "false"):
+		return strings.ToLower(nsfw), true
+	}
+	for _, level := range knownNSFWLevels {
+		if strings.EqualFold(nsfw, string(level)) {
+			return string(level), true
+		}
+	}
+	return "", false
+}
+
+// imageSortValue returns the canonical spelling of an ImageParams Sort value,
+// matching ImageSort constants case-insensitively. An empty value is valid and
+// stays empty.
+func imageSortValue(sort string) (string, bool) {
+	if sort == "" {
+		return "", true
+	}
+	for _, known := range knownImageSorts {
+		if strings.EqualFold(sort, string(known)) {
+			return string(known), true
+		}
+	}
+	return "", false
 }
 
 // validateCreatorParams validates creator search parameters
@@ -389,13 +1312,16 @@ func (c *Client) validateCreatorParams(params CreatorParams) error {
 	if params.Limit < 0 || params.Limit > 200 {
 		return errors.New("limit must be between 0 and 200")
 	}
+	if err := c.validateDefaultLimit(params.Limit); err != nil {
+		return err
+	}
 	if params.Page < 0 {
 		return errors.New("page cannot be negative")
 	}
 	if len(params.Query) > 500 {
 		return errors.New("query parameter too long (max 500 characters)")
 	}
-	return nil
+	return validateCursor(params.Cursor, params.Page, c.strictValidation)
 }
 
 // validateTagParams validates tag search parameters
@@ -403,11 +1329,26 @@ func (c *Client) validateTagParams(params TagParams) error {
 	if params.Limit < 0 || params.Limit > 200 {
 		return errors.New("limit must be between 0 and 200")
 	}
+	if err := c.validateDefaultLimit(params.Limit); err != nil {
+		return err
+	}
 	if params.Page < 0 {
 		return errors.New("page cannot be negative")
 	}
-	if len(params.Query) > 500 {
-		return errors.New("query parameter too long (max 500 characters)")
+	if len(params.Query) > 500 {
+		return errors.New("query parameter too long (max 500 characters)")
+	}
+	return validateCursor(params.Cursor, params.Page, c.strictValidation)
+}
+
+// validateCursor checks a pagination cursor. With strict validation a cursor
+// can't be combined with a page; otherwise the cursor takes precedence.
+func validateCursor(cursor string, page int, strict bool) error {
+	if strict && cursor != "" && page > 0 {
+		return errors.New("cursor and page cannot be used together")
+	}
+	if len(cursor) > 500 {
+		return errors.New("cursor parameter too long (max 500 characters)")
 	}
 	return nil
 }
@@ -452,8 +1393,10 @@ func (c *Client) calculateBackoffDelay(attempt int) time.Duration {
 	delay := time.Duration(float64(c.retryDelay) * math.Pow(2, float64(attempt)))
 
 	// Add jitter (±25% random variation)
-	jitter := time.Duration(float64(delay) * 0.25 * (2*rand.Float64() - 1))
-	delay += jitter
+	if !c.disableJitter {
+		jitter := time.Duration(float64(delay) * 0.25 * (2*rand.Float64() - 1))
+		delay += jitter
+	}
 
 	// Cap at maximum delay
 	if delay > c.maxRetryDelay {
@@ -463,11 +1406,47 @@ func (c *Client) calculateBackoffDelay(attempt int) time.Duration {
 	return delay
 }
 
+// requestOptions carries per-request overrides for doRequestWithOptions
+type requestOptions struct {
+	headers   http.Header // extra headers, applied after the defaults
+	noTimeout bool        // bypass the client timeout; the context still bounds the request
+	endpoint  string      // logical endpoint for metrics; derived from the URL when empty
+}
+
 // doRequest executes an HTTP request with retry logic and returns the response
 func (c *Client) doRequest(ctx context.Context, method, url string, body []byte) (*http.Response, error) {
+	if c.cache != nil && method == "GET" && body == nil {
+		return c.doCachedRequest(ctx, url)
+	}
+	return c.doRequestWithOptions(ctx, method, url, body, requestOptions{})
+}
+
+// doRequestWithOptions executes an HTTP request with retry logic and per-request overrides
+func (c *Client) doRequestWithOptions(ctx context.Context, method, url string, body []byte, opts requestOptions) (*http.Response, error) {
 	var lastErr error
 
-	for attempt := 0; attempt <= c.maxRetries; attempt++ {
+	endpoint := c.requestEndpoint(url, opts)
+	ctx = context.WithValue(ctx, endpointContextKey{}, endpoint)
+
+	timeout, maxRetries := c.endpointPolicy(url, opts)
+	if opts.noTimeout {
+		timeout = 0
+	}
+	httpClient := c.httpClient
+	if timeout != httpClient.Timeout {
+		clientCopy := *httpClient
+		clientCopy.Timeout = timeout
+		httpClient = &clientCopy
+	}
+
+	c.warnMissingDeadline(ctx, method, url)
+
+	var requestID string
+	if c.requestIDGenerator != nil {
+		requestID = c.requestIDGenerator()
+	}
+
+	for attempt := 0; attempt <= maxRetries; attempt++ {
 		// Create request for this attempt
 		var req *http.Request
 		var err error
@@ -485,23 +1464,74 @@ func (c *Client) doRequest(ctx context.Context, method, url string, body []byte)
 		// Set headers
 		req.Header.Set("User-Agent", c.userAgent)
 		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("Accept-Encoding", "gzip, deflate") // Request compression
+		if !c.transport.disableCompression {
+			req.Header.Set("Accept-Encoding", "gzip, deflate") // Request compression
+		}
+		if c.language != "" {
+			req.Header.Set("Accept-Language", c.language)
+		}
 
 		// Add authentication if token is provided
 		if c.apiToken != "" {
 			req.Header.Set("Authorization", "Bearer "+c.apiToken)
 		}
 
-		resp, err := c.httpClient.Do(req)
+		if requestID != "" {
+			req.Header.Set(RequestIDHeader, requestID)
+		}
+
+		for key, values := range opts.headers {
+			req.Header[key] = values
+		}
+
+		for _, intercept := range c.requestInterceptors {
+			if intercepted := intercept(req, attempt); intercepted != nil {
+				req = intercepted
+			}
+		}
+
+		release, err := c.acquireRequestSlot(req.Context())
+		if err != nil {
+			// Let response interceptors close out what request interceptors began
+			for _, intercept := range c.responseInterceptors {
+				intercept(req, nil, err, attempt)
+			}
+			return nil, err
+		}
+		start := time.Now()
+		resp, err := httpClient.Do(req)
+		release()
+		duration := time.Since(start)
+		c.logAttempt(req, resp, err, attempt, duration)
+		c.warnSlowRequest(req, duration)
+		if c.headerCallback != nil && resp != nil {
+			c.headerCallback(c.relativePath(req.URL), resp.Header)
+		}
+		if c.metrics != nil {
+			c.recordMetrics(req, resp, err, duration, endpoint)
+		}
+
+		for _, intercept := range c.responseInterceptors {
+			intercept(req, resp, err, attempt)
+		}
 
 		// If successful or non-retryable error, return immediately
 		if err == nil {
 			if !isRetryableStatusCode(resp.StatusCode) {
-				return resp, nil
+				if !c.shouldBufferBody(method, resp, opts) {
+					return resp, nil
+				}
+				if lastErr = c.bufferResponseBody(resp); lastErr == nil {
+					return resp, nil
+				}
+				if !errors.Is(lastErr, io.ErrUnexpectedEOF) && !isRetryableError(lastErr) {
+					return nil, lastErr
+				}
+			} else {
+				// Close response body for retryable status codes
+				resp.Body.Close()
+				lastErr = fmt.Errorf("HTTP %d: %s", resp.StatusCode, http.StatusText(resp.StatusCode))
 			}
-			// Close response body for retryable status codes
-			resp.Body.Close()
-			lastErr = fmt.Errorf("HTTP %d: %s", resp.StatusCode, http.StatusText(resp.StatusCode))
 		} else {
 			lastErr = err
 			if !isRetryableError(err) {
@@ -510,8 +1540,11 @@ func (c *Client) doRequest(ctx context.Context, method, url string, body []byte)
 		}
 
 		// Don't wait after the last attempt
-		if attempt < c.maxRetries {
+		if attempt < maxRetries {
 			delay := c.calculateBackoffDelay(attempt)
+			if c.retryCallback != nil {
+				c.retryCallback(attempt+1, lastErr, delay)
+			}
 
 			// Create timer with context cancellation support
 			timer := time.NewTimer(delay)
@@ -525,7 +1558,157 @@ func (c *Client) doRequest(ctx context.Context, method, url string, body []byte)
 		}
 	}
 
-	return nil, fmt.Errorf("failed to execute request after %d attempts: %w", c.maxRetries+1, lastErr)
+	// A context that ended during the final attempt takes precedence, so
+	// cancellation is never reported as exhaustion
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return nil, fmt.Errorf("%w: failed to execute request after %d attempts: %w", ErrRetriesExhausted, maxRetries+1, lastErr)
+}
+
+// logAttempt writes a log line for a completed HTTP attempt
+func (c *Client) logAttempt(req *http.Request, resp *http.Response, err error, attempt int, duration time.Duration) {
+	if c.logger == nil {
+		return
+	}
+
+	outcome := "error=" + fmt.Sprint(err)
+	if err == nil {
+		outcome = "status=" + strconv.Itoa(resp.StatusCode)
+	}
+
+	if id := req.Header.Get(RequestIDHeader); id != "" {
+		c.logger.Printf("civitai: %s %s attempt=%d %s duration=%v request_id=%s", req.Method, req.URL, attempt+1, outcome, duration, id)
+	} else {
+		c.logger.Printf("civitai: %s %s attempt=%d %s duration=%v", req.Method, req.URL, attempt+1, outcome, duration)
+	}
+}
+
+// warningLogger returns the WithLogger logger, or the standard logger when
+// none is set, so opt-in warnings are never silently dropped
+func (c *Client) warningLogger() Logger {
+	if c.logger != nil {
+		return c.logger
+	}
+	return log.Default()
+}
+
+// warnSlowRequest logs attempts slower than WithSlowRequestThreshold
+func (c *Client) warnSlowRequest(req *http.Request, duration time.Duration) {
+	if c.slowRequestThreshold <= 0 || duration <= c.slowRequestThreshold {
+		return
+	}
+
+	c.warningLogger().Printf("civitai: warning: slow request %s %s took %v (threshold %v)",
+		req.Method, req.URL.Path, duration.Round(time.Millisecond), c.slowRequestThreshold)
+}
+
+// shouldBufferBody reports whether a response body is read up front so that
+// WithRetryOnDecodeError can retry truncated bodies
+func (c *Client) shouldBufferBody(method string, resp *http.Response, opts requestOptions) bool {
+	return c.retryOnDecodeError && !opts.noTimeout && method != "HEAD" &&
+		resp.StatusCode >= 200 && resp.StatusCode < 300
+}
+
+// bufferResponseBody reads a successful response body into memory for
+// WithRetryOnDecodeError, returning an error wrapping io.ErrUnexpectedEOF when
+// the body was cut short. Bodies over the response size limit are left for
+// handleResponse to reject.
+func (c *Client) bufferResponseBody(resp *http.Response) error {
+	limit := c.responseLimit(resp)
+	raw, err := io.ReadAll(io.LimitReader(resp.Body, limit+1))
+	if err != nil {
+		resp.Body.Close()
+		return fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if int64(len(raw)) > limit {
+		resp.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(raw), resp.Body), resp.Body}
+		return nil
+	}
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(raw))
+
+	// A well-formed HTTP body can still hold truncated JSON, so check that
+	// it decodes to the end
+	var reader io.Reader = bytes.NewReader(raw)
+	if resp.Header.Get("Content-Encoding") == "gzip" {
+		gzipReader, err := gzip.NewReader(reader)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				err = io.ErrUnexpectedEOF
+			}
+			return fmt.Errorf("truncated response body: %w", err)
+		}
+		defer gzipReader.Close()
+		reader = gzipReader
+	}
+
+	var value json.RawMessage
+	if err := json.NewDecoder(reader).Decode(&value); errors.Is(err, io.ErrUnexpectedEOF) {
+		return fmt.Errorf("truncated response body: %w", err)
+	}
+
+	return nil
+}
+
+// warnMissingDeadline logs a warning for retrying requests without a context
+// deadline when WithDeadlineWarnings is set
+func (c *Client) warnMissingDeadline(ctx context.Context, method, url string) {
+	if !c.deadlineWarnings || c.maxRetries <= 0 {
+		return
+	}
+	if _, ok := ctx.Deadline(); ok {
+		return
+	}
+
+	c.warningLogger().Printf("civitai: warning: %s %s has no context deadline and may retry up to %d times with backoff up to %v; consider context.WithTimeout",
+		method, url, c.maxRetries, c.maxRetryDelay)
+}
+
+// reportProgress emits a ProgressEvent when WithProgressReporter is set
+func (c *Client) reportProgress(operation string, processed, total int) {
+	if c.progressReporter == nil {
+		return
+	}
+	c.progressReporter(ProgressEvent{Operation: operation, Processed: processed, Total: total})
+}
+
+// acquireRequestSlot waits for a free slot when WithMaxConcurrentRequests is set
+// and returns a function that releases it
+func (c *Client) acquireRequestSlot(ctx context.Context) (func(), error) {
+	if c.requestSlots == nil {
+		return func() {}, nil
+	}
+
+	release := func() { <-c.requestSlots }
+
+	// Take a free slot without allocating a timer
+	select {
+	case c.requestSlots <- struct{}{}:
+		return release, nil
+	default:
+	}
+
+	var timeout <-chan time.Time
+	if c.semaphoreAcquireTimeout > 0 {
+		timer := time.NewTimer(c.semaphoreAcquireTimeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	select {
+	case c.requestSlots <- struct{}{}:
+		return release, nil
+	case <-timeout:
+		return nil, fmt.Errorf("%w: no request slot available after %v", ErrTooBusy, c.semaphoreAcquireTimeout)
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
 }
 
 // handleResponse processes the HTTP response and unmarshals JSON
@@ -544,21 +1727,73 @@ func (c *Client) handleResponse(resp *http.Response, target interface{}) error {
 	}
 
 	// Apply response size limit to prevent DoS attacks
-	limitedReader := io.LimitReader(reader, c.maxResponseSize)
+	maxSize := c.responseLimit(resp)
+	limitedReader := &io.LimitedReader{R: reader, N: maxSize}
+	var body io.Reader = limitedReader
+
+	accepted := false
+	if c.errorClassifier != nil {
+		var err error
+		if accepted, body, err = c.classifyError(resp, body); err != nil {
+			return err
+		}
+	}
+
+	success := accepted || (resp.StatusCode >= 200 && resp.StatusCode < 300)
+	if c.charsetDetection {
+		raw, err := io.ReadAll(body)
+		if err != nil {
+			return fmt.Errorf("failed to read response body: %w", err)
+		}
+		decoded, err := transcodeToUTF8(raw, resp.Header.Get("Content-Type"))
+		switch {
+		case err == nil:
+			body = bytes.NewReader(decoded)
+		case limitedReader.N <= 0:
+			return &ResponseTooLargeError{Limit: maxSize}
+		case success:
+			return fmt.Errorf("failed to decode response: %w", err)
+		default:
+			// Error bodies are decoded best-effort, so keep the raw bytes
+			body = bytes.NewReader(raw)
+		}
+	}
+
+	// Keep a copy of what is read for WithErrorResponseSnapshot
+	var snapshot *responseSnapshot
+	if c.errorSnapshotBytes > 0 {
+		snapshot = &responseSnapshot{
+			limit:   c.errorSnapshotBytes,
+			capture: c.errorSnapshotBytes + len(c.apiToken) + snapshotRedactionMargin,
+		}
+		body = io.TeeReader(body, snapshot)
+	}
 
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+	if !success {
 		var apiErr APIError
-		if err := json.NewDecoder(limitedReader).Decode(&apiErr); err != nil {
-			return fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, resp.Status)
+		if err := json.NewDecoder(body).Decode(&apiErr); err != nil {
+			if snapshot != nil {
+				return &statusError{status: resp.StatusCode, err: snapshot.apiError(body, resp.StatusCode, "", resp.Status, c.apiToken)}
+			}
+			return &statusError{status: resp.StatusCode, err: fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, resp.Status)}
 		}
-		return fmt.Errorf("API error [%s]: %s", apiErr.Code, apiErr.Message)
+		if snapshot != nil {
+			return &statusError{status: resp.StatusCode, err: snapshot.apiError(body, resp.StatusCode, apiErr.Code, apiErr.Message, c.apiToken)}
+		}
+		return &statusError{status: resp.StatusCode, err: fmt.Errorf("API error [%s]: %s", apiErr.Code, apiErr.Message)}
 	}
 
 	if target != nil {
-		decoder := json.NewDecoder(limitedReader)
+		decoder := json.NewDecoder(body)
+		if c.strictJSON {
+			decoder.DisallowUnknownFields()
+		}
 		if err := decoder.Decode(target); err != nil {
-			if err == io.EOF || err == io.ErrUnexpectedEOF {
-				return fmt.Errorf("response size exceeded maximum allowed size of %d bytes", c.maxResponseSize)
+			if (err == io.EOF || err == io.ErrUnexpectedEOF) && limitedReader.N <= 0 {
+				return &ResponseTooLargeError{Limit: maxSize}
+			}
+			if snapshot != nil {
+				return fmt.Errorf("failed to decode response: %w: %w", err, snapshot.apiError(body, resp.StatusCode, "", "malformed response body", c.apiToken))
 			}
 			return fmt.Errorf("failed to decode response: %w", err)
 		}
@@ -567,14 +1802,65 @@ func (c *Client) handleResponse(resp *http.Response, target interface{}) error {
 	return nil
 }
 
+// classifyError runs the WithErrorClassifier function on a copy of resp whose
+// body is the decoded body, reporting whether it accepted the response and
+// returning a reader over the same bytes for the default handling
+func (c *Client) classifyError(resp *http.Response, body io.Reader) (bool, io.Reader, error) {
+	raw, err := io.ReadAll(body)
+	if err != nil {
+		return false, nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	decoded := *resp
+	decoded.Header = resp.Header.Clone()
+	decoded.Header.Del("Content-Encoding")
+	decoded.Header.Del("Content-Length")
+	decoded.ContentLength = int64(len(raw))
+	decoded.Uncompressed = true
+	decoded.Body = io.NopCloser(bytes.NewReader(raw))
+	classifyErr := c.errorClassifier(&decoded)
+
+	if errors.Is(classifyErr, ErrAcceptResponse) {
+		return true, bytes.NewReader(raw), nil
+	}
+	return false, bytes.NewReader(raw), classifyErr
+}
+
 // SearchModels searches for models with the given parameters
 func (c *Client) SearchModels(ctx context.Context, params SearchParams) ([]Model, *Metadata, error) {
+	if params.Period == "" {
+		params.Period = c.defaultPeriod
+	}
+	if len(params.Types) == 0 && len(c.defaultTypes) > 0 {
+		if err := validateModelTypes(c.defaultTypes); err != nil {
+			return nil, nil, fmt.Errorf("invalid default types: %w", err)
+		}
+		params.Types = c.defaultTypes
+	}
+	return c.searchModels(ctx, params)
+}
+
+// searchModels runs a model search without WithDefaultPeriod and
+// WithDefaultTypes, for lookups whose results must not depend on the client's
+// browsing defaults
+func (c *Client) searchModels(ctx context.Context, params SearchParams) ([]Model, *Metadata, error) {
 	if err := validateSearchParams(params); err != nil {
 		return nil, nil, fmt.Errorf("invalid search parameters: %w", err)
 	}
+	if err := c.validateDefaultLimit(params.Limit); err != nil {
+		return nil, nil, err
+	}
+	if err := validateCursor(params.Cursor, params.Page, c.strictValidation); err != nil {
+		return nil, nil, fmt.Errorf("invalid search parameters: %w", err)
+	}
+	if c.strictValidation {
+		if err := c.validateStrictSearchParams(params); err != nil {
+			return nil, nil, fmt.Errorf("invalid search parameters: %w", err)
+		}
+	}
 
 	queryParams := c.buildSearchParams(params)
-	url := c.addQueryParams(c.buildURL("models"), queryParams)
+	url := c.addQueryParams(c.endpointURL(EndpointModels), queryParams)
 
 	resp, err := c.doRequest(ctx, "GET", url, nil)
 	if err != nil {
@@ -587,19 +1873,145 @@ func (c *Client) SearchModels(ctx context.Context, params SearchParams) ([]Model
 	}
 
 	if err := c.handleResponse(resp, &apiResp); err != nil {
-		return nil, nil, err
+		return nil, nil, c.authRequiredError(params, err)
+	}
+	for i := range apiResp.Items {
+		c.applyBaseModelAliases(apiResp.Items[i].ModelVersions)
+	}
+
+	if c.filterBaseModels && len(params.BaseModels) > 0 {
+		baseModels := make([]BaseModel, len(params.BaseModels))
+		for i, bm := range params.BaseModels {
+			baseModels[i] = c.normalizeBaseModel(string(bm))
+		}
+		apiResp.Items = filterModelsByBaseModel(apiResp.Items, baseModels)
+	}
+	if c.maxInlineVersions > 0 {
+		for i := range apiResp.Items {
+			apiResp.Items[i].TrimVersions(c.maxInlineVersions)
+		}
 	}
 
 	return apiResp.Items, apiResp.Metadata, nil
 }
 
+// authRequiredError adds guidance to a 401 or 403 from a favorites or hidden
+// search, which the API rejects without a valid token; other errors are
+// returned unchanged. The result matches ErrAuthRequired and wraps err.
+func (c *Client) authRequiredError(params SearchParams, err error) error {
+	if !params.Favorites && !params.Hidden {
+		return err
+	}
+	if status := responseStatus(err); status != http.StatusUnauthorized && status != http.StatusForbidden {
+		return err
+	}
+
+	if c.apiToken == "" {
+		return fmt.Errorf("%w: favorites and hidden searches need an API token; configure a client with NewClient(token): %w", ErrAuthRequired, err)
+	}
+	return fmt.Errorf("%w: favorites and hidden searches need a valid API token; check the token passed to NewClient: %w", ErrAuthRequired, err)
+}
+
+// SearchModelsPrev fetches the page before the one meta describes, so a UI can
+// step back through results. params should be the same search that returned
+// meta; its Cursor and Page are replaced. The previous position is taken from
+// meta.PrevCursor, then the cursor or page in meta.PrevPage, then
+// meta.CurrentPage. It returns ErrNoPreviousPage when none of these point
+// before the current page.
+func (c *Client) SearchModelsPrev(ctx context.Context, params SearchParams, meta *Metadata) ([]Model, *Metadata, error) {
+	cursor, page, err := previousPosition(meta)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	params.Cursor, params.Page = cursor, page
+	return c.SearchModels(ctx, params)
+}
+
+// previousPosition returns the cursor or page number of the page before meta
+func previousPosition(meta *Metadata) (string, int, error) {
+	if meta == nil {
+		return "", 0, ErrNoPreviousPage
+	}
+	if meta.PrevCursor != "" {
+		return meta.PrevCursor, 0, nil
+	}
+	if meta.PrevPage != "" {
+		if u, err := url.Parse(meta.PrevPage); err == nil {
+			query := u.Query()
+			if cursor := query.Get("cursor"); cursor != "" {
+				return cursor, 0, nil
+			}
+			if page, err := strconv.Atoi(query.Get("page")); err == nil && page > 0 {
+				return "", page, nil
+			}
+		}
+	}
+	if meta.CurrentPage > 1 {
+		return "", meta.CurrentPage - 1, nil
+	}
+	return "", 0, ErrNoPreviousPage
+}
+
+// filterModelsByBaseModel keeps only the versions built on one of baseModels,
+// dropping models with no such version. The input models are not modified.
+func filterModelsByBaseModel(models []Model, baseModels []BaseModel) []Model {
+	allowed := make(map[BaseModel]bool, len(baseModels))
+	for _, bm := range baseModels {
+		allowed[NormalizeBaseModel(string(bm))] = true
+	}
+
+	filtered := make([]Model, 0, len(models))
+	for _, model := range models {
+		var versions []ModelVersion
+		for _, version := range model.ModelVersions {
+			if allowed[NormalizeBaseModel(string(version.BaseModel))] {
+				versions = append(versions, version)
+			}
+		}
+		if len(versions) == 0 {
+			continue
+		}
+		model.ModelVersions = versions
+		filtered = append(filtered, model)
+	}
+	return filtered
+}
+
+// normalizeBaseModel applies NormalizeBaseModel and then any WithBaseModelAlias
+// aliases
+func (c *Client) normalizeBaseModel(s string) BaseModel {
+	model := NormalizeBaseModel(s)
+	if alias, ok := c.baseModelAliases[baseModelAliasKey(string(model))]; ok {
+		return alias
+	}
+	return model
+}
+
+// applyBaseModelAlias rewrites baseModel through normalizeBaseModel. Decoding
+// already applies NormalizeBaseModel, so this is a no-op without
+// WithBaseModelAlias aliases.
+func (c *Client) applyBaseModelAlias(baseModel *BaseModel) {
+	if len(c.baseModelAliases) == 0 {
+		return
+	}
+	*baseModel = c.normalizeBaseModel(string(*baseModel))
+}
+
+// applyBaseModelAliases applies applyBaseModelAlias to each version
+func (c *Client) applyBaseModelAliases(versions []ModelVersion) {
+	for i := range versions {
+		c.applyBaseModelAlias(&versions[i].BaseModel)
+	}
+}
+
 // GetModel retrieves a specific model by ID
 func (c *Client) GetModel(ctx context.Context, modelID int) (*Model, error) {
 	if err := validateModelID(modelID); err != nil {
 		return nil, fmt.Errorf("invalid model ID: %w", err)
 	}
 
-	url := c.buildURL(fmt.Sprintf("models/%d", modelID))
+	url := c.endpointURL(EndpointModels, strconv.Itoa(modelID))
 
 	resp, err := c.doRequest(ctx, "GET", url, nil)
 	if err != nil {
@@ -610,6 +2022,8 @@ func (c *Client) GetModel(ctx context.Context, modelID int) (*Model, error) {
 	if err := c.handleResponse(resp, &model); err != nil {
 		return nil, err
 	}
+	c.applyBaseModelAliases(model.ModelVersions)
+	model.TrimVersions(c.maxInlineVersions)
 
 	return &model, nil
 }
@@ -620,7 +2034,7 @@ func (c *Client) GetModelVersion(ctx context.Context, versionID int) (*ModelVers
 		return nil, fmt.Errorf("invalid version ID: %w", err)
 	}
 
-	url := c.buildURL(fmt.Sprintf("model-versions/%d", versionID))
+	url := c.endpointURL(EndpointModelVersions, strconv.Itoa(versionID))
 
 	resp, err := c.doRequest(ctx, "GET", url, nil)
 	if err != nil {
@@ -631,17 +2045,102 @@ func (c *Client) GetModelVersion(ctx context.Context, versionID int) (*ModelVers
 	if err := c.handleResponse(resp, &version); err != nil {
 		return nil, err
 	}
+	c.applyBaseModelAlias(&version.BaseModel)
 
 	return &version, nil
 }
 
+// GetModelVersionFile retrieves a model version and selects the file matching pref.
+// It returns an error wrapping ErrNoMatchingFile when no file satisfies the preference.
+func (c *Client) GetModelVersionFile(ctx context.Context, versionID int, pref FilePreference) (*ModelVersion, *File, error) {
+	version, err := c.GetModelVersion(ctx, versionID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	file := version.SelectFile(pref)
+	if file == nil {
+		return version, nil, fmt.Errorf("%w: version %d has %d files, none matching %s",
+			ErrNoMatchingFile, versionID, len(version.Files), pref)
+	}
+
+	return version, file, nil
+}
+
+// RefreshVersionAvailability re-fetches a model version to check whether it is
+// still live and returns one of the VersionAvailability constants, for
+// periodic mirror checks. A version that no longer exists reports
+// VersionAvailabilityDeleted rather than an error.
+//
+// The API has no availability-only endpoint, so this makes the same request
+// as GetModelVersion and downloads the whole version; it only saves decoding
+// everything but the availability and model mode fields. Use GetModelVersion
+// instead when the rest of the version is needed too.
+func (c *Client) RefreshVersionAvailability(ctx context.Context, versionID int) (string, error) {
+	if err := validateVersionID(versionID); err != nil {
+		return "", fmt.Errorf("invalid version ID: %w", err)
+	}
+
+	url := c.endpointURL(EndpointModelVersions, strconv.Itoa(versionID))
+
+	resp, err := c.doRequest(ctx, "GET", url, nil)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		resp.Body.Close()
+		return VersionAvailabilityDeleted, nil
+	}
+
+	// Decode raw first so the partial struct below is unaffected by WithStrictJSON
+	var raw json.RawMessage
+	if err := c.handleResponse(resp, &raw); err != nil {
+		return "", err
+	}
+
+	var status struct {
+		Availability string `json:"availability"`
+		Model        struct {
+			Mode string `json:"mode"`
+		} `json:"model"`
+	}
+	if err := json.Unmarshal(raw, &status); err != nil {
+		return "", fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	switch status.Model.Mode {
+	case "Archived":
+		return VersionAvailabilityArchived, nil
+	case "TakenDown":
+		return VersionAvailabilityTakenDown, nil
+	}
+
+	switch status.Availability {
+	case "EarlyAccess":
+		return VersionAvailabilityEarlyAccess, nil
+	case "Private", "Unsearchable":
+		return VersionAvailabilityPrivate, nil
+	default:
+		return VersionAvailabilityAvailable, nil
+	}
+}
+
+// CompatibleBaseModels returns the base models a version is compatible with,
+// using the graph from WithCompatibilityGraph or the default graph
+func (c *Client) CompatibleBaseModels(version *ModelVersion) []BaseModel {
+	if c.compatibilityGraph != nil {
+		return version.CompatibleBaseModelsIn(c.compatibilityGraph)
+	}
+	return version.GetCompatibleBaseModels()
+}
+
 // GetModelVersionsByModelID retrieves all versions for a specific model
 func (c *Client) GetModelVersionsByModelID(ctx context.Context, modelID int) ([]ModelVersion, error) {
 	if err := validateModelID(modelID); err != nil {
 		return nil, fmt.Errorf("invalid model ID: %w", err)
 	}
 
-	url := c.buildURL(fmt.Sprintf("models/%d/versions", modelID))
+	url := c.endpointURL(EndpointModels, strconv.Itoa(modelID), "versions")
 
 	resp, err := c.doRequest(ctx, "GET", url, nil)
 	if err != nil {
@@ -652,10 +2151,28 @@ func (c *Client) GetModelVersionsByModelID(ctx context.Context, modelID int) ([]
 	if err := c.handleResponse(resp, &versions); err != nil {
 		return nil, err
 	}
+	c.applyBaseModelAliases(versions)
 
 	return versions, nil
 }
 
+// GetLatestVersion returns the newest version of a model by creation date. It
+// fetches only the model's versions, which is cheaper than GetModel when
+// polling for updates. The error matches ErrNotFound when the model doesn't
+// exist or has no versions.
+func (c *Client) GetLatestVersion(ctx context.Context, modelID int) (*ModelVersion, error) {
+	versions, err := c.GetModelVersionsByModelID(ctx, modelID)
+	if err != nil {
+		return nil, err
+	}
+	if len(versions) == 0 {
+		return nil, fmt.Errorf("%w: model %d has no versions", ErrNotFound, modelID)
+	}
+
+	latest := SortVersions(versions, true)[0]
+	return &latest, nil
+}
+
 // GetModelVersionByHash retrieves a model version by file hash
 // GET /api/v1/model-versions/by-hash/:hash
 // Supports AutoV1, AutoV2, SHA256, CRC32, and Blake3 hash algorithms
@@ -664,22 +2181,40 @@ func (c *Client) GetModelVersionByHash(ctx context.Context, hash string) (*Model
 		return nil, fmt.Errorf("invalid hash: %w", err)
 	}
 
-	url := c.buildURL(fmt.Sprintf("model-versions/by-hash/%s", hash))
+	if c.cache != nil {
+		// A miss here falls through to the response cache, which counts it
+		if version, found, err := c.cachedVersionByHash(hash); found {
+			c.recordCacheLookup(true)
+			return version, err
+		}
+	}
+
+	url := c.endpointURL(EndpointModelVersions, "by-hash", hash)
 
 	resp, err := c.doRequest(ctx, "GET", url, nil)
 	if err != nil {
 		return nil, err
 	}
 
+	notFound := resp.StatusCode == http.StatusNotFound
 	var version ModelVersionByHashResponse
 	if err := c.handleResponse(resp, &version); err != nil {
+		if notFound && c.cache != nil {
+			c.storeVersionByHash(hash, nil, err)
+		}
 		return nil, err
 	}
+	c.applyBaseModelAlias(&version.BaseModel)
+
+	if c.cache != nil {
+		c.storeVersionByHash(hash, &version, nil)
+	}
 
 	return &version, nil
 }
 
-// buildSearchParams converts SearchParams to query parameters
+// buildSearchParams converts SearchParams to query parameters. When both Page
+// and Cursor are set, only the cursor is sent.
 func (c *Client) buildSearchParams(params SearchParams) map[string]string {
 	queryParams := make(map[string]string)
 
@@ -702,11 +2237,11 @@ func (c *Client) buildSearchParams(params SearchParams) map[string]string {
 	if params.Rating > 0 {
 		queryParams["rating"] = strconv.Itoa(params.Rating)
 	}
-	if params.Page > 0 {
+	if params.Page > 0 && params.Cursor == "" {
 		queryParams["page"] = strconv.Itoa(params.Page)
 	}
-	if params.Limit > 0 {
-		queryParams["limit"] = strconv.Itoa(params.Limit)
+	if limit := c.effectiveLimit(params.Limit); limit > 0 {
+		queryParams["limit"] = strconv.Itoa(limit)
 	}
 	if params.Cursor != "" {
 		queryParams["cursor"] = params.Cursor
@@ -752,6 +2287,13 @@ func (c *Client) buildSearchParams(params SearchParams) map[string]string {
 			queryParams["supportsGeneration"] = "false"
 		}
 	}
+	if len(params.BaseModels) > 0 {
+		var baseModels []string
+		for _, bm := range params.BaseModels {
+			baseModels = append(baseModels, string(bm))
+		}
+		queryParams["baseModels"] = strings.Join(baseModels, ",")
+	}
 
 	return queryParams
 }
@@ -759,7 +2301,7 @@ func (c *Client) buildSearchParams(params SearchParams) map[string]string {
 // Health checks the API health status
 func (c *Client) Health(ctx context.Context) error {
 	// CivitAI doesn't have a dedicated health endpoint, so we'll use a simple model request
-	url := c.buildURL("models")
+	url := c.endpointURL(EndpointModels)
 	queryParams := map[string]string{"limit": "1"}
 	url = c.addQueryParams(url, queryParams)
 
@@ -874,7 +2416,9 @@ func (c *Client) SearchModelsByAIRType(ctx context.Context, airType AIRType, par
 	return c.SearchModels(ctx, params)
 }
 
-// ConvertModelToAIR converts a CivitAI model to an AIR identifier
+// ConvertModelToAIR converts a CivitAI model to an AIR identifier. An empty
+// ecosystem is inferred from the model's tags, falling back to
+// DefaultAIREcosystem.
 func ConvertModelToAIR(model *Model, ecosystem string, versionID ...int) *AIR {
 	if model == nil {
 		return nil
@@ -882,8 +2426,8 @@ func ConvertModelToAIR(model *Model, ecosystem string, versionID ...int) *AIR {
 
 	// Determine ecosystem if not provided
 	if ecosystem == "" {
-		// Try to infer from model tags or default to sdxl
-		ecosystem = string(AIREcosystemSDXL)
+		// Try to infer from model tags or use the default ecosystem
+		ecosystem = string(DefaultAIREcosystem())
 		if model.Tags != nil {
 			for _, tag := range model.Tags {
 				switch strings.ToLower(tag) {
@@ -926,7 +2470,8 @@ func ConvertModelToAIR(model *Model, ecosystem string, versionID ...int) *AIR {
 	return air
 }
 
-// ConvertVersionToAIR converts a CivitAI model version to an AIR identifier
+// ConvertVersionToAIR converts a CivitAI model version to an AIR identifier.
+// An empty ecosystem becomes DefaultAIREcosystem.
 func ConvertVersionToAIR(version *ModelVersion, ecosystem string) *AIR {
 	if version == nil {
 		return nil
@@ -934,7 +2479,7 @@ func ConvertVersionToAIR(version *ModelVersion, ecosystem string) *AIR {
 
 	// Determine ecosystem if not provided
 	if ecosystem == "" {
-		ecosystem = string(AIREcosystemSDXL) // Default
+		ecosystem = string(DefaultAIREcosystem())
 	}
 
 	air := NewCivitAIModelAIR(ecosystem, version.ModelID, version.ID)
@@ -975,6 +2520,72 @@ func (c *Client) QuickSearch(ctx context.Context, query string, limit int) ([]Mo
 	return models, err
 }
 
+// findModelSearchLimit is how many search results FindModelByName considers
+const findModelSearchLimit = 20
+
+// FindModelByName searches for a model by name or URL slug and returns the best
+// match. Matching is best-effort since names are not unique: an exact
+// case-insensitive match wins, then a match ignoring punctuation and spacing
+// (so slugs like "realistic-vision" match), then a single partial match.
+// When several models match equally well the error wraps ErrAmbiguous.
+func (c *Client) FindModelByName(ctx context.Context, name string) (*Model, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, errors.New("name cannot be empty")
+	}
+
+	models, _, err := c.searchModels(ctx, SearchParams{Query: name, Limit: findModelSearchLimit})
+	if err != nil {
+		return nil, err
+	}
+
+	lowerName := strings.ToLower(strings.Join(strings.Fields(name), " "))
+	compactName := compactModelName(name)
+
+	tiers := []func(Model) bool{
+		func(m Model) bool { return strings.ToLower(strings.Join(strings.Fields(m.Name), " ")) == lowerName },
+		func(m Model) bool { return compactName != "" && compactModelName(m.Name) == compactName },
+		func(m Model) bool {
+			return compactName != "" && strings.Contains(compactModelName(m.Name), compactName)
+		},
+	}
+
+	for _, matches := range tiers {
+		var found []*Model
+		for i := range models {
+			if matches(models[i]) {
+				found = append(found, &models[i])
+			}
+		}
+
+		switch len(found) {
+		case 0:
+			continue
+		case 1:
+			return found[0], nil
+		default:
+			ids := make([]string, len(found))
+			for i, m := range found {
+				ids[i] = fmt.Sprintf("%d (%s)", m.ID, m.Name)
+			}
+			return nil, fmt.Errorf("%w: %d models match %q: %s", ErrAmbiguous, len(found), name, strings.Join(ids, ", "))
+		}
+	}
+
+	return nil, fmt.Errorf("no model found matching %q", name)
+}
+
+// compactModelName lowercases a name and strips everything but letters and digits
+func compactModelName(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
 // GetPopularModels returns the most downloaded models
 func (c *Client) GetPopularModels(ctx context.Context, limit int) ([]Model, error) {
 	models, _, err := c.SearchModels(ctx, SearchParams{
@@ -993,6 +2604,19 @@ func (c *Client) GetNewestModels(ctx context.Context, limit int) ([]Model, error
 	return models, err
 }
 
+// GetGenerationModels searches for models that can be used with CivitAI's
+// on-site generation, forcing SupportsGeneration to true. All other params are
+// applied as in SearchModels; explicitly asking for SupportsGeneration=false is
+// rejected since it contradicts the helper.
+func (c *Client) GetGenerationModels(ctx context.Context, params SearchParams) ([]Model, *Metadata, error) {
+	if params.SupportsGeneration != nil && !*params.SupportsGeneration {
+		return nil, nil, errors.New("GetGenerationModels cannot be used with SupportsGeneration=false")
+	}
+	supportsGeneration := true
+	params.SupportsGeneration = &supportsGeneration
+	return c.SearchModels(ctx, params)
+}
+
 // GetSafeImages returns safe-for-work images
 func (c *Client) GetSafeImages(ctx context.Context, limit int) ([]DetailedImageResponse, error) {
 	images, _, err := c.GetImages(ctx, ImageParams{
@@ -24,9 +24,17 @@ SOFTWARE.
 package civitai
 
 import (
+	"compress/gzip"
 	"context"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"runtime/debug"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -86,6 +94,43 @@ func TestBuildURL(t *testing.T) {
 	}
 }
 
+func TestWithEndpointPath(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		if strings.HasSuffix(r.URL.Path, "/1") {
+			w.Write([]byte(`{"id": 1}`))
+			return
+		}
+		w.Write([]byte(`{"items": [], "metadata": {}}`))
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	client := NewClientWithoutAuth(WithBaseURL(server.URL), WithEndpointPath(EndpointModels, "/v2/models/"))
+
+	if _, _, err := client.SearchModels(ctx, SearchParams{}); err != nil {
+		t.Fatalf("SearchModels failed: %v", err)
+	}
+	if _, err := client.GetModel(ctx, 1); err != nil {
+		t.Fatalf("GetModel failed: %v", err)
+	}
+	if _, _, err := client.GetImages(ctx, ImageParams{}); err != nil {
+		t.Fatalf("GetImages failed: %v", err)
+	}
+
+	expected := []string{"/v2/models", "/v2/models/1", "/images"}
+	if len(paths) != len(expected) {
+		t.Fatalf("Expected paths %v, got %v", expected, paths)
+	}
+	for i, path := range expected {
+		if paths[i] != path {
+			t.Errorf("Expected path %s, got %s", path, paths[i])
+		}
+	}
+}
+
 func TestHealth(t *testing.T) {
 	// Create a test server
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -132,3 +177,782 @@ func TestAPIError(t *testing.T) {
 		t.Errorf("Expected error message '%s', got '%s'", expected2, err2.Error())
 	}
 }
+
+func TestInterceptors(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		if r.Header.Get("X-Intercepted") != "yes" {
+			t.Error("Expected request interceptor header on request")
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"items": [], "metadata": {"totalItems": 0}}`))
+	}))
+	defer server.Close()
+
+	type ctxKey struct{}
+	var requestAttempts, responseAttempts []int
+	var statuses []int
+
+	client := NewClientWithoutAuth(
+		WithBaseURL(server.URL),
+		WithRetryConfig(2, 10*time.Millisecond, 50*time.Millisecond),
+		WithRequestInterceptor(func(req *http.Request, attempt int) *http.Request {
+			requestAttempts = append(requestAttempts, attempt)
+			req.Header.Set("X-Intercepted", "yes")
+			return req.WithContext(context.WithValue(req.Context(), ctxKey{}, attempt))
+		}),
+		WithResponseInterceptor(func(req *http.Request, resp *http.Response, err error, attempt int) {
+			if req.Context().Value(ctxKey{}) != attempt {
+				t.Errorf("Expected response interceptor to receive intercepted request for attempt %d", attempt)
+			}
+			if err != nil {
+				t.Errorf("Unexpected transport error: %v", err)
+				return
+			}
+			responseAttempts = append(responseAttempts, attempt)
+			statuses = append(statuses, resp.StatusCode)
+		}),
+	)
+
+	if _, _, err := client.SearchModels(context.Background(), SearchParams{Limit: 1}); err != nil {
+		t.Fatalf("SearchModels failed: %v", err)
+	}
+
+	if len(requestAttempts) != 2 || requestAttempts[0] != 0 || requestAttempts[1] != 1 {
+		t.Errorf("Expected request interceptor attempts [0 1], got %v", requestAttempts)
+	}
+	if len(responseAttempts) != 2 || responseAttempts[0] != 0 || responseAttempts[1] != 1 {
+		t.Errorf("Expected response interceptor attempts [0 1], got %v", responseAttempts)
+	}
+	if len(statuses) != 2 || statuses[0] != http.StatusServiceUnavailable || statuses[1] != http.StatusOK {
+		t.Errorf("Expected statuses [503 200], got %v", statuses)
+	}
+}
+
+func TestEndpointFromContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"items": [], "metadata": {}}`))
+	}))
+	defer server.Close()
+
+	var endpoints []string
+	client := NewClientWithoutAuth(
+		WithBaseURL(server.URL),
+		WithEndpointPath(EndpointModels, "v2/catalog"),
+		WithRequestInterceptor(func(req *http.Request, attempt int) *http.Request {
+			endpoints = append(endpoints, EndpointFromContext(req.Context()))
+			return nil
+		}),
+	)
+
+	ctx := context.Background()
+	if _, _, err := client.SearchModels(ctx, SearchParams{}); err != nil {
+		t.Fatalf("SearchModels failed: %v", err)
+	}
+	if _, _, err := client.GetTags(ctx, TagParams{}); err != nil {
+		t.Fatalf("GetTags failed: %v", err)
+	}
+
+	if len(endpoints) != 2 || endpoints[0] != EndpointModels || endpoints[1] != EndpointTags {
+		t.Errorf("Expected endpoints [models tags], got %v", endpoints)
+	}
+	if endpoint := EndpointFromContext(ctx); endpoint != "" {
+		t.Errorf("Expected no endpoint outside a request, got %q", endpoint)
+	}
+}
+
+func TestStrictJSON(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id": 123, "name": "Test Model", "type": "Checkpoint", "brandNewField": true, "createdAt": "2024-01-01T00:00:00Z", "updatedAt": "2024-01-01T00:00:00Z"}`))
+	}))
+	defer server.Close()
+
+	t.Run("Lenient mode ignores unknown fields", func(t *testing.T) {
+		client := NewClientWithoutAuth(WithBaseURL(server.URL))
+		model, err := client.GetModel(context.Background(), 123)
+		if err != nil {
+			t.Fatalf("Expected lenient decoding to succeed, got: %v", err)
+		}
+		if model.ID != 123 {
+			t.Errorf("Expected model ID 123, got %d", model.ID)
+		}
+	})
+
+	t.Run("Strict mode rejects unknown fields", func(t *testing.T) {
+		client := NewClientWithoutAuth(WithBaseURL(server.URL), WithStrictJSON())
+		_, err := client.GetModel(context.Background(), 123)
+		if err == nil {
+			t.Fatal("Expected strict decoding to fail on unknown field")
+		}
+		if !strings.Contains(err.Error(), "brandNewField") {
+			t.Errorf("Expected error to name the unknown field, got: %v", err)
+		}
+	})
+}
+
+// recordingLogger captures log lines for assertions
+type recordingLogger struct {
+	mu    sync.Mutex
+	lines []string
+}
+
+func (l *recordingLogger) Printf(format string, v ...interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.lines = append(l.lines, fmt.Sprintf(format, v...))
+}
+
+func TestWithRequestID(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		received []string
+		calls    int32
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		received = append(received, r.Header.Get(RequestIDHeader))
+		mu.Unlock()
+
+		// Fail the first attempt to check that retries reuse the ID
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id": 1, "name": "Test Model"}`))
+	}))
+	defer server.Close()
+
+	var counter int32
+	logger := &recordingLogger{}
+	client := NewClientWithoutAuth(
+		WithBaseURL(server.URL),
+		WithRetryConfig(1, time.Millisecond, time.Millisecond),
+		WithLogger(logger),
+		WithRequestID(func() string {
+			return fmt.Sprintf("req-%d", atomic.AddInt32(&counter, 1))
+		}),
+	)
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.GetModel(context.Background(), 1); err != nil {
+			t.Fatalf("GetModel failed: %v", err)
+		}
+	}
+
+	expected := []string{"req-1", "req-1", "req-2"}
+	if len(received) != len(expected) {
+		t.Fatalf("Expected %d requests, got %d", len(expected), len(received))
+	}
+	for i := range expected {
+		if received[i] != expected[i] {
+			t.Errorf("Request %d: expected ID %s, got %q", i, expected[i], received[i])
+		}
+	}
+
+	if len(logger.lines) != 3 {
+		t.Fatalf("Expected 3 log lines, got %d: %v", len(logger.lines), logger.lines)
+	}
+	if !strings.Contains(logger.lines[0], "status=503") || !strings.Contains(logger.lines[0], "request_id=req-1") {
+		t.Errorf("Expected first log line to include status and request ID, got %q", logger.lines[0])
+	}
+	if !strings.Contains(logger.lines[2], "attempt=1") || !strings.Contains(logger.lines[2], "request_id=req-2") {
+		t.Errorf("Expected last log line for req-2 attempt 1, got %q", logger.lines[2])
+	}
+
+	t.Run("No header by default", func(t *testing.T) {
+		received = nil
+		atomic.StoreInt32(&calls, 1)
+		plain := NewClientWithoutAuth(WithBaseURL(server.URL))
+		if _, err := plain.GetModel(context.Background(), 1); err != nil {
+			t.Fatalf("GetModel failed: %v", err)
+		}
+		if received[0] != "" {
+			t.Errorf("Expected no %s header, got %q", RequestIDHeader, received[0])
+		}
+	})
+}
+
+func TestWithDeadlineWarnings(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id": 1, "name": "Test Model"}`))
+	}))
+	defer server.Close()
+
+	countWarnings := func(logger *recordingLogger) int {
+		logger.mu.Lock()
+		defer logger.mu.Unlock()
+		count := 0
+		for _, line := range logger.lines {
+			if strings.Contains(line, "no context deadline") {
+				count++
+			}
+		}
+		return count
+	}
+
+	t.Run("Warns without deadline", func(t *testing.T) {
+		logger := &recordingLogger{}
+		client := NewClientWithoutAuth(WithBaseURL(server.URL), WithLogger(logger), WithDeadlineWarnings())
+
+		if _, err := client.GetModel(context.Background(), 1); err != nil {
+			t.Fatalf("GetModel failed: %v", err)
+		}
+		if countWarnings(logger) != 1 {
+			t.Errorf("Expected 1 deadline warning, got lines: %v", logger.lines)
+		}
+	})
+
+	t.Run("Silent with deadline", func(t *testing.T) {
+		logger := &recordingLogger{}
+		client := NewClientWithoutAuth(WithBaseURL(server.URL), WithLogger(logger), WithDeadlineWarnings())
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if _, err := client.GetModel(ctx, 1); err != nil {
+			t.Fatalf("GetModel failed: %v", err)
+		}
+		if countWarnings(logger) != 0 {
+			t.Errorf("Expected no deadline warning, got lines: %v", logger.lines)
+		}
+	})
+
+	t.Run("Silent when retries are disabled", func(t *testing.T) {
+		logger := &recordingLogger{}
+		client := NewClientWithoutAuth(WithBaseURL(server.URL), WithLogger(logger), WithDeadlineWarnings(), WithRetryConfig(0, 0, 0))
+
+		if _, err := client.GetModel(context.Background(), 1); err != nil {
+			t.Fatalf("GetModel failed: %v", err)
+		}
+		if countWarnings(logger) != 0 {
+			t.Errorf("Expected no deadline warning, got lines: %v", logger.lines)
+		}
+	})
+
+	t.Run("Opt-in only", func(t *testing.T) {
+		logger := &recordingLogger{}
+		client := NewClientWithoutAuth(WithBaseURL(server.URL), WithLogger(logger))
+
+		if _, err := client.GetModel(context.Background(), 1); err != nil {
+			t.Fatalf("GetModel failed: %v", err)
+		}
+		if countWarnings(logger) != 0 {
+			t.Errorf("Expected no deadline warning, got lines: %v", logger.lines)
+		}
+	})
+}
+
+func TestWithSlowRequestThreshold(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Path, "/creators") {
+			time.Sleep(50 * time.Millisecond)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"items": [], "metadata": {}}`))
+	}))
+	defer server.Close()
+
+	slowLines := func(logger *recordingLogger) []string {
+		logger.mu.Lock()
+		defer logger.mu.Unlock()
+		var lines []string
+		for _, line := range logger.lines {
+			if strings.Contains(line, "slow request") {
+				lines = append(lines, line)
+			}
+		}
+		return lines
+	}
+
+	t.Run("Logs slow requests", func(t *testing.T) {
+		logger := &recordingLogger{}
+		client := NewClientWithoutAuth(WithBaseURL(server.URL), WithLogger(logger), WithSlowRequestThreshold(20*time.Millisecond))
+
+		if _, _, err := client.GetCreators(context.Background(), CreatorParams{}); err != nil {
+			t.Fatalf("GetCreators failed: %v", err)
+		}
+		lines := slowLines(logger)
+		if len(lines) != 1 {
+			t.Fatalf("Expected 1 slow request warning, got lines: %v", logger.lines)
+		}
+		if !strings.Contains(lines[0], "/creators") {
+			t.Errorf("Expected endpoint in warning, got %q", lines[0])
+		}
+	})
+
+	t.Run("Silent for fast requests", func(t *testing.T) {
+		logger := &recordingLogger{}
+		client := NewClientWithoutAuth(WithBaseURL(server.URL), WithLogger(logger), WithSlowRequestThreshold(time.Second))
+
+		if _, _, err := client.GetCreators(context.Background(), CreatorParams{}); err != nil {
+			t.Fatalf("GetCreators failed: %v", err)
+		}
+		if lines := slowLines(logger); len(lines) != 0 {
+			t.Errorf("Expected no slow request warning, got %v", lines)
+		}
+	})
+
+	t.Run("Opt-in only", func(t *testing.T) {
+		logger := &recordingLogger{}
+		client := NewClientWithoutAuth(WithBaseURL(server.URL), WithLogger(logger))
+
+		if _, _, err := client.GetCreators(context.Background(), CreatorParams{}); err != nil {
+			t.Fatalf("GetCreators failed: %v", err)
+		}
+		if lines := slowLines(logger); len(lines) != 0 {
+			t.Errorf("Expected no slow request warning, got %v", lines)
+		}
+	})
+}
+
+func TestWithResponseHeaderCallback(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Remaining", "42")
+		w.Header().Set("Content-Type", "application/json")
+		if strings.HasSuffix(r.URL.Path, "/404") {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error": "not found"}`))
+			return
+		}
+		w.Write([]byte(`{"id": 1}`))
+	}))
+	defer server.Close()
+
+	type call struct {
+		endpoint  string
+		remaining string
+	}
+	var calls []call
+	client := NewClientWithoutAuth(
+		WithBaseURL(server.URL+"/api/v1"),
+		WithResponseHeaderCallback(func(endpoint string, h http.Header) {
+			calls = append(calls, call{endpoint, h.Get("X-RateLimit-Remaining")})
+		}),
+	)
+
+	ctx := context.Background()
+	if _, err := client.GetModel(ctx, 1); err != nil {
+		t.Fatalf("GetModel failed: %v", err)
+	}
+	if _, err := client.GetModel(ctx, 404); err == nil {
+		t.Error("Expected error for missing model")
+	}
+
+	expected := []call{{"models/1", "42"}, {"models/404", "42"}}
+	if len(calls) != len(expected) {
+		t.Fatalf("Expected %d callbacks, got %v", len(expected), calls)
+	}
+	for i, c := range expected {
+		if calls[i] != c {
+			t.Errorf("Expected callback %+v, got %+v", c, calls[i])
+		}
+	}
+}
+
+func TestWithResponseMetrics(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.Contains(r.URL.Path, "/images"):
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(`{"error": "boom"}`))
+		case strings.Contains(r.URL.Path, "/tags"):
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte(`{"error": "slow down"}`))
+		case strings.Contains(r.URL.Path, "/models/404"):
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error": "not found"}`))
+		case strings.Contains(r.URL.Path, "/download/"):
+			w.Write([]byte("file contents"))
+		default:
+			w.Write([]byte(`{"id": 1, "items": [], "metadata": {}}`))
+		}
+	}))
+	defer server.Close()
+
+	metrics := &ResponseMetrics{}
+	client := NewClientWithoutAuth(
+		WithBaseURL(server.URL+"/api/v1"),
+		WithRetryConfig(0, time.Millisecond, time.Millisecond),
+		WithEndpointPath(EndpointCreators, "v2/creators"),
+		WithResponseMetrics(metrics),
+	)
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			client.SearchModels(ctx, SearchParams{})
+		}()
+	}
+	wg.Wait()
+
+	client.GetModel(ctx, 404)
+	client.GetModelVersion(ctx, 7)
+	client.GetImages(ctx, ImageParams{})
+	client.GetTags(ctx, TagParams{})
+	client.GetCreators(ctx, CreatorParams{})
+	client.DownloadFile(ctx, File{URL: server.URL + "/api/download/models/1"}, io.Discard)
+
+	expected := map[string]EndpointMetrics{
+		EndpointModels:        {Requests: 6, Errors: 1},
+		EndpointModelVersions: {Requests: 1},
+		EndpointImages:        {Requests: 1, Errors: 1, ServerErrors: 1},
+		EndpointTags:          {Requests: 1, Errors: 1, RateLimitErrors: 1},
+		EndpointCreators:      {Requests: 1},
+		EndpointDownloads:     {Requests: 1},
+	}
+
+	perEndpoint := metrics.PerEndpointMetrics()
+	if len(perEndpoint) != len(expected) {
+		t.Errorf("Expected %d endpoints, got %v", len(expected), perEndpoint)
+	}
+	for endpoint, want := range expected {
+		got, ok := perEndpoint[endpoint]
+		if !ok {
+			t.Errorf("Expected metrics for %s", endpoint)
+			continue
+		}
+		if got.Requests != want.Requests || got.Errors != want.Errors ||
+			got.ServerErrors != want.ServerErrors || got.RateLimitErrors != want.RateLimitErrors {
+			t.Errorf("Expected %s metrics %+v, got %+v", endpoint, want, got)
+		}
+		if got.MaxResponse < got.AverageResponse {
+			t.Errorf("Expected %s max response >= average, got %v < %v", endpoint, got.MaxResponse, got.AverageResponse)
+		}
+	}
+
+	if metrics.TotalRequests != 11 {
+		t.Errorf("Expected 11 total requests, got %d", metrics.TotalRequests)
+	}
+	if metrics.FailedRequests != 3 {
+		t.Errorf("Expected 3 failed requests, got %d", metrics.FailedRequests)
+	}
+
+	perEndpoint[EndpointModels] = EndpointMetrics{}
+	if metrics.PerEndpointMetrics()[EndpointModels].Requests != 6 {
+		t.Error("Expected PerEndpointMetrics to return a copy")
+	}
+}
+
+func TestWithErrorClassifier(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/1"):
+			w.Write([]byte(`{"error": "upstream unavailable"}`))
+		case strings.HasSuffix(r.URL.Path, "/2"):
+			w.WriteHeader(599)
+			w.Write([]byte(`{"id": 2, "name": "Proxied"}`))
+		case strings.HasSuffix(r.URL.Path, "/404"):
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error": "not found"}`))
+		default:
+			w.Write([]byte(`{"id": 3, "name": "Normal"}`))
+		}
+	}))
+	defer server.Close()
+
+	errUpstream := fmt.Errorf("upstream error")
+	var calls int32
+	client := NewClientWithoutAuth(
+		WithBaseURL(server.URL),
+		WithRetryConfig(0, time.Millisecond, time.Millisecond),
+		WithErrorClassifier(func(resp *http.Response) error {
+			atomic.AddInt32(&calls, 1)
+			if resp.StatusCode == 599 {
+				return ErrAcceptResponse
+			}
+			body, _ := io.ReadAll(resp.Body)
+			if strings.Contains(string(body), `"error"`) && resp.StatusCode == http.StatusOK {
+				return errUpstream
+			}
+			return nil
+		}),
+	)
+	ctx := context.Background()
+
+	t.Run("Error in a 200 body", func(t *testing.T) {
+		if _, err := client.GetModel(ctx, 1); err != errUpstream {
+			t.Errorf("Expected classifier error, got %v", err)
+		}
+	})
+
+	t.Run("Accepted nonstandard status", func(t *testing.T) {
+		model, err := client.GetModel(ctx, 2)
+		if err != nil {
+			t.Fatalf("Expected accepted response, got %v", err)
+		}
+		if model.Name != "Proxied" {
+			t.Errorf("Expected Proxied, got %s", model.Name)
+		}
+	})
+
+	t.Run("Nil keeps default handling", func(t *testing.T) {
+		model, err := client.GetModel(ctx, 3)
+		if err != nil {
+			t.Fatalf("GetModel failed: %v", err)
+		}
+		if model.Name != "Normal" {
+			t.Errorf("Expected body to be rewound after classifier read it, got %q", model.Name)
+		}
+		if _, err := client.GetModel(ctx, 404); err == nil || responseStatus(err) != http.StatusNotFound {
+			t.Errorf("Expected default 404 error, got %v", err)
+		}
+	})
+
+	if got := atomic.LoadInt32(&calls); got != 4 {
+		t.Errorf("Expected classifier to be called 4 times, got %d", got)
+	}
+
+	t.Run("Without classifier", func(t *testing.T) {
+		plain := NewClientWithoutAuth(WithBaseURL(server.URL), WithRetryConfig(0, time.Millisecond, time.Millisecond))
+		if _, err := plain.GetModel(ctx, 2); err == nil {
+			t.Error("Expected error for status 599 without a classifier")
+		}
+	})
+}
+
+func TestWithLanguage(t *testing.T) {
+	var got atomic.Value
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got.Store(r.Header.Values("Accept-Language"))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id": 1, "name": "Test"}`))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name     string
+		options  []ClientOption
+		expected []string
+	}{
+		{"Default sends none", nil, nil},
+		{"Configured", []ClientOption{WithLanguage("de-DE")}, []string{"de-DE"}},
+		{"Quality values kept", []ClientOption{WithLanguage("fr;q=0.9, en;q=0.8")}, []string{"fr;q=0.9, en;q=0.8"}},
+		{"Sanitized", []ClientOption{WithLanguage(" en-US\r\nX-Injected: 1 ")}, []string{"en-USX-Injected 1"}},
+		{"Empty after sanitizing", []ClientOption{WithLanguage("\r\n")}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := append([]ClientOption{WithBaseURL(server.URL)}, tt.options...)
+			client := NewClientWithoutAuth(options...)
+			if _, err := client.GetModel(context.Background(), 1); err != nil {
+				t.Fatalf("GetModel failed: %v", err)
+			}
+
+			values, _ := got.Load().([]string)
+			if strings.Join(values, "|") != strings.Join(tt.expected, "|") {
+				t.Errorf("Expected Accept-Language %q, got %q", tt.expected, values)
+			}
+		})
+	}
+}
+
+func TestWithErrorResponseSnapshot(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/models/1":
+			w.Write([]byte(`{"id": 1, "name": "Broken`))
+		case "/models/2":
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`<html>upstream failed; Authorization: Bearer abc.def "apiKey": "sk-123" secret-token</html>`))
+		case "/models/3":
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"code": "BAD_INPUT", "message": "bad model", "padding": "` + strings.Repeat("x", 200) + `"}`))
+		case "/models/4":
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(strings.Repeat("x", 58) + " secret-token " + strings.Repeat("x", 100)))
+		}
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	client := NewClient("secret-token", WithBaseURL(server.URL), WithRetryConfig(0, 0, 0), WithErrorResponseSnapshot(64))
+
+	t.Run("Malformed response", func(t *testing.T) {
+		_, err := client.GetModel(ctx, 1)
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("Expected error wrapping *APIError, got %v", err)
+		}
+		if apiErr.Details != `{"id": 1, "name": "Broken` {
+			t.Errorf("Expected snapshot of malformed body, got %q", apiErr.Details)
+		}
+		if !strings.Contains(err.Error(), "failed to decode response") || !strings.Contains(err.Error(), "Broken") {
+			t.Errorf("Expected decode error with snapshot, got %v", err)
+		}
+	})
+
+	t.Run("Non-JSON error body is redacted", func(t *testing.T) {
+		_, err := client.GetModel(ctx, 2)
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("Expected error wrapping *APIError, got %v", err)
+		}
+		for _, secret := range []string{"abc.def", "sk-123", "secret-token"} {
+			if strings.Contains(apiErr.Details, secret) {
+				t.Errorf("Expected %q to be redacted, got %q", secret, apiErr.Details)
+			}
+		}
+		if !strings.Contains(apiErr.Details, "upstream failed") || responseStatus(err) != http.StatusForbidden {
+			t.Errorf("Expected 403 with snapshot, got %v", err)
+		}
+	})
+
+	t.Run("Truncated JSON error body", func(t *testing.T) {
+		_, err := client.GetModel(ctx, 3)
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("Expected error wrapping *APIError, got %v", err)
+		}
+		if apiErr.Code != "BAD_INPUT" || apiErr.Message != "bad model" {
+			t.Errorf("Expected decoded code and message, got %q and %q", apiErr.Code, apiErr.Message)
+		}
+		if !strings.HasSuffix(apiErr.Details, "...(truncated)") || len(apiErr.Details) != 64+len("...(truncated)") {
+			t.Errorf("Expected 64-byte truncated snapshot, got %q", apiErr.Details)
+		}
+	})
+
+	t.Run("Secret straddling the limit is redacted", func(t *testing.T) {
+		_, err := client.GetModel(ctx, 4)
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("Expected error wrapping *APIError, got %v", err)
+		}
+		if strings.Contains(apiErr.Details, "secre") {
+			t.Errorf("Expected token prefix to be redacted, got %q", apiErr.Details)
+		}
+		if !strings.HasSuffix(apiErr.Details, "...(truncated)") || len(apiErr.Details) != 64+len("...(truncated)") {
+			t.Errorf("Expected 64-byte truncated snapshot, got %q", apiErr.Details)
+		}
+	})
+
+	t.Run("Disabled by default", func(t *testing.T) {
+		plain := NewClientWithoutAuth(WithBaseURL(server.URL), WithRetryConfig(0, 0, 0))
+		_, err := plain.GetModel(ctx, 1)
+		var apiErr *APIError
+		if err == nil || errors.As(err, &apiErr) {
+			t.Errorf("Expected plain decode error, got %v", err)
+		}
+	})
+}
+
+func TestWithUserAgentAutoVersion(t *testing.T) {
+	t.Run("Sends a well-formed user agent", func(t *testing.T) {
+		var userAgent string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			userAgent = r.Header.Get("User-Agent")
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"id": 1}`))
+		}))
+		defer server.Close()
+
+		client := NewClientWithoutAuth(WithBaseURL(server.URL), WithUserAgentAutoVersion())
+		if _, err := client.GetModel(context.Background(), 1); err != nil {
+			t.Fatalf("GetModel failed: %v", err)
+		}
+
+		version, found := strings.CutPrefix(userAgent, "go-civitai-sdk/")
+		if !found || version == "" || strings.ContainsAny(version, " /") {
+			t.Errorf("Expected go-civitai-sdk/<version>, got %q", userAgent)
+		}
+	})
+
+	tests := []struct {
+		name     string
+		info     *debug.BuildInfo
+		expected string
+	}{
+		{"No build info", nil, DefaultUserAgent},
+		{
+			name:     "Dependency version",
+			info:     &debug.BuildInfo{Deps: []*debug.Module{{Path: "example.com/other", Version: "v9.0.0"}, {Path: modulePath, Version: "v1.4.2"}}},
+			expected: "go-civitai-sdk/1.4.2",
+		},
+		{
+			name:     "Replaced dependency",
+			info:     &debug.BuildInfo{Deps: []*debug.Module{{Path: modulePath, Version: "v1.4.2", Replace: &debug.Module{Path: modulePath, Version: "v1.5.0-rc.1"}}}},
+			expected: "go-civitai-sdk/1.5.0-rc.1",
+		},
+		{
+			name:     "Replaced by a local directory",
+			info:     &debug.BuildInfo{Deps: []*debug.Module{{Path: modulePath, Version: "v1.4.2", Replace: &debug.Module{Path: "../go-civitai-sdk"}}}},
+			expected: DefaultUserAgent,
+		},
+		{
+			name:     "Development build of the SDK itself",
+			info:     &debug.BuildInfo{Main: debug.Module{Path: modulePath, Version: "(devel)"}},
+			expected: DefaultUserAgent,
+		},
+		{
+			name:     "SDK missing from build info",
+			info:     &debug.BuildInfo{Main: debug.Module{Path: "example.com/app", Version: "v2.0.0"}},
+			expected: DefaultUserAgent,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if userAgent := userAgentFromBuildInfo(tt.info); userAgent != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, userAgent)
+			}
+		})
+	}
+}
+
+func TestWithErrorClassifierGzip(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Encoding", "gzip")
+		gz := gzip.NewWriter(w)
+		defer gz.Close()
+		if strings.HasSuffix(r.URL.Path, "/1") {
+			gz.Write([]byte(`{"error": "upstream unavailable"}`))
+			return
+		}
+		gz.Write([]byte(`{"id": 2, "name": "Compressed"}`))
+	}))
+	defer server.Close()
+
+	errUpstream := errors.New("upstream error")
+	var sawEncoding string
+	client := NewClientWithoutAuth(
+		WithBaseURL(server.URL),
+		WithErrorClassifier(func(resp *http.Response) error {
+			sawEncoding = resp.Header.Get("Content-Encoding")
+			body, _ := io.ReadAll(resp.Body)
+			if strings.Contains(string(body), `"error"`) {
+				return errUpstream
+			}
+			return nil
+		}),
+	)
+	ctx := context.Background()
+
+	if _, err := client.GetModel(ctx, 1); err != errUpstream {
+		t.Errorf("Expected classifier to see the decompressed error body, got %v", err)
+	}
+	if sawEncoding != "" {
+		t.Errorf("Expected no Content-Encoding on the decoded response, got %q", sawEncoding)
+	}
+
+	model, err := client.GetModel(ctx, 2)
+	if err != nil {
+		t.Fatalf("GetModel failed: %v", err)
+	}
+	if model.Name != "Compressed" {
+		t.Errorf("Expected Compressed, got %q", model.Name)
+	}
+}
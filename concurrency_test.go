@@ -0,0 +1,321 @@
+/*
+Copyright (c) 2025 Regi Ellis
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package civitai
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// concurrencyTestServer serves plausible responses for every endpoint
+func concurrencyTestServer() *httptest.Server {
+	version := `{"id": 10, "modelId": 1, "name": "v1", "baseModel": "SD 1.5",
+		"files": [{"id": 100, "name": "model.safetensors", "primary": true, "sizeKB": 1,
+		"metadata": {"format": "SafeTensor"}, "url": "%s/download/10"}]}`
+	model := `{"id": 1, "name": "Shared Model", "type": "Checkpoint", "tags": ["anime"],
+		"stats": {"downloadCount": 5}, "modelVersions": [` + version + `]}`
+
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := strings.Trim(r.URL.Path, "/")
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("ETag", `"v1"`)
+		switch {
+		case strings.HasPrefix(path, "download/"):
+			w.Header().Set("Content-Type", "application/octet-stream")
+			w.Write([]byte("weights"))
+		case path == "models":
+			fmt.Fprintf(w, `{"items": [`+model+`], "metadata": {"currentPage": 2, "prevCursor": "p1"}}`, server.URL)
+		case strings.HasPrefix(path, "models/") && strings.HasSuffix(path, "/versions"):
+			fmt.Fprintf(w, `[`+version+`]`, server.URL)
+		case strings.HasPrefix(path, "models/"):
+			fmt.Fprintf(w, model, server.URL)
+		case strings.HasPrefix(path, "model-versions/"):
+			fmt.Fprintf(w, version, server.URL)
+		case path == "images":
+			w.Write([]byte(`{"items": [{"id": 7, "url": "https://example.com/7.png", "width": 512, "height": 768,
+				"meta": {"prompt": "a cat"}}], "metadata": {}}`))
+		case path == "creators":
+			w.Write([]byte(`{"items": [{"username": "artist", "modelCount": 1}], "metadata": {}}`))
+		case path == "tags":
+			w.Write([]byte(`{"items": [{"name": "anime", "modelCount": 1}], "metadata": {}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error": "not found"}`))
+		}
+	}))
+	return server
+}
+
+// TestClientConcurrentUse shares one fully configured client between many
+// goroutines calling every method; run with -race to check for data races
+func TestClientConcurrentUse(t *testing.T) {
+	server := concurrencyTestServer()
+	defer server.Close()
+
+	var headerCalls, progressEvents, interceptions int64
+	metrics := &ResponseMetrics{}
+	client := NewClient("test-token",
+		WithBaseURL(server.URL),
+		WithCache(time.Millisecond),
+		WithResponseMetrics(metrics),
+		WithMaxConcurrentRequests(4),
+		WithRetryConfig(1, time.Millisecond, time.Millisecond),
+		WithLogger(log.New(io.Discard, "", 0)),
+		WithSlowRequestThreshold(time.Nanosecond),
+		WithDeadlineWarnings(),
+		WithAutoCursorRecovery(),
+		WithStrictValidation(),
+		WithBaseModelFilterClientSide(),
+		WithResponseHeaderCallback(func(string, http.Header) { atomic.AddInt64(&headerCalls, 1) }),
+		WithProgressReporter(func(ProgressEvent) { atomic.AddInt64(&progressEvents, 1) }),
+		WithRequestInterceptor(func(req *http.Request, attempt int) *http.Request {
+			atomic.AddInt64(&interceptions, 1)
+			return nil
+		}),
+	)
+
+	file := File{Name: "model.safetensors", URL: server.URL + "/download/10", Metadata: FileMetadata{Format: FileFormatSafeTensors}}
+	version := &ModelVersion{ID: 10, ModelID: 1, BaseModel: BaseModelSD1_5, Files: []File{file}}
+	air := NewCivitAIModelAIR(string(AIREcosystemSD1), 1, 10)
+	downloader := client.NewDownloader()
+	dir := t.TempDir()
+	var downloads int64
+
+	operations := map[string]func(ctx context.Context) error{
+		"SearchModels": func(ctx context.Context) error {
+			_, _, err := client.SearchModels(ctx, SearchParams{Query: "anime", BaseModels: []BaseModel{BaseModelSD1_5}})
+			return err
+		},
+		"SearchModelsPrev": func(ctx context.Context) error {
+			_, _, err := client.SearchModelsPrev(ctx, SearchParams{}, &Metadata{PrevCursor: "p1"})
+			return err
+		},
+		"GetModel": func(ctx context.Context) error {
+			_, err := client.GetModel(ctx, 1)
+			return err
+		},
+		"GetModelVersion": func(ctx context.Context) error {
+			_, err := client.GetModelVersion(ctx, 10)
+			return err
+		},
+		"GetModelVersionFile": func(ctx context.Context) error {
+			_, _, err := client.GetModelVersionFile(ctx, 10, FilePreference{Format: FileFormatSafeTensors})
+			return err
+		},
+		"GetModelVersionsByModelID": func(ctx context.Context) error {
+			_, err := client.GetModelVersionsByModelID(ctx, 1)
+			return err
+		},
+		"GetLatestVersion": func(ctx context.Context) error {
+			_, err := client.GetLatestVersion(ctx, 1)
+			return err
+		},
+		"GetModelVersionByHash": func(ctx context.Context) error {
+			_, err := client.GetModelVersionByHash(ctx, "ABCDEF0123456789")
+			return err
+		},
+		"GetModelByAIR": func(ctx context.Context) error {
+			_, err := client.GetModelByAIR(ctx, air)
+			return err
+		},
+		"GetModelVersionByAIR": func(ctx context.Context) error {
+			_, err := client.GetModelVersionByAIR(ctx, air)
+			return err
+		},
+		"QuickSearch": func(ctx context.Context) error {
+			_, err := client.QuickSearch(ctx, "anime", 5)
+			return err
+		},
+		"GetPopularModels": func(ctx context.Context) error {
+			_, err := client.GetPopularModels(ctx, 5)
+			return err
+		},
+		"GetImages": func(ctx context.Context) error {
+			_, _, err := client.GetImages(ctx, ImageParams{Limit: 5})
+			return err
+		},
+		"GetVersionImages": func(ctx context.Context) error {
+			_, err := client.GetVersionImages(ctx, 10, 5)
+			return err
+		},
+		"GetCreators": func(ctx context.Context) error {
+			_, _, err := client.GetCreators(ctx, CreatorParams{Limit: 5})
+			return err
+		},
+		"GetTags": func(ctx context.Context) error {
+			_, _, err := client.GetTags(ctx, TagParams{Limit: 5})
+			return err
+		},
+		"Search": func(ctx context.Context) error {
+			_, err := client.Search(ctx, "anime", nil, 5)
+			return err
+		},
+		"RecommendForUser": func(ctx context.Context) error {
+			_, err := client.RecommendForUser(ctx, []int{1}, 5)
+			return err
+		},
+		"ModelsIterator": func(ctx context.Context) error {
+			it := client.ModelsIterator(ctx, SearchParams{Limit: 5})
+			for it.Next() {
+				it.Model()
+			}
+			return it.Err()
+		},
+		"DownloadFile": func(ctx context.Context) error {
+			_, err := client.DownloadFile(ctx, file, io.Discard)
+			return err
+		},
+		"DownloadBestFile": func(ctx context.Context) error {
+			_, _, err := client.DownloadBestFile(ctx, version, []FileFormat{FileFormatSafeTensors}, io.Discard)
+			return err
+		},
+		"DownloadToPath": func(ctx context.Context) error {
+			n := atomic.AddInt64(&downloads, 1)
+			_, err := client.DownloadToPath(ctx, file, filepath.Join(dir, fmt.Sprintf("model-%d.safetensors", n)))
+			return err
+		},
+		"Downloader": func(ctx context.Context) error {
+			downloader.Enqueue(version, dir)
+			downloader.Len()
+			return nil
+		},
+		"ImagesIterator": func(ctx context.Context) error {
+			it := client.ImagesIterator(ctx, ImageParams{Limit: 5})
+			for it.Next() {
+				it.Image()
+			}
+			return it.Err()
+		},
+		"CreatorsIterator": func(ctx context.Context) error {
+			it := client.CreatorsIterator(ctx, CreatorParams{Limit: 5})
+			for it.Next() {
+				it.Creator()
+			}
+			return it.Err()
+		},
+		"TagsIterator": func(ctx context.Context) error {
+			it := client.TagsIterator(ctx, TagParams{Limit: 5})
+			for it.Next() {
+				it.Tag()
+			}
+			return it.Err()
+		},
+		"GetCreatorsWithModels": func(ctx context.Context) error {
+			_, err := client.GetCreatorsWithModels(ctx, CreatorParams{Limit: 5}, 2)
+			return err
+		},
+		"GetModelsForTopTags": func(ctx context.Context) error {
+			_, err := client.GetModelsForTopTags(ctx, 2, 2)
+			return err
+		},
+		"GetGenerationModels": func(ctx context.Context) error {
+			_, _, err := client.GetGenerationModels(ctx, SearchParams{Limit: 5})
+			return err
+		},
+		"SearchModelsByAIRType": func(ctx context.Context) error {
+			_, _, err := client.SearchModelsByAIRType(ctx, AIRTypeModel, SearchParams{Limit: 5})
+			return err
+		},
+		"FindModelByName": func(ctx context.Context) error {
+			_, err := client.FindModelByName(ctx, "Shared Model")
+			return err
+		},
+		"RefreshVersionAvailability": func(ctx context.Context) error {
+			_, err := client.RefreshVersionAvailability(ctx, 10)
+			return err
+		},
+		"GetSafeImages": func(ctx context.Context) error {
+			_, err := client.GetSafeImages(ctx, 5)
+			return err
+		},
+		"Health": func(ctx context.Context) error {
+			return client.Health(ctx)
+		},
+		"Accessors": func(ctx context.Context) error {
+			client.HasAPIToken()
+			client.GetMaskedAPIToken()
+			client.IsAuthenticated()
+			client.CompatibleBaseModels(version)
+			metrics.PerEndpointMetrics()
+			return nil
+		},
+		"ClearCache": func(ctx context.Context) error {
+			client.ClearCache()
+			return nil
+		},
+	}
+
+	const goroutines = 8
+	const rounds = 3
+	ctx := context.Background()
+
+	var mu sync.Mutex
+	failures := make(map[string]error)
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for r := 0; r < rounds; r++ {
+				for name, op := range operations {
+					if err := op(ctx); err != nil {
+						mu.Lock()
+						failures[name] = err
+						mu.Unlock()
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	for name, err := range failures {
+		t.Errorf("Expected %s to succeed, got %v", name, err)
+	}
+
+	var perEndpointTotal int64
+	for _, stats := range metrics.PerEndpointMetrics() {
+		perEndpointTotal += stats.Requests
+	}
+	snapshot := metrics.Snapshot()
+	if snapshot.TotalRequests != perEndpointTotal {
+		t.Errorf("Expected total requests %d to match per-endpoint sum %d", snapshot.TotalRequests, perEndpointTotal)
+	}
+	if got := atomic.LoadInt64(&interceptions); got != snapshot.TotalRequests {
+		t.Errorf("Expected %d interceptions, got %d", snapshot.TotalRequests, got)
+	}
+	if atomic.LoadInt64(&headerCalls) == 0 || atomic.LoadInt64(&progressEvents) == 0 {
+		t.Error("Expected header callbacks and progress events")
+	}
+}
@@ -25,9 +25,15 @@ package civitai
 
 import (
 	"context"
+	"crypto/tls"
+	"errors"
 	"net/http"
+	"strings"
+	"net/http/cookiejar"
 	"net/http/httptest"
+	"net/url"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -153,7 +159,7 @@ func TestConnectionPooling(t *testing.T) {
 	t.Run("Concurrent requests with pooling", func(t *testing.T) {
 		var requestCount int32
 		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			requestCount++
+			atomic.AddInt32(&requestCount, 1)
 			// Add small delay to simulate real API
 			time.Sleep(10 * time.Millisecond)
 			w.Header().Set("Content-Type", "application/json")
@@ -196,8 +202,8 @@ func TestConnectionPooling(t *testing.T) {
 			t.Errorf("Concurrent request failed: %v", err)
 		}
 
-		if requestCount != numConcurrent {
-			t.Errorf("Expected %d requests, got %d", numConcurrent, requestCount)
+		if got := atomic.LoadInt32(&requestCount); got != numConcurrent {
+			t.Errorf("Expected %d requests, got %d", numConcurrent, got)
 		}
 
 		// With proper connection pooling, concurrent requests should complete faster
@@ -218,9 +224,12 @@ func TestConnectionPooling(t *testing.T) {
 			WithConnectionPooling(15, 3),
 		)
 
-		// Should have the custom client but with modified transport
-		if client.httpClient != customClient {
-			t.Error("Expected custom HTTP client to be preserved")
+		// Should keep the custom client's settings on a private copy
+		if client.httpClient == customClient || customClient.Transport != nil {
+			t.Error("Expected custom HTTP client to be copied rather than modified")
+		}
+		if client.httpClient.Timeout != 5*time.Second {
+			t.Errorf("Expected custom timeout 5s to be preserved, got %v", client.httpClient.Timeout)
 		}
 
 		transport, ok := client.httpClient.Transport.(*http.Transport)
@@ -316,3 +325,561 @@ func TestAdvancedHTTPConfiguration(t *testing.T) {
 		}
 	})
 }
+
+func TestWithMinTLSVersion(t *testing.T) {
+	t.Run("Default transport", func(t *testing.T) {
+		client := NewClientWithoutAuth(WithMinTLSVersion(tls.VersionTLS12))
+
+		transport, ok := client.httpClient.Transport.(*http.Transport)
+		if !ok {
+			t.Fatal("Expected HTTP transport to be *http.Transport")
+		}
+		if transport.TLSClientConfig == nil || transport.TLSClientConfig.MinVersion != tls.VersionTLS12 {
+			t.Errorf("Expected MinVersion TLS 1.2, got %+v", transport.TLSClientConfig)
+		}
+		if http.DefaultTransport.(*http.Transport).TLSClientConfig != nil &&
+			http.DefaultTransport.(*http.Transport).TLSClientConfig.MinVersion == tls.VersionTLS12 {
+			t.Error("Expected http.DefaultTransport to be left unmodified")
+		}
+	})
+
+	t.Run("Preserves pooling in either order", func(t *testing.T) {
+		for _, options := range [][]ClientOption{
+			{WithMinTLSVersion(tls.VersionTLS13), WithConnectionPooling(20, 5)},
+			{WithConnectionPooling(20, 5), WithMinTLSVersion(tls.VersionTLS13)},
+		} {
+			client := NewClientWithoutAuth(options...)
+
+			transport, ok := client.httpClient.Transport.(*http.Transport)
+			if !ok {
+				t.Fatal("Expected HTTP transport to be *http.Transport")
+			}
+			if transport.MaxIdleConns != 20 || transport.MaxIdleConnsPerHost != 5 {
+				t.Errorf("Expected pooling 20/5, got %d/%d", transport.MaxIdleConns, transport.MaxIdleConnsPerHost)
+			}
+			if transport.TLSClientConfig == nil || transport.TLSClientConfig.MinVersion != tls.VersionTLS13 {
+				t.Errorf("Expected MinVersion TLS 1.3, got %+v", transport.TLSClientConfig)
+			}
+		}
+	})
+
+	t.Run("Does not mutate custom HTTP client", func(t *testing.T) {
+		original := &http.Transport{MaxIdleConns: 7}
+		httpClient := &http.Client{Transport: original}
+		client := NewClientWithoutAuth(WithHTTPClient(httpClient), WithMinTLSVersion(tls.VersionTLS12))
+
+		if httpClient.Transport != original || (original.TLSClientConfig != nil && original.TLSClientConfig.MinVersion != 0) {
+			t.Error("Expected caller's HTTP client and transport to be unmodified")
+		}
+		transport := client.httpClient.Transport.(*http.Transport)
+		if transport.MaxIdleConns != 7 || transport.TLSClientConfig.MinVersion != tls.VersionTLS12 {
+			t.Errorf("Expected cloned transport with MaxIdleConns 7 and TLS 1.2, got %d/%+v", transport.MaxIdleConns, transport.TLSClientConfig)
+		}
+	})
+}
+
+func TestMaxConcurrentRequests(t *testing.T) {
+	unblock := make(chan struct{})
+	started := make(chan struct{}, 4)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		started <- struct{}{}
+		<-unblock
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id": 1, "name": "Test Model"}`))
+	}))
+	defer server.Close()
+
+	client := NewClientWithoutAuth(
+		WithBaseURL(server.URL),
+		WithMaxConcurrentRequests(2),
+		WithSemaphoreAcquireTimeout(50*time.Millisecond),
+	)
+	ctx := context.Background()
+
+	// Saturate both slots
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.GetModel(ctx, 1); err != nil {
+				t.Errorf("Expected saturating request to succeed, got %v", err)
+			}
+		}()
+	}
+	<-started
+	<-started
+
+	t.Run("Times out with ErrTooBusy", func(t *testing.T) {
+		start := time.Now()
+		_, err := client.GetModel(ctx, 1)
+		if !errors.Is(err, ErrTooBusy) {
+			t.Fatalf("Expected ErrTooBusy, got %v", err)
+		}
+		if elapsed := time.Since(start); elapsed > time.Second {
+			t.Errorf("Expected to fail near the acquire timeout, took %v", elapsed)
+		}
+	})
+
+	t.Run("Interceptors see the acquisition failure", func(t *testing.T) {
+		var requests, responses int
+		var responseErr error
+		intercepted := NewClientWithoutAuth(
+			WithBaseURL(server.URL),
+			WithMaxConcurrentRequests(2),
+			WithSemaphoreAcquireTimeout(20*time.Millisecond),
+			WithRequestInterceptor(func(req *http.Request, attempt int) *http.Request {
+				requests++
+				return nil
+			}),
+			WithResponseInterceptor(func(req *http.Request, resp *http.Response, err error, attempt int) {
+				responses++
+				responseErr = err
+			}),
+		)
+		intercepted.requestSlots = client.requestSlots
+
+		if _, err := intercepted.GetModel(ctx, 1); !errors.Is(err, ErrTooBusy) {
+			t.Fatalf("Expected ErrTooBusy, got %v", err)
+		}
+		if requests != 1 || responses != 1 {
+			t.Errorf("Expected 1 request and 1 response interception, got %d and %d", requests, responses)
+		}
+		if !errors.Is(responseErr, ErrTooBusy) {
+			t.Errorf("Expected response interceptor to get ErrTooBusy, got %v", responseErr)
+		}
+	})
+
+	t.Run("Context cancellation while waiting", func(t *testing.T) {
+		waitingClient := NewClientWithoutAuth(WithBaseURL(server.URL), WithMaxConcurrentRequests(2))
+		waitingClient.requestSlots = client.requestSlots
+
+		cancelCtx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+		defer cancel()
+		_, err := waitingClient.GetModel(cancelCtx, 1)
+		if !errors.Is(err, context.DeadlineExceeded) || errors.Is(err, ErrTooBusy) {
+			t.Errorf("Expected context deadline error, got %v", err)
+		}
+	})
+
+	close(unblock)
+	wg.Wait()
+
+	t.Run("Slots released after completion", func(t *testing.T) {
+		if _, err := client.GetModel(ctx, 1); err != nil {
+			t.Errorf("Expected request to succeed once slots are free, got %v", err)
+		}
+		if len(client.requestSlots) != 0 {
+			t.Errorf("Expected all slots released, %d still held", len(client.requestSlots))
+		}
+	})
+}
+
+func TestWithPreferIPv4(t *testing.T) {
+	t.Run("Installs dialer and preserves pooling", func(t *testing.T) {
+		client := NewClientWithoutAuth(WithConnectionPooling(20, 5), WithPreferIPv4())
+
+		transport, ok := client.httpClient.Transport.(*http.Transport)
+		if !ok {
+			t.Fatal("Expected HTTP transport to be *http.Transport")
+		}
+		if transport.DialContext == nil {
+			t.Error("Expected a custom DialContext to be set")
+		}
+		if transport.MaxIdleConns != 20 || transport.MaxIdleConnsPerHost != 5 {
+			t.Errorf("Expected pooling 20/5, got %d/%d", transport.MaxIdleConns, transport.MaxIdleConnsPerHost)
+		}
+	})
+
+	t.Run("Default client keeps OS dialing", func(t *testing.T) {
+		client := NewClientWithoutAuth()
+		if client.httpClient.Transport != nil {
+			t.Errorf("Expected default transport, got %T", client.httpClient.Transport)
+		}
+	})
+
+	t.Run("Connects over IPv4", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"id": 1, "name": "Test Model"}`))
+		}))
+		defer server.Close()
+
+		client := NewClientWithoutAuth(WithBaseURL(server.URL), WithPreferIPv4())
+		model, err := client.GetModel(context.Background(), 1)
+		if err != nil {
+			t.Fatalf("GetModel failed: %v", err)
+		}
+		if model.ID != 1 {
+			t.Errorf("Expected model ID 1, got %d", model.ID)
+		}
+	})
+}
+
+func TestWithDialTimeoutAndKeepAlive(t *testing.T) {
+	t.Run("Configures dialer and preserves pooling", func(t *testing.T) {
+		client := NewClientWithoutAuth(WithDialTimeout(5*time.Second), WithConnectionPooling(20, 5), WithKeepAlive(-1))
+
+		dialer := client.transport.dialer()
+		if dialer.Timeout != 5*time.Second {
+			t.Errorf("Expected dial timeout 5s, got %v", dialer.Timeout)
+		}
+		if dialer.KeepAlive != -1 {
+			t.Errorf("Expected keep-alive disabled, got %v", dialer.KeepAlive)
+		}
+
+		transport, ok := client.httpClient.Transport.(*http.Transport)
+		if !ok {
+			t.Fatal("Expected HTTP transport to be *http.Transport")
+		}
+		if transport.DialContext == nil {
+			t.Error("Expected a custom DialContext to be set")
+		}
+		if transport.MaxIdleConns != 20 || transport.MaxIdleConnsPerHost != 5 {
+			t.Errorf("Expected pooling 20/5, got %d/%d", transport.MaxIdleConns, transport.MaxIdleConnsPerHost)
+		}
+	})
+
+	t.Run("Unset values keep defaults", func(t *testing.T) {
+		dialer := NewClientWithoutAuth(WithKeepAlive(time.Minute)).transport.dialer()
+		if dialer.Timeout != 30*time.Second || dialer.KeepAlive != time.Minute {
+			t.Errorf("Expected 30s timeout and 1m keep-alive, got %v and %v", dialer.Timeout, dialer.KeepAlive)
+		}
+
+		client := NewClientWithoutAuth(WithDialTimeout(0))
+		if client.httpClient.Transport != nil {
+			t.Errorf("Expected default transport, got %T", client.httpClient.Transport)
+		}
+	})
+
+	t.Run("Connects with custom dialer", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"id": 1, "name": "Test Model"}`))
+		}))
+		defer server.Close()
+
+		client := NewClientWithoutAuth(WithBaseURL(server.URL), WithDialTimeout(time.Second), WithPreferIPv4())
+		if _, err := client.GetModel(context.Background(), 1); err != nil {
+			t.Errorf("GetModel failed: %v", err)
+		}
+	})
+}
+
+func TestSharedHTTPClientIsolation(t *testing.T) {
+	original := &http.Transport{MaxIdleConns: 7}
+	shared := &http.Client{Transport: original, Timeout: 5 * time.Second}
+
+	configured := NewClientWithoutAuth(
+		WithHTTPClient(shared),
+		WithTimeout(time.Second),
+		WithConnectionPooling(20, 5),
+		WithMinTLSVersion(tls.VersionTLS12),
+	)
+	plain := NewClientWithoutAuth(WithHTTPClient(shared))
+
+	if shared.Timeout != 5*time.Second || shared.Transport != original {
+		t.Errorf("Expected shared client to be unmodified, got timeout %v and transport %p", shared.Timeout, shared.Transport)
+	}
+	if original.MaxIdleConns != 7 || (original.TLSClientConfig != nil && original.TLSClientConfig.MinVersion != 0) {
+		t.Error("Expected shared transport to be unmodified")
+	}
+	if plain.httpClient != shared {
+		t.Error("Expected unconfigured client to use the shared HTTP client as-is")
+	}
+
+	if configured.httpClient == shared {
+		t.Fatal("Expected configured client to use a private HTTP client")
+	}
+	if configured.httpClient.Timeout != time.Second {
+		t.Errorf("Expected timeout 1s, got %v", configured.httpClient.Timeout)
+	}
+	transport := configured.httpClient.Transport.(*http.Transport)
+	if transport.MaxIdleConns != 20 || transport.TLSClientConfig.MinVersion != tls.VersionTLS12 {
+		t.Errorf("Expected private transport with pooling and TLS 1.2, got %d/%+v", transport.MaxIdleConns, transport.TLSClientConfig)
+	}
+}
+
+func TestWithMaxResponseHeaderBytes(t *testing.T) {
+	t.Run("Sets transport limit and preserves pooling", func(t *testing.T) {
+		for _, options := range [][]ClientOption{
+			{WithMaxResponseHeaderBytes(64 << 10), WithConnectionPooling(20, 5)},
+			{WithConnectionPooling(20, 5), WithMaxResponseHeaderBytes(64 << 10)},
+		} {
+			client := NewClientWithoutAuth(options...)
+
+			transport, ok := client.httpClient.Transport.(*http.Transport)
+			if !ok {
+				t.Fatal("Expected HTTP transport to be *http.Transport")
+			}
+			if transport.MaxResponseHeaderBytes != 64<<10 {
+				t.Errorf("Expected MaxResponseHeaderBytes %d, got %d", 64<<10, transport.MaxResponseHeaderBytes)
+			}
+			if transport.MaxIdleConns != 20 || transport.MaxIdleConnsPerHost != 5 {
+				t.Errorf("Expected pooling 20/5, got %d/%d", transport.MaxIdleConns, transport.MaxIdleConnsPerHost)
+			}
+		}
+	})
+
+	t.Run("Default keeps Go's limit", func(t *testing.T) {
+		client := NewClientWithoutAuth()
+		if client.httpClient.Transport != nil {
+			t.Errorf("Expected default transport, got %T", client.httpClient.Transport)
+		}
+	})
+
+	t.Run("Rejects oversized headers", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Padding", strings.Repeat("x", 8<<10))
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"id": 1}`))
+		}))
+		defer server.Close()
+
+		client := NewClientWithoutAuth(WithBaseURL(server.URL), WithMaxResponseHeaderBytes(1<<10), WithRetryConfig(0, 0, 0))
+		if _, err := client.GetModel(context.Background(), 1); err == nil {
+			t.Error("Expected error for oversized response headers")
+		}
+	})
+}
+
+func TestWithMaxConnsPerHost(t *testing.T) {
+	t.Run("Sets transport limit and preserves pooling", func(t *testing.T) {
+		for _, options := range [][]ClientOption{
+			{WithMaxConnsPerHost(8), WithConnectionPooling(20, 5)},
+			{WithConnectionPooling(20, 5), WithMaxConnsPerHost(8)},
+		} {
+			client := NewClientWithoutAuth(options...)
+
+			transport, ok := client.httpClient.Transport.(*http.Transport)
+			if !ok {
+				t.Fatal("Expected HTTP transport to be *http.Transport")
+			}
+			if transport.MaxConnsPerHost != 8 {
+				t.Errorf("Expected MaxConnsPerHost 8, got %d", transport.MaxConnsPerHost)
+			}
+			if transport.MaxIdleConns != 20 || transport.MaxIdleConnsPerHost != 5 {
+				t.Errorf("Expected pooling 20/5, got %d/%d", transport.MaxIdleConns, transport.MaxIdleConnsPerHost)
+			}
+		}
+	})
+
+	t.Run("Default and non-positive keep Go's unlimited default", func(t *testing.T) {
+		for _, client := range []*Client{NewClientWithoutAuth(), NewClientWithoutAuth(WithMaxConnsPerHost(-1))} {
+			if client.httpClient.Transport != nil {
+				t.Errorf("Expected default transport, got %T", client.httpClient.Transport)
+			}
+		}
+	})
+}
+
+func TestWithCompression(t *testing.T) {
+	t.Run("Disabling sets transport flag", func(t *testing.T) {
+		for _, options := range [][]ClientOption{
+			{WithCompression(false), WithConnectionPooling(20, 5)},
+			{WithConnectionPooling(20, 5), WithCompression(false)},
+		} {
+			client := NewClientWithoutAuth(options...)
+
+			transport, ok := client.httpClient.Transport.(*http.Transport)
+			if !ok {
+				t.Fatal("Expected HTTP transport to be *http.Transport")
+			}
+			if !transport.DisableCompression {
+				t.Error("Expected DisableCompression to be true")
+			}
+			if transport.MaxIdleConns != 20 || transport.MaxIdleConnsPerHost != 5 {
+				t.Errorf("Expected pooling 20/5, got %d/%d", transport.MaxIdleConns, transport.MaxIdleConnsPerHost)
+			}
+		}
+	})
+
+	t.Run("Enabled by default", func(t *testing.T) {
+		for _, client := range []*Client{
+			NewClientWithoutAuth(WithConnectionPooling(20, 5)),
+			NewClientWithoutAuth(WithConnectionPooling(20, 5), WithCompression(true)),
+		} {
+			transport, ok := client.httpClient.Transport.(*http.Transport)
+			if !ok {
+				t.Fatal("Expected HTTP transport to be *http.Transport")
+			}
+			if transport.DisableCompression {
+				t.Error("Expected DisableCompression to be false")
+			}
+		}
+	})
+
+	t.Run("Disabled client does not request gzip", func(t *testing.T) {
+		var acceptEncoding string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			acceptEncoding = r.Header.Get("Accept-Encoding")
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"id": 1}`))
+		}))
+		defer server.Close()
+
+		client := NewClientWithoutAuth(WithBaseURL(server.URL), WithCompression(false))
+		if _, err := client.GetModel(context.Background(), 1); err != nil {
+			t.Fatalf("GetModel failed: %v", err)
+		}
+		if strings.Contains(acceptEncoding, "gzip") {
+			t.Errorf("Expected no gzip in Accept-Encoding, got %q", acceptEncoding)
+		}
+	})
+}
+
+func TestWithCookieJar(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		cookie, err := r.Cookie("session")
+		if err != nil {
+			w.Header().Set("X-Session", "missing")
+		} else {
+			w.Header().Set("X-Session", cookie.Value)
+		}
+		w.Write([]byte(`{"id": 1}`))
+	}))
+	defer server.Close()
+
+	newJar := func(t *testing.T) http.CookieJar {
+		jar, err := cookiejar.New(nil)
+		if err != nil {
+			t.Fatalf("Failed to create cookie jar: %v", err)
+		}
+		serverURL, _ := url.Parse(server.URL)
+		jar.SetCookies(serverURL, []*http.Cookie{{Name: "session", Value: "abc123"}})
+		return jar
+	}
+
+	var lastSession string
+	recordSession := WithResponseInterceptor(func(req *http.Request, resp *http.Response, err error, attempt int) {
+		if resp != nil {
+			lastSession = resp.Header.Get("X-Session")
+		}
+	})
+
+	t.Run("Sends jar cookies", func(t *testing.T) {
+		client := NewClientWithoutAuth(WithBaseURL(server.URL), WithCookieJar(newJar(t)), recordSession)
+		if _, err := client.GetModel(context.Background(), 1); err != nil {
+			t.Fatalf("GetModel failed: %v", err)
+		}
+		if lastSession != "abc123" {
+			t.Errorf("Expected session cookie abc123, got %q", lastSession)
+		}
+	})
+
+	t.Run("Composes with custom HTTP client", func(t *testing.T) {
+		shared := &http.Client{Timeout: 5 * time.Second}
+		client := NewClientWithoutAuth(WithBaseURL(server.URL), WithCookieJar(newJar(t)), WithHTTPClient(shared), recordSession)
+		if _, err := client.GetModel(context.Background(), 1); err != nil {
+			t.Fatalf("GetModel failed: %v", err)
+		}
+		if lastSession != "abc123" {
+			t.Errorf("Expected session cookie abc123, got %q", lastSession)
+		}
+		if shared.Jar != nil {
+			t.Error("Expected shared HTTP client to be left without a jar")
+		}
+		if client.httpClient.Timeout != 5*time.Second {
+			t.Errorf("Expected custom timeout 5s to be preserved, got %v", client.httpClient.Timeout)
+		}
+	})
+}
+
+func TestTransportOptionsCompose(t *testing.T) {
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		t.Fatalf("cookiejar.New failed: %v", err)
+	}
+	proxy := func(*http.Request) (*url.URL, error) { return nil, nil }
+	original := &http.Transport{Proxy: proxy, MaxIdleConns: 7}
+	shared := &http.Client{Transport: original, Timeout: 5 * time.Second}
+
+	options := []ClientOption{
+		WithHTTPClient(shared),
+		WithTimeout(time.Second),
+		WithConnectionPooling(20, 5),
+		WithMinTLSVersion(tls.VersionTLS13),
+		WithDialTimeout(3 * time.Second),
+		WithPreferIPv4(),
+		WithMaxResponseHeaderBytes(64 << 10),
+		WithMaxConnsPerHost(8),
+		WithCompression(false),
+		WithCookieJar(jar),
+	}
+	reversed := make([]ClientOption, len(options))
+	for i, option := range options {
+		reversed[len(options)-1-i] = option
+	}
+
+	for name, opts := range map[string][]ClientOption{"forward": options, "reversed": reversed} {
+		t.Run(name, func(t *testing.T) {
+			client := NewClientWithoutAuth(opts...)
+
+			if client.httpClient == shared {
+				t.Fatal("Expected the shared HTTP client to be copied")
+			}
+			if client.httpClient.Timeout != time.Second {
+				t.Errorf("Expected timeout 1s, got %v", client.httpClient.Timeout)
+			}
+			if client.httpClient.Jar != jar {
+				t.Error("Expected cookie jar to be set")
+			}
+
+			transport, ok := client.httpClient.Transport.(*http.Transport)
+			if !ok {
+				t.Fatal("Expected HTTP transport to be *http.Transport")
+			}
+			if transport == original {
+				t.Error("Expected the shared transport to be cloned")
+			}
+			if transport.Proxy == nil {
+				t.Error("Expected the custom transport's proxy to be preserved")
+			}
+			if transport.MaxIdleConns != 20 || transport.MaxIdleConnsPerHost != 5 {
+				t.Errorf("Expected pooling 20/5, got %d/%d", transport.MaxIdleConns, transport.MaxIdleConnsPerHost)
+			}
+			if transport.TLSClientConfig == nil || transport.TLSClientConfig.MinVersion != tls.VersionTLS13 {
+				t.Errorf("Expected MinVersion TLS 1.3, got %+v", transport.TLSClientConfig)
+			}
+			if transport.DialContext == nil {
+				t.Error("Expected a custom DialContext to be set")
+			}
+			if transport.MaxResponseHeaderBytes != 64<<10 {
+				t.Errorf("Expected MaxResponseHeaderBytes %d, got %d", 64<<10, transport.MaxResponseHeaderBytes)
+			}
+			if transport.MaxConnsPerHost != 8 {
+				t.Errorf("Expected MaxConnsPerHost 8, got %d", transport.MaxConnsPerHost)
+			}
+			if !transport.DisableCompression {
+				t.Error("Expected compression to be disabled")
+			}
+		})
+	}
+
+	if shared.Timeout != 5*time.Second || shared.Transport != original || shared.Jar != nil {
+		t.Error("Expected shared client to be unmodified")
+	}
+	if original.MaxIdleConns != 7 || original.DialContext != nil || (original.TLSClientConfig != nil && original.TLSClientConfig.MinVersion != 0) {
+		t.Error("Expected shared transport to be unmodified")
+	}
+
+	t.Run("Timeout survives a later WithHTTPClient", func(t *testing.T) {
+		client := NewClientWithoutAuth(WithTimeout(time.Second), WithHTTPClient(&http.Client{Timeout: time.Minute}))
+		if client.httpClient.Timeout != time.Second {
+			t.Errorf("Expected timeout 1s, got %v", client.httpClient.Timeout)
+		}
+	})
+
+	t.Run("Pooling survives a later WithHTTPClient", func(t *testing.T) {
+		client := NewClientWithoutAuth(WithConnectionPooling(20, 5), WithHTTPClient(&http.Client{Transport: &http.Transport{Proxy: proxy}}))
+
+		transport, ok := client.httpClient.Transport.(*http.Transport)
+		if !ok {
+			t.Fatal("Expected HTTP transport to be *http.Transport")
+		}
+		if transport.MaxIdleConns != 20 || transport.Proxy == nil {
+			t.Errorf("Expected pooling and proxy to coexist, got MaxIdleConns %d and proxy set %v", transport.MaxIdleConns, transport.Proxy != nil)
+		}
+	})
+}
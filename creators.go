@@ -44,6 +44,16 @@ SOFTWARE.
 //	}
 //	creators, _, err := client.GetCreators(ctx, params)
 //
+// # Fetching One Creator
+//
+// Look up a single profile by username. The creators endpoint only supports
+// partial-match queries, so GetCreator picks the exact match from the results:
+//
+//	creator, err := client.GetCreator(ctx, "artist-name")
+//	if errors.Is(err, civitai.ErrNotFound) {
+//		fmt.Println("No such creator")
+//	}
+//
 // # Creator Information
 //
 // Each creator object contains comprehensive statistics:
@@ -73,6 +83,19 @@ SOFTWARE.
 //		}
 //	}
 //
+// # Creators with Their Models
+//
+// Fetch creators and a sample of each one's most downloaded models in one call:
+//
+//	byCreator, err := client.GetCreatorsWithModels(ctx, civitai.CreatorParams{Limit: 10}, 3)
+//	if err != nil {
+//		// Creators whose model search failed are missing from byCreator
+//		log.Printf("partial results: %v", err)
+//	}
+//	for username, models := range byCreator {
+//		fmt.Printf("%s: %d models\n", username, len(models))
+//	}
+//
 // # Best Practices
 //
 // 1. Implement retry logic with exponential backoff
@@ -92,11 +115,21 @@ package civitai
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 )
 
-// GetCreators retrieves a list of creators from the CivitAI API
+// creatorModelsConcurrency bounds the per-creator model searches in GetCreatorsWithModels
+const creatorModelsConcurrency = 4
+
+// GetCreators retrieves a list of creators from the CivitAI API. The creators
+// endpoint is slow and flaky, so by default each attempt gets twice the client
+// timeout and two more retries than other calls; see
+// WithRequestTimeoutPerEndpoint and WithMaxRetriesPerEndpoint.
 // GET /api/v1/creators
 func (c *Client) GetCreators(ctx context.Context, params CreatorParams) ([]Creator, *Metadata, error) {
 	if err := c.validateCreatorParams(params); err != nil {
@@ -104,7 +137,7 @@ func (c *Client) GetCreators(ctx context.Context, params CreatorParams) ([]Creat
 	}
 
 	queryParams := c.buildCreatorParams(params)
-	url := c.addQueryParams(c.buildURL("creators"), queryParams)
+	url := c.addQueryParams(c.endpointURL(EndpointCreators), queryParams)
 
 	resp, err := c.doRequest(ctx, "GET", url, nil)
 	if err != nil {
@@ -123,19 +156,148 @@ func (c *Client) GetCreators(ctx context.Context, params CreatorParams) ([]Creat
 	return apiResp.Items, apiResp.Metadata, nil
 }
 
+// GetCreator returns the creator with the given username. It queries the
+// creators endpoint, which matches partial usernames, following pages until
+// a result's username matches exactly. Without an exact match it returns the
+// first result matching regardless of case. The error matches ErrNotFound
+// when no result matches.
+func (c *Client) GetCreator(ctx context.Context, username string) (*Creator, error) {
+	username = strings.TrimSpace(username)
+	if username == "" {
+		return nil, errors.New("username cannot be empty")
+	}
+
+	params := CreatorParams{Query: username, Limit: MaxLimit}
+	var match *Creator
+	for {
+		creators, meta, err := c.GetCreators(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range creators {
+			if creators[i].Username == username {
+				return &creators[i], nil
+			}
+			if match == nil && strings.EqualFold(creators[i].Username, username) {
+				match = &creators[i]
+			}
+		}
+
+		if len(creators) == 0 || meta == nil {
+			break
+		}
+		if meta.NextCursor != "" && meta.NextCursor != params.Cursor {
+			params.Cursor, params.Page = meta.NextCursor, 0
+			continue
+		}
+		if params.Cursor == "" && (meta.NextPage != "" || meta.CurrentPage < meta.TotalPages) {
+			params.Page = max(meta.CurrentPage, params.Page, 1) + 1
+			continue
+		}
+		break
+	}
+
+	if match == nil {
+		return nil, fmt.Errorf("%w: creator %q", ErrNotFound, username)
+	}
+	return match, nil
+}
+
 // buildCreatorParams converts CreatorParams to query parameters
 func (c *Client) buildCreatorParams(params CreatorParams) map[string]string {
 	queryParams := make(map[string]string)
 
-	if params.Limit > 0 {
-		queryParams["limit"] = strconv.Itoa(params.Limit)
+	if limit := c.effectiveLimit(params.Limit); limit > 0 {
+		queryParams["limit"] = strconv.Itoa(limit)
 	}
-	if params.Page > 0 {
+	if params.Page > 0 && params.Cursor == "" {
 		queryParams["page"] = strconv.Itoa(params.Page)
 	}
 	if params.Query != "" {
 		queryParams["query"] = params.Query
 	}
+	if params.Cursor != "" {
+		queryParams["cursor"] = params.Cursor
+	}
 
 	return queryParams
 }
+
+// GetCreatorsWithModels lists creators matching params and concurrently fetches
+// up to modelsPerCreator of each creator's most downloaded models, keyed by
+// username. At most 4 model searches run at once. All requests use the client's
+// retry configuration, which helps with the flaky creators endpoint. Creators
+// whose model search fails are omitted from the result and reported in the
+// returned error alongside the models that were fetched successfully.
+func (c *Client) GetCreatorsWithModels(ctx context.Context, params CreatorParams, modelsPerCreator int) (map[string][]Model, error) {
+	if modelsPerCreator <= 0 {
+		return nil, fmt.Errorf("models per creator must be positive, got %d", modelsPerCreator)
+	}
+
+	creators, _, err := c.GetCreators(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch creators: %w", err)
+	}
+
+	perCreator := make([][]Model, len(creators))
+	errs := make([]error, len(creators))
+	sem := make(chan struct{}, creatorModelsConcurrency)
+	var (
+		wg        sync.WaitGroup
+		completed int32
+		total     int
+	)
+	for _, creator := range creators {
+		if creator.Username != "" {
+			total++
+		}
+	}
+
+	for i, creator := range creators {
+		if creator.Username == "" {
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int, username string) {
+			defer wg.Done()
+			if c.progressReporter != nil {
+				defer func() {
+					c.reportProgress("GetCreatorsWithModels", int(atomic.AddInt32(&completed, 1)), total)
+				}()
+			}
+
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-ctx.Done():
+				errs[i] = fmt.Errorf("creator %q: %w", username, ctx.Err())
+				return
+			}
+
+			models, _, err := c.searchModels(ctx, SearchParams{Username: username, Limit: modelsPerCreator, Sort: SortMostDownload})
+			if err != nil {
+				errs[i] = fmt.Errorf("creator %q: %w", username, err)
+				return
+			}
+			perCreator[i] = models
+		}(i, creator.Username)
+	}
+
+	wg.Wait()
+
+	result := make(map[string][]Model, len(creators))
+	for i, creator := range creators {
+		if creator.Username == "" || errs[i] != nil {
+			continue
+		}
+		result[creator.Username] = perCreator[i]
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return result, fmt.Errorf("failed to fetch models for some creators: %w", err)
+	}
+
+	return result, nil
+}
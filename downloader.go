@@ -0,0 +1,402 @@
+/*
+Copyright (c) 2025 Regi Ellis
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+// Package civitai - Batch Download Manager
+//
+// This file provides a Downloader that mirrors many model versions with
+// bounded concurrency, per-file retries, resumable partial files, and
+// progress reporting.
+//
+// # Queueing Downloads
+//
+//	downloader := client.NewDownloader(
+//		civitai.WithDownloaderConcurrency(3),
+//		civitai.WithDownloaderRetries(5),
+//	)
+//	for i := range versions {
+//		downloader.Enqueue(&versions[i], "/models/checkpoints")
+//	}
+//
+//	results, err := downloader.Run(ctx)
+//	for _, result := range results {
+//		if result.Err != nil {
+//			fmt.Printf("%d failed: %v\n", result.Version.ID, result.Err)
+//		}
+//	}
+//
+// # Progress
+//
+//	downloader := client.NewDownloader(civitai.WithDownloadProgress(func(p civitai.DownloadProgress) {
+//		fmt.Printf("\r%s: %d/%d bytes", p.File.Name, p.Downloaded, p.Total)
+//	}))
+//
+// # Resuming
+//
+// Data is written to "<path>.part" and renamed into place once the size and
+// SHA256 hash check out. An interrupted download, whether from a failed attempt
+// or an earlier run, resumes from the partial file using a byte range request.
+// A partial file larger than the reported size, or one the server reports as
+// complete without a size or hash to confirm it, is discarded and downloaded
+// again.
+
+package civitai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultDownloaderConcurrency is the default number of simultaneous downloads
+	DefaultDownloaderConcurrency = 2
+
+	// DefaultDownloaderRetries is the default number of retries per file
+	DefaultDownloaderRetries = 3
+
+	// partialDownloadSuffix is appended to destination paths while downloading
+	partialDownloadSuffix = ".part"
+)
+
+// Downloader downloads queued model versions to disk
+type Downloader struct {
+	client      *Client
+	concurrency int
+	retries     int
+	preference  FilePreference
+	progress    func(DownloadProgress)
+
+	mu    sync.Mutex
+	queue []downloadJob
+}
+
+// DownloaderOption configures a Downloader
+type DownloaderOption func(*Downloader)
+
+// DownloadProgress reports the state of a single file download
+type DownloadProgress struct {
+	Version    *ModelVersion
+	File       *File
+	Path       string
+	Downloaded int64 // Bytes on disk, including any resumed portion
+	Total      int64 // Expected size in bytes, or -1 when unknown
+}
+
+// DownloadResult reports the outcome of a single queued download
+type DownloadResult struct {
+	Version  *ModelVersion
+	File     *File  // Selected file; nil when no file matched
+	Path     string // Final path of the downloaded file
+	Bytes    int64  // Size of the completed file
+	Attempts int
+	Err      error
+}
+
+type downloadJob struct {
+	version *ModelVersion
+	dest    string
+}
+
+// WithDownloaderConcurrency sets how many files are downloaded at once
+func WithDownloaderConcurrency(n int) DownloaderOption {
+	return func(d *Downloader) {
+		if n > 0 {
+			d.concurrency = n
+		}
+	}
+}
+
+// WithDownloaderRetries sets how many times a failed file is retried
+func WithDownloaderRetries(n int) DownloaderOption {
+	return func(d *Downloader) {
+		if n >= 0 {
+			d.retries = n
+		}
+	}
+}
+
+// WithDownloaderFilePreference selects which file of each version to download.
+// By default the primary file is downloaded.
+func WithDownloaderFilePreference(pref FilePreference) DownloaderOption {
+	return func(d *Downloader) {
+		d.preference = pref
+	}
+}
+
+// WithDownloadProgress registers a callback invoked as data is written. It is
+// called from download goroutines and must be safe for concurrent use.
+func WithDownloadProgress(callback func(DownloadProgress)) DownloaderOption {
+	return func(d *Downloader) {
+		d.progress = callback
+	}
+}
+
+// NewDownloader creates a Downloader that uses the client for requests
+func (c *Client) NewDownloader(options ...DownloaderOption) *Downloader {
+	d := &Downloader{
+		client:      c,
+		concurrency: DefaultDownloaderConcurrency,
+		retries:     DefaultDownloaderRetries,
+	}
+
+	for _, option := range options {
+		option(d)
+	}
+
+	return d
+}
+
+// Enqueue adds a version to the queue. If dest ends in a path separator or is
+// an existing directory the file is saved there under its own name; otherwise
+// dest is the file path. Run fails an item whose file path is already taken by
+// an earlier item in the queue.
+func (d *Downloader) Enqueue(version *ModelVersion, dest string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.queue = append(d.queue, downloadJob{version: version, dest: dest})
+}
+
+// Len returns the number of queued downloads
+func (d *Downloader) Len() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return len(d.queue)
+}
+
+// Run downloads everything queued so far and empties the queue. Results are
+// returned in enqueue order; the error is non-nil when any download failed.
+func (d *Downloader) Run(ctx context.Context) ([]DownloadResult, error) {
+	d.mu.Lock()
+	jobs := d.queue
+	d.queue = nil
+	d.mu.Unlock()
+
+	results := make([]DownloadResult, len(jobs))
+	claimed := make(map[string]bool, len(jobs))
+	for i, job := range jobs {
+		results[i] = d.resolve(job)
+		if results[i].Err != nil {
+			continue
+		}
+		// Two downloads into one file would share, and corrupt, its partial file
+		if claimed[results[i].Path] {
+			results[i].Err = fmt.Errorf("destination %s is already used by another queued download", results[i].Path)
+			continue
+		}
+		claimed[results[i].Path] = true
+	}
+
+	sem := make(chan struct{}, d.concurrency)
+	var wg sync.WaitGroup
+
+	for i := range results {
+		if results[i].Err != nil {
+			continue
+		}
+		wg.Add(1)
+		go func(result *DownloadResult) {
+			defer wg.Done()
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+				d.download(ctx, result)
+			case <-ctx.Done():
+				result.Err = ctx.Err()
+			}
+		}(&results[i])
+	}
+	wg.Wait()
+
+	failed := 0
+	for _, result := range results {
+		if result.Err != nil {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return results, fmt.Errorf("%d of %d downloads failed", failed, len(results))
+	}
+	return results, nil
+}
+
+// resolve selects the file for a job and the path it is saved to
+func (d *Downloader) resolve(job downloadJob) DownloadResult {
+	result := DownloadResult{Version: job.version}
+	if job.version == nil {
+		result.Err = errors.New("version cannot be nil")
+		return result
+	}
+
+	file := job.version.SelectFile(d.preference)
+	if file == nil {
+		result.Err = fmt.Errorf("%w: version %d has no file matching %s", ErrNoMatchingFile, job.version.ID, d.preference)
+		return result
+	}
+	result.File = file
+
+	path, err := downloadPath(job.dest, *file)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	result.Path = filepath.Clean(path)
+
+	return result
+}
+
+// download retries the resolved download until it succeeds
+func (d *Downloader) download(ctx context.Context, result *DownloadResult) {
+	for attempt := 0; attempt <= d.retries; attempt++ {
+		if attempt > 0 {
+			timer := time.NewTimer(d.client.calculateBackoffDelay(attempt - 1))
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				result.Err = ctx.Err()
+				return
+			case <-timer.C:
+			}
+		}
+
+		result.Attempts++
+		result.Bytes, result.Err = d.downloadToFile(ctx, result.Version, result.File, result.Path)
+		if result.Err == nil || ctx.Err() != nil {
+			return
+		}
+	}
+}
+
+// downloadPath resolves the destination file path for a job. dest names a
+// directory when it ends in a path separator or already is one; the file then
+// keeps the base of its own name inside it, so a crafted name can't escape it.
+func downloadPath(dest string, file File) (string, error) {
+	if dest == "" {
+		return "", errors.New("destination cannot be empty")
+	}
+
+	isDir := os.IsPathSeparator(dest[len(dest)-1])
+	if !isDir {
+		info, err := os.Stat(dest)
+		isDir = err == nil && info.IsDir()
+	}
+	if isDir {
+		name := filepath.Base(file.Name)
+		if name == "." || name == ".." || name == string(filepath.Separator) {
+			return "", fmt.Errorf("file %d has no usable name", file.ID)
+		}
+		return filepath.Join(dest, name), nil
+	}
+
+	return dest, nil
+}
+
+// downloadToFile downloads file to path through the client's DownloadFile
+// path, resuming from an existing partial file
+func (d *Downloader) downloadToFile(ctx context.Context, version *ModelVersion, file *File, path string) (int64, error) {
+	if file.URL == "" {
+		return 0, errors.New("file has no download URL")
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return 0, fmt.Errorf("failed to create destination directory: %w", err)
+	}
+
+	partPath := path + partialDownloadSuffix
+	out, err := os.OpenFile(partPath, os.O_CREATE|os.O_RDWR, 0o644)
+	if err != nil {
+		return 0, fmt.Errorf("failed to open partial file: %w", err)
+	}
+	defer out.Close()
+
+	partial := &partialDownload{file: out}
+	if d.progress != nil {
+		partial.progress = func(written, total int64) {
+			d.progress(DownloadProgress{Version: version, File: file, Path: path, Downloaded: written, Total: total})
+		}
+	}
+
+	size, err := d.client.downloadFile(ctx, *file, nil, partial)
+	if err != nil {
+		return size, err
+	}
+
+	if err := out.Close(); err != nil {
+		return size, fmt.Errorf("failed to close partial file: %w", err)
+	}
+	if err := os.Rename(partPath, path); err != nil {
+		return size, fmt.Errorf("failed to move completed download into place: %w", err)
+	}
+
+	return size, nil
+}
+
+// contentRangeTotal returns the complete length from a "bytes start-end/total"
+// Content-Range header, or -1 when it is missing or unknown
+func contentRangeTotal(header string) int64 {
+	_, total, found := strings.Cut(header, "/")
+	if !found {
+		return -1
+	}
+	size, err := strconv.ParseInt(total, 10, 64)
+	if err != nil {
+		return -1
+	}
+	return size
+}
+
+// progressWriter reports the running total after each write
+type progressWriter struct {
+	w       io.Writer
+	written int64
+	report  func(written int64)
+}
+
+func (p *progressWriter) Write(b []byte) (int, error) {
+	n, err := p.w.Write(b)
+	p.written += int64(n)
+	p.report(p.written)
+	return n, err
+}
+
+// progressWriterAt reports the running total after each write from parallel
+// download segments
+type progressWriterAt struct {
+	*os.File
+	mu      sync.Mutex
+	written int64
+	report  func(written int64)
+}
+
+func (p *progressWriterAt) WriteAt(b []byte, off int64) (int, error) {
+	n, err := p.File.WriteAt(b, off)
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.written += int64(n)
+	p.report(p.written)
+	return n, err
+}
@@ -0,0 +1,358 @@
+/*
+Copyright (c) 2025 Regi Ellis
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package civitai
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestDownloader(t *testing.T) {
+	contents := map[string][]byte{
+		"/a":     bytes.Repeat([]byte("a"), 64*1024),
+		"/b":     bytes.Repeat([]byte("b"), 32*1024),
+		"/c":     bytes.Repeat([]byte("c"), 48*1024),
+		"/large": bytes.Repeat([]byte("l"), 2*minDownloadSegmentSize+4096),
+	}
+	hashOf := func(content []byte) string {
+		sum := sha256.Sum256(content)
+		return hex.EncodeToString(sum[:])
+	}
+
+	var (
+		flakyCalls    int32
+		rangeRequests int32
+		inFlight      int32
+		maxInFlight   int32
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			max := atomic.LoadInt32(&maxInFlight)
+			if current <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, current) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+
+		if r.Header.Get("Range") != "" {
+			atomic.AddInt32(&rangeRequests, 1)
+		}
+
+		path := r.URL.Path
+		if path == "/flaky" {
+			// The first attempt drops the connection halfway through
+			path = "/a"
+			if atomic.AddInt32(&flakyCalls, 1) == 1 {
+				w.Header().Set("Content-Length", "65536")
+				w.Write(contents[path][:1000])
+				w.(http.Flusher).Flush()
+				panic(http.ErrAbortHandler)
+			}
+		}
+
+		if path == "/unsatisfiable" {
+			// Rejects every range without saying how large the file is
+			if r.Header.Get("Range") != "" {
+				w.WriteHeader(http.StatusRequestedRangeNotSatisfiable)
+				return
+			}
+			path = "/b"
+		}
+
+		content, ok := contents[path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		http.ServeContent(w, r, "file", time.Time{}, bytes.NewReader(content))
+	}))
+	defer server.Close()
+
+	newVersion := func(id int, path, name, hash string) *ModelVersion {
+		return &ModelVersion{ID: id, Files: []File{{
+			ID:      id,
+			Name:    name,
+			URL:     server.URL + path,
+			Primary: true,
+			Hashes:  Hashes{SHA256: hash},
+		}}}
+	}
+
+	client := NewClientWithoutAuth(WithRetryConfig(0, time.Millisecond, time.Millisecond))
+
+	t.Run("Downloads queue with bounded concurrency", func(t *testing.T) {
+		dir := t.TempDir()
+		atomic.StoreInt32(&maxInFlight, 0)
+
+		var (
+			mu       sync.Mutex
+			progress = make(map[int]int64)
+		)
+		downloader := client.NewDownloader(
+			WithDownloaderConcurrency(2),
+			WithDownloadProgress(func(p DownloadProgress) {
+				mu.Lock()
+				progress[p.Version.ID] = p.Downloaded
+				mu.Unlock()
+			}),
+		)
+		downloader.Enqueue(newVersion(1, "/a", "a.safetensors", hashOf(contents["/a"])), dir)
+		downloader.Enqueue(newVersion(2, "/b", "b.safetensors", hashOf(contents["/b"])), dir)
+		downloader.Enqueue(newVersion(3, "/c", "c.safetensors", ""), filepath.Join(dir, "nested", "custom.bin"))
+
+		if downloader.Len() != 3 {
+			t.Fatalf("Expected 3 queued downloads, got %d", downloader.Len())
+		}
+
+		results, err := downloader.Run(context.Background())
+		if err != nil {
+			t.Fatalf("Run failed: %v", err)
+		}
+		if downloader.Len() != 0 {
+			t.Errorf("Expected queue to be drained, got %d", downloader.Len())
+		}
+
+		expected := []struct {
+			path    string
+			content []byte
+		}{
+			{filepath.Join(dir, "a.safetensors"), contents["/a"]},
+			{filepath.Join(dir, "b.safetensors"), contents["/b"]},
+			{filepath.Join(dir, "nested", "custom.bin"), contents["/c"]},
+		}
+		for i, result := range results {
+			if result.Err != nil || result.Attempts != 1 {
+				t.Errorf("Result %d: expected success on first attempt, got %v after %d", i, result.Err, result.Attempts)
+			}
+			if result.Path != expected[i].path {
+				t.Errorf("Result %d: expected path %s, got %s", i, expected[i].path, result.Path)
+			}
+			data, err := os.ReadFile(expected[i].path)
+			if err != nil || !bytes.Equal(data, expected[i].content) {
+				t.Errorf("Result %d: file content mismatch (err %v)", i, err)
+			}
+			if progress[result.Version.ID] != int64(len(expected[i].content)) {
+				t.Errorf("Result %d: expected final progress %d, got %d", i, len(expected[i].content), progress[result.Version.ID])
+			}
+			if _, err := os.Stat(expected[i].path + partialDownloadSuffix); !os.IsNotExist(err) {
+				t.Errorf("Result %d: expected partial file to be removed", i)
+			}
+		}
+
+		if max := atomic.LoadInt32(&maxInFlight); max > 2 {
+			t.Errorf("Expected at most 2 concurrent downloads, got %d", max)
+		}
+	})
+
+	t.Run("Retries and resumes interrupted download", func(t *testing.T) {
+		dir := t.TempDir()
+		atomic.StoreInt32(&rangeRequests, 0)
+
+		downloader := client.NewDownloader(WithDownloaderRetries(2))
+		downloader.Enqueue(newVersion(4, "/flaky", "flaky.safetensors", hashOf(contents["/a"])), dir)
+
+		results, err := downloader.Run(context.Background())
+		if err != nil {
+			t.Fatalf("Run failed: %v", results[0].Err)
+		}
+		if results[0].Attempts != 2 {
+			t.Errorf("Expected 2 attempts, got %d", results[0].Attempts)
+		}
+		if atomic.LoadInt32(&rangeRequests) != 1 {
+			t.Errorf("Expected the retry to resume with a range request, got %d", rangeRequests)
+		}
+		data, _ := os.ReadFile(filepath.Join(dir, "flaky.safetensors"))
+		if !bytes.Equal(data, contents["/a"]) {
+			t.Error("Expected resumed file to match the original content")
+		}
+	})
+
+	t.Run("Resumes partial file from an earlier run", func(t *testing.T) {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "b.safetensors")
+		if err := os.WriteFile(path+partialDownloadSuffix, contents["/b"][:1000], 0o644); err != nil {
+			t.Fatal(err)
+		}
+		atomic.StoreInt32(&rangeRequests, 0)
+
+		downloader := client.NewDownloader()
+		downloader.Enqueue(newVersion(2, "/b", "b.safetensors", hashOf(contents["/b"])), dir)
+
+		if _, err := downloader.Run(context.Background()); err != nil {
+			t.Fatalf("Run failed: %v", err)
+		}
+		if atomic.LoadInt32(&rangeRequests) != 1 {
+			t.Errorf("Expected a range request, got %d", rangeRequests)
+		}
+		data, _ := os.ReadFile(path)
+		if !bytes.Equal(data, contents["/b"]) {
+			t.Error("Expected resumed file to match the original content")
+		}
+	})
+
+	t.Run("Rejects unverifiable partial file", func(t *testing.T) {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "b.safetensors")
+		if err := os.WriteFile(path+partialDownloadSuffix, contents["/b"][:1000], 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		version := newVersion(8, "/unsatisfiable", "b.safetensors", "")
+		version.Files[0].SizeKB = 32
+		downloader := client.NewDownloader(WithDownloaderRetries(1))
+		downloader.Enqueue(version, dir)
+
+		results, err := downloader.Run(context.Background())
+		if err != nil {
+			t.Fatalf("Run failed: %v", results[0].Err)
+		}
+		if results[0].Attempts != 2 {
+			t.Errorf("Expected the stale partial file to cost an attempt, got %d attempts", results[0].Attempts)
+		}
+		data, _ := os.ReadFile(path)
+		if !bytes.Equal(data, contents["/b"]) {
+			t.Error("Expected the download to start over and match the original content")
+		}
+	})
+
+	t.Run("Discards oversized partial file", func(t *testing.T) {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "b.safetensors")
+		oversized := append(append([]byte{}, contents["/b"]...), bytes.Repeat([]byte("x"), 8*1024)...)
+		if err := os.WriteFile(path+partialDownloadSuffix, oversized, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		atomic.StoreInt32(&rangeRequests, 0)
+
+		version := newVersion(9, "/b", "b.safetensors", "")
+		version.Files[0].SizeKB = 32
+		downloader := client.NewDownloader()
+		downloader.Enqueue(version, dir)
+
+		if _, err := downloader.Run(context.Background()); err != nil {
+			t.Fatalf("Run failed: %v", err)
+		}
+		if atomic.LoadInt32(&rangeRequests) != 0 {
+			t.Errorf("Expected no range request, got %d", rangeRequests)
+		}
+		data, _ := os.ReadFile(path)
+		if !bytes.Equal(data, contents["/b"]) {
+			t.Error("Expected the download to start over and match the original content")
+		}
+	})
+
+	t.Run("Uses parallel ranged downloads", func(t *testing.T) {
+		dir := t.TempDir()
+		atomic.StoreInt32(&rangeRequests, 0)
+
+		var final int64
+		parallel := NewClientWithoutAuth(WithDownloadConcurrency(2))
+		downloader := parallel.NewDownloader(WithDownloadProgress(func(p DownloadProgress) {
+			if p.Downloaded == p.Total {
+				atomic.StoreInt64(&final, p.Downloaded)
+			}
+		}))
+		downloader.Enqueue(newVersion(10, "/large", "large.safetensors", hashOf(contents["/large"])), dir)
+
+		if _, err := downloader.Run(context.Background()); err != nil {
+			t.Fatalf("Run failed: %v", err)
+		}
+		if got := atomic.LoadInt32(&rangeRequests); got != 2 {
+			t.Errorf("Expected 2 segment requests, got %d", got)
+		}
+		if got := atomic.LoadInt64(&final); got != int64(len(contents["/large"])) {
+			t.Errorf("Expected final progress %d, got %d", len(contents["/large"]), got)
+		}
+		data, _ := os.ReadFile(filepath.Join(dir, "large.safetensors"))
+		if !bytes.Equal(data, contents["/large"]) {
+			t.Error("Expected assembled file to match the original content")
+		}
+	})
+
+	t.Run("Rejects duplicate destinations", func(t *testing.T) {
+		dir := t.TempDir()
+
+		downloader := client.NewDownloader()
+		downloader.Enqueue(newVersion(1, "/a", "model.safetensors", hashOf(contents["/a"])), dir)
+		downloader.Enqueue(newVersion(2, "/b", "model.safetensors", hashOf(contents["/b"])), dir+string(filepath.Separator))
+
+		results, err := downloader.Run(context.Background())
+		if err == nil || !strings.Contains(err.Error(), "1 of 2") {
+			t.Errorf("Expected '1 of 2 downloads failed', got %v", err)
+		}
+		if results[0].Err != nil {
+			t.Errorf("Expected first download to succeed, got %v", results[0].Err)
+		}
+		if results[1].Err == nil || results[1].Attempts != 0 {
+			t.Errorf("Expected duplicate to fail without an attempt, got %v after %d", results[1].Err, results[1].Attempts)
+		}
+		data, _ := os.ReadFile(filepath.Join(dir, "model.safetensors"))
+		if !bytes.Equal(data, contents["/a"]) {
+			t.Error("Expected the first download's content")
+		}
+	})
+
+	t.Run("Reports per-item failures", func(t *testing.T) {
+		dir := t.TempDir()
+
+		downloader := client.NewDownloader(WithDownloaderRetries(1))
+		downloader.Enqueue(newVersion(1, "/a", "a.safetensors", hashOf(contents["/a"])), dir)
+		downloader.Enqueue(newVersion(5, "/missing", "missing.safetensors", ""), dir)
+		downloader.Enqueue(newVersion(6, "/c", "bad.safetensors", strings.Repeat("0", 64)), dir)
+		downloader.Enqueue(&ModelVersion{ID: 7}, dir)
+
+		results, err := downloader.Run(context.Background())
+		if err == nil || !strings.Contains(err.Error(), "3 of 4") {
+			t.Errorf("Expected '3 of 4 downloads failed', got %v", err)
+		}
+		if results[0].Err != nil {
+			t.Errorf("Expected first download to succeed, got %v", results[0].Err)
+		}
+		if results[1].Err == nil || results[1].Attempts != 2 {
+			t.Errorf("Expected missing file to fail after 2 attempts, got %v after %d", results[1].Err, results[1].Attempts)
+		}
+		if !errors.Is(results[2].Err, ErrHashMismatch) {
+			t.Errorf("Expected ErrHashMismatch, got %v", results[2].Err)
+		}
+		if _, err := os.Stat(filepath.Join(dir, "bad.safetensors")); !os.IsNotExist(err) {
+			t.Error("Expected corrupt download not to be moved into place")
+		}
+		if !errors.Is(results[3].Err, ErrNoMatchingFile) {
+			t.Errorf("Expected ErrNoMatchingFile, got %v", results[3].Err)
+		}
+	})
+}
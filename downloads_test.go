@@ -0,0 +1,399 @@
+/*
+Copyright (c) 2025 Regi Ellis
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package civitai
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newDownloadServer serves content with byte-range support and counts ranged requests
+func newDownloadServer(content []byte, rangeRequests *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Range") != "" {
+			atomic.AddInt32(rangeRequests, 1)
+		}
+		http.ServeContent(w, r, "model.safetensors", time.Time{}, bytes.NewReader(content))
+	}))
+}
+
+func TestDownloadFile(t *testing.T) {
+	content := make([]byte, 4*minDownloadSegmentSize+123)
+	rand.New(rand.NewSource(1)).Read(content)
+	sum := sha256.Sum256(content)
+	hash := hex.EncodeToString(sum[:])
+
+	t.Run("Sequential download", func(t *testing.T) {
+		var rangeRequests int32
+		server := newDownloadServer(content, &rangeRequests)
+		defer server.Close()
+
+		client := NewClientWithoutAuth()
+		var buf bytes.Buffer
+		written, err := client.DownloadFile(context.Background(), File{URL: server.URL, Hashes: Hashes{SHA256: hash}}, &buf)
+		if err != nil {
+			t.Fatalf("DownloadFile failed: %v", err)
+		}
+
+		if written != int64(len(content)) {
+			t.Errorf("Expected %d bytes written, got %d", len(content), written)
+		}
+		if !bytes.Equal(buf.Bytes(), content) {
+			t.Error("Downloaded content does not match")
+		}
+		if rangeRequests != 0 {
+			t.Errorf("Expected no ranged requests, got %d", rangeRequests)
+		}
+	})
+
+	t.Run("Parallel ranged download", func(t *testing.T) {
+		var rangeRequests int32
+		server := newDownloadServer(content, &rangeRequests)
+		defer server.Close()
+
+		client := NewClientWithoutAuth(WithDownloadConcurrency(4))
+		out, err := os.Create(filepath.Join(t.TempDir(), "model.safetensors"))
+		if err != nil {
+			t.Fatalf("Failed to create output file: %v", err)
+		}
+		defer out.Close()
+
+		written, err := client.DownloadFile(context.Background(), File{URL: server.URL, Hashes: Hashes{SHA256: hash}}, out)
+		if err != nil {
+			t.Fatalf("DownloadFile failed: %v", err)
+		}
+
+		if written != int64(len(content)) {
+			t.Errorf("Expected %d bytes written, got %d", len(content), written)
+		}
+		if rangeRequests != 4 {
+			t.Errorf("Expected 4 ranged requests, got %d", rangeRequests)
+		}
+
+		data, err := os.ReadFile(out.Name())
+		if err != nil {
+			t.Fatalf("Failed to read output file: %v", err)
+		}
+		if !bytes.Equal(data, content) {
+			t.Error("Assembled content does not match")
+		}
+	})
+
+	t.Run("Fallback without range support", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Range") != "" {
+				t.Error("Did not expect a ranged request")
+			}
+			w.Write(content)
+		}))
+		defer server.Close()
+
+		client := NewClientWithoutAuth(WithDownloadConcurrency(4))
+		out, err := os.Create(filepath.Join(t.TempDir(), "model.safetensors"))
+		if err != nil {
+			t.Fatalf("Failed to create output file: %v", err)
+		}
+		defer out.Close()
+
+		written, err := client.DownloadFile(context.Background(), File{URL: server.URL}, out)
+		if err != nil {
+			t.Fatalf("DownloadFile failed: %v", err)
+		}
+		if written != int64(len(content)) {
+			t.Errorf("Expected %d bytes written, got %d", len(content), written)
+		}
+	})
+
+	t.Run("Hash mismatch", func(t *testing.T) {
+		var rangeRequests int32
+		server := newDownloadServer(content, &rangeRequests)
+		defer server.Close()
+
+		client := NewClientWithoutAuth(WithDownloadConcurrency(4))
+		out, err := os.Create(filepath.Join(t.TempDir(), "model.safetensors"))
+		if err != nil {
+			t.Fatalf("Failed to create output file: %v", err)
+		}
+		defer out.Close()
+
+		_, err = client.DownloadFile(context.Background(), File{URL: server.URL, Hashes: Hashes{SHA256: "deadbeef"}}, out)
+		if !errors.Is(err, ErrHashMismatch) {
+			t.Errorf("Expected ErrHashMismatch, got %v", err)
+		}
+	})
+
+	t.Run("Missing URL", func(t *testing.T) {
+		client := NewClientWithoutAuth()
+		if _, err := client.DownloadFile(context.Background(), File{}, &bytes.Buffer{}); err == nil {
+			t.Error("Expected error for file without URL")
+		}
+	})
+}
+
+func TestDownloadToPath(t *testing.T) {
+	content := []byte("model weights")
+	sum := sha256.Sum256(content)
+	hash := hex.EncodeToString(sum[:])
+
+	var rangeRequests int32
+	server := newDownloadServer(content, &rangeRequests)
+	defer server.Close()
+
+	client := NewClientWithoutAuth()
+	ctx := context.Background()
+
+	t.Run("Writes verified file", func(t *testing.T) {
+		dir := t.TempDir()
+		file := File{Name: "model.safetensors", URL: server.URL, Hashes: Hashes{SHA256: hash}}
+
+		written, err := client.DownloadToPath(ctx, file, dir)
+		if err != nil {
+			t.Fatalf("DownloadToPath failed: %v", err)
+		}
+		if written != int64(len(content)) {
+			t.Errorf("Expected %d bytes written, got %d", len(content), written)
+		}
+
+		data, err := os.ReadFile(filepath.Join(dir, "model.safetensors"))
+		if err != nil {
+			t.Fatalf("Failed to read downloaded file: %v", err)
+		}
+		if !bytes.Equal(data, content) {
+			t.Error("Downloaded content does not match")
+		}
+		if entries, _ := os.ReadDir(dir); len(entries) != 1 {
+			t.Errorf("Expected only the downloaded file, got %d entries", len(entries))
+		}
+	})
+
+	t.Run("Trailing separator creates the directory", func(t *testing.T) {
+		dir := filepath.Join(t.TempDir(), "models") + string(filepath.Separator)
+		file := File{Name: "../escape/model.safetensors", URL: server.URL, Hashes: Hashes{SHA256: hash}}
+
+		if _, err := client.DownloadToPath(ctx, file, dir); err != nil {
+			t.Fatalf("DownloadToPath failed: %v", err)
+		}
+		data, err := os.ReadFile(filepath.Join(dir, "model.safetensors"))
+		if err != nil {
+			t.Fatalf("Expected file inside the new directory: %v", err)
+		}
+		if !bytes.Equal(data, content) {
+			t.Error("Downloaded content does not match")
+		}
+	})
+
+	t.Run("Failed download leaves no file", func(t *testing.T) {
+		dir := t.TempDir()
+		dest := filepath.Join(dir, "model.safetensors")
+		file := File{Name: "model.safetensors", URL: server.URL, Hashes: Hashes{SHA256: "deadbeef"}}
+
+		if _, err := client.DownloadToPath(ctx, file, dest); !errors.Is(err, ErrHashMismatch) {
+			t.Errorf("Expected ErrHashMismatch, got %v", err)
+		}
+		if _, err := os.Stat(dest); !os.IsNotExist(err) {
+			t.Errorf("Expected no file at destination, got %v", err)
+		}
+		if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+			t.Errorf("Expected temporary file to be cleaned up, got %d entries", len(entries))
+		}
+	})
+
+	t.Run("Failed download keeps existing file", func(t *testing.T) {
+		dest := filepath.Join(t.TempDir(), "model.safetensors")
+		if err := os.WriteFile(dest, []byte("previous"), 0o644); err != nil {
+			t.Fatalf("Failed to write existing file: %v", err)
+		}
+
+		file := File{URL: server.URL, Hashes: Hashes{SHA256: "deadbeef"}}
+		if _, err := client.DownloadToPath(ctx, file, dest); err == nil {
+			t.Error("Expected download to fail")
+		}
+		if data, _ := os.ReadFile(dest); string(data) != "previous" {
+			t.Errorf("Expected existing file to be untouched, got %q", data)
+		}
+	})
+}
+
+func TestDownloadBestFile(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("contents of " + r.URL.Path))
+	}))
+	defer server.Close()
+
+	prefs := []FileFormat{FileFormatSafeTensors, FileFormatCKPT}
+	client := NewClientWithoutAuth()
+
+	tests := []struct {
+		name     string
+		files    []File
+		wantID   int
+		wantBody string
+	}{
+		{
+			name: "SafeTensor preferred",
+			files: []File{
+				{ID: 1, URL: server.URL + "/ckpt", Metadata: FileMetadata{Format: FileFormatCKPT}},
+				{ID: 2, URL: server.URL + "/st", Metadata: FileMetadata{Format: FileFormatSafeTensors}},
+			},
+			wantID:   2,
+			wantBody: "contents of /st",
+		},
+		{
+			name: "Fall back to CKPT",
+			files: []File{
+				{ID: 1, URL: server.URL + "/ckpt", Metadata: FileMetadata{Format: FileFormatCKPT}},
+				{ID: 3, URL: server.URL + "/pickle", Metadata: FileMetadata{Format: FileFormatPickleTensor}},
+			},
+			wantID:   1,
+			wantBody: "contents of /ckpt",
+		},
+		{
+			name: "Skip unclean SafeTensor",
+			files: []File{
+				{ID: 2, URL: server.URL + "/st", Metadata: FileMetadata{Format: FileFormatSafeTensors}, VirusScanResult: "Danger"},
+				{ID: 1, URL: server.URL + "/ckpt", Metadata: FileMetadata{Format: FileFormatCKPT}},
+			},
+			wantID:   1,
+			wantBody: "contents of /ckpt",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			file, written, err := client.DownloadBestFile(context.Background(), &ModelVersion{ID: 9, Files: tt.files}, prefs, &buf)
+			if err != nil {
+				t.Fatalf("DownloadBestFile failed: %v", err)
+			}
+			if file.ID != tt.wantID {
+				t.Errorf("Expected file %d, got %d", tt.wantID, file.ID)
+			}
+			if buf.String() != tt.wantBody || written != int64(len(tt.wantBody)) {
+				t.Errorf("Expected body %q (%d bytes), got %q (%d bytes)", tt.wantBody, len(tt.wantBody), buf.String(), written)
+			}
+		})
+	}
+
+	t.Run("No preferred format available", func(t *testing.T) {
+		version := &ModelVersion{ID: 9, Files: []File{{ID: 3, URL: server.URL, Metadata: FileMetadata{Format: FileFormatPickleTensor}}}}
+		_, _, err := client.DownloadBestFile(context.Background(), version, prefs, &bytes.Buffer{})
+		if !errors.Is(err, ErrNoMatchingFile) {
+			t.Errorf("Expected ErrNoMatchingFile, got %v", err)
+		}
+	})
+}
+
+func TestResolveDownload(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/models/10/versions":
+			w.Write([]byte(`[
+				{"id": 101, "createdAt": "2024-01-01T00:00:00Z", "files": [{"name": "old.safetensors", "url": "https://civitai.com/api/download/models/101", "metadata": {"format": "SafeTensor"}}]},
+				{"id": 102, "createdAt": "2024-06-01T00:00:00Z", "files": [
+					{"name": "new.ckpt", "url": "https://civitai.com/api/download/models/102?type=Model&format=PickleTensor", "primary": true, "metadata": {"format": "PickleTensor"}},
+					{"name": "new.safetensors", "url": "https://civitai.com/api/download/models/102?type=Model&format=SafeTensor", "metadata": {"format": "SafeTensor"}}
+				]}
+			]`))
+		case "/model-versions/201":
+			w.Write([]byte(`{"id": 201, "modelId": 20, "files": [
+				{"name": "infected.safetensors", "url": "https://civitai.com/api/download/models/201", "primary": true, "virusScanResult": "Danger", "metadata": {"format": "SafeTensor"}},
+				{"name": "clean.ckpt", "url": "https://civitai.com/api/download/models/201?format=CKPT", "metadata": {"format": "CKPT"}}
+			]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	client := NewClientWithoutAuth(WithBaseURL(server.URL), WithRetryConfig(0, 0, 0))
+
+	t.Run("Model-only AIR uses latest version", func(t *testing.T) {
+		air, _ := ParseAIR("urn:air:sdxl:lora:civitai:10")
+		file, url, err := client.ResolveDownload(ctx, air)
+		if err != nil {
+			t.Fatalf("ResolveDownload failed: %v", err)
+		}
+		if file.Name != "new.safetensors" {
+			t.Errorf("Expected SafeTensor file of latest version, got %s", file.Name)
+		}
+		if url != file.URL {
+			t.Errorf("Expected file URL without token, got %s", url)
+		}
+	})
+
+	t.Run("Version-specific AIR with format", func(t *testing.T) {
+		air, _ := ParseAIR("urn:air:sdxl:model:civitai:20@201.ckpt")
+		file, _, err := client.ResolveDownload(ctx, air)
+		if err != nil {
+			t.Fatalf("ResolveDownload failed: %v", err)
+		}
+		if file.Name != "clean.ckpt" {
+			t.Errorf("Expected clean.ckpt, got %s", file.Name)
+		}
+	})
+
+	t.Run("No clean file in format", func(t *testing.T) {
+		air, _ := ParseAIR("urn:air:sdxl:model:civitai:20@201.safetensors")
+		if _, _, err := client.ResolveDownload(ctx, air); !errors.Is(err, ErrNoMatchingFile) {
+			t.Errorf("Expected ErrNoMatchingFile, got %v", err)
+		}
+	})
+
+	t.Run("Missing version", func(t *testing.T) {
+		air, _ := ParseAIR("urn:air:sdxl:model:civitai:20@999")
+		if _, _, err := client.ResolveDownload(ctx, air); !errors.Is(err, ErrNotFound) {
+			t.Errorf("Expected ErrNotFound, got %v", err)
+		}
+	})
+
+	t.Run("Token added to URL", func(t *testing.T) {
+		authed := NewClient("my-token", WithBaseURL(server.URL), WithRetryConfig(0, 0, 0))
+		air, _ := ParseAIR("urn:air:sdxl:model:civitai:20@201")
+		_, url, err := authed.ResolveDownload(ctx, air)
+		if err != nil {
+			t.Fatalf("ResolveDownload failed: %v", err)
+		}
+		if url != "https://civitai.com/api/download/models/201?format=CKPT&token=my-token" {
+			t.Errorf("Expected URL with token, got %s", url)
+		}
+	})
+
+	t.Run("Nil AIR", func(t *testing.T) {
+		if _, _, err := client.ResolveDownload(ctx, nil); err == nil {
+			t.Error("Expected error for nil AIR")
+		}
+	})
+}
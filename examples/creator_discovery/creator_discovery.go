@@ -136,24 +136,19 @@ func main() {
 
 	// Example 7: Use tags to find related models
 	fmt.Println("\n=== Models with Popular Tags ===")
-	if len(tags) > 0 {
-		// Use the most popular tag to find models
-		popularTag := tags[0].Name
-
-		tagModelParams := civitai.SearchParams{
-			Tag:   popularTag,
-			Sort:  civitai.SortMostDownload,
-			Limit: 5,
-		}
-
-		tagModels, _, err := client.SearchModels(ctx, tagModelParams)
-		if err != nil {
-			log.Printf("Failed to search models by tag: %v", err)
-		} else {
-			fmt.Printf("Top models tagged with '%s':\n", popularTag)
-			for _, model := range tagModels {
-				fmt.Printf("- %s (%d downloads)\n", model.Name, model.Stats.DownloadCount)
-			}
+	tagModels, err := client.GetModelsForTopTagsWithOptions(ctx, civitai.TopTagsOptions{
+		TagLimit:     3,
+		ModelsPerTag: 5,
+		Concurrency:  2,
+		DedupeModels: true,
+	})
+	if err != nil {
+		log.Printf("Failed to search models for some tags: %v", err)
+	}
+	for tag, models := range tagModels {
+		fmt.Printf("Top models tagged with '%s':\n", tag)
+		for _, model := range models {
+			fmt.Printf("- %s (%d downloads)\n", model.Name, model.Stats.DownloadCount)
 		}
 	}
 
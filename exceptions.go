@@ -22,6 +22,166 @@ SOFTWARE.
 
 package civitai
 
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// ErrResponseTooLarge is matched by errors.Is when a response body exceeds the
+// client's maximum response size. Use errors.As with *ResponseTooLargeError to
+// read the limit that was hit.
+var ErrResponseTooLarge = errors.New("response too large")
+
+// ErrTooBusy is returned when WithMaxConcurrentRequests is set and no request
+// slot becomes available within the WithSemaphoreAcquireTimeout duration
+var ErrTooBusy = errors.New("client too busy")
+
+// ErrRetriesExhausted is returned when every attempt of a retried request
+// failed; the error also wraps the last attempt's error. Requests stopped by
+// context cancellation or deadline report the context's error instead, so
+// errors.Is(err, context.Canceled) or errors.Is(err, context.DeadlineExceeded)
+// means the caller gave up rather than the server.
+var ErrRetriesExhausted = errors.New("retries exhausted")
+
+// ErrNoPreviousPage is returned by SearchModelsPrev when the metadata has no
+// previous cursor or page, such as on the first page of results
+var ErrNoPreviousPage = errors.New("no previous page")
+
+// ErrAcceptResponse can be returned by a WithErrorClassifier function to have a
+// response decoded as a success even though its status code is not 2xx. It is
+// never returned by the client.
+var ErrAcceptResponse = errors.New("accept response")
+
+// ErrNotFound is matched by errors.Is when the requested resource doesn't
+// exist: the API responded 404, or a lookup such as GetLatestVersion had
+// nothing to return
+var ErrNotFound = errors.New("not found")
+
+// ErrAuthRequired is matched by errors.Is when a search that only works for
+// authenticated users, such as one filtering on favorites or hidden models,
+// was rejected with 401 or 403
+var ErrAuthRequired = errors.New("authentication required")
+
+// ErrUnsupportedCharset is matched by errors.Is when WithCharsetDetection is
+// set and a response body is in a charset the client can't transcode to UTF-8
+var ErrUnsupportedCharset = errors.New("unsupported response charset")
+
+// ErrAmbiguous is returned when a lookup by name matches several resources equally well
+var ErrAmbiguous = errors.New("ambiguous match")
+
+// ResponseTooLargeError reports a response body that exceeded the configured limit
+type ResponseTooLargeError struct {
+	Limit int64 // Maximum allowed response size in bytes
+}
+
+// Error implements the error interface for ResponseTooLargeError
+func (e *ResponseTooLargeError) Error() string {
+	return fmt.Sprintf("response size exceeded maximum allowed size of %d bytes", e.Limit)
+}
+
+// Is reports whether target is ErrResponseTooLarge
+func (e *ResponseTooLargeError) Is(target error) bool {
+	return target == ErrResponseTooLarge
+}
+
+// statusError is an error for a non-2xx API response that remembers the HTTP
+// status code, so callers inside the package can react to specific statuses
+type statusError struct {
+	status int
+	err    error
+}
+
+// Error implements the error interface for statusError
+func (e *statusError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap returns the underlying error
+func (e *statusError) Unwrap() error {
+	return e.err
+}
+
+// Is reports whether target is ErrNotFound and the response was a 404
+func (e *statusError) Is(target error) bool {
+	return target == ErrNotFound && e.status == http.StatusNotFound
+}
+
+// responseStatus returns the HTTP status code of a failed API response in
+// err's chain, or 0 when err did not come from an API response
+func responseStatus(err error) int {
+	var se *statusError
+	if errors.As(err, &se) {
+		return se.status
+	}
+	return 0
+}
+
+// snapshotRedactionMargin is how far past its limit a responseSnapshot reads,
+// so secrets cut off by the limit are still recognized and redacted
+const snapshotRedactionMargin = 256
+
+// responseSnapshot keeps the first bytes written to it, for
+// WithErrorResponseSnapshot. It captures up to capture bytes, which should
+// exceed limit by the length of any secret that must be redacted, and reports
+// at most limit bytes once redacted.
+type responseSnapshot struct {
+	buf     []byte
+	limit   int
+	capture int
+}
+
+// Write implements io.Writer, discarding bytes past the capture size
+func (s *responseSnapshot) Write(p []byte) (int, error) {
+	n := len(p)
+	if room := s.capture - len(s.buf); n > room {
+		p = p[:max(room, 0)]
+	}
+	s.buf = append(s.buf, p...)
+	return n, nil
+}
+
+// apiError reads any unread body from rest into the snapshot and returns an
+// APIError carrying the redacted snapshot in Details
+func (s *responseSnapshot) apiError(rest io.Reader, status int, code, message, token string) *APIError {
+	if len(s.buf) < s.capture {
+		io.Copy(io.Discard, io.LimitReader(rest, int64(s.capture-len(s.buf))))
+	}
+
+	// Redact before truncating so a secret straddling the limit can't leak
+	details := redactSecrets(string(s.buf), token)
+	truncated := len(s.buf) > s.limit
+	if truncated && len(details) > s.limit {
+		details = details[:s.limit]
+	}
+	details = strings.ToValidUTF8(details, "")
+	if truncated {
+		details += "...(truncated)"
+	}
+	return &APIError{StatusCode: status, Code: code, Message: message, Details: details}
+}
+
+// secretPatterns match credentials that may be echoed in response bodies; the
+// first group is kept and the rest replaced
+var secretPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)(bearer\s+)[^\s"',;]+`),
+	regexp.MustCompile(`(?i)("?[a-z_-]*(?:token|key|password|secret)"?\s*[:=]\s*"?)[^\s"',;&}]+`),
+}
+
+// redactSecrets masks bearer tokens, credential-like fields, and token itself in s
+func redactSecrets(s, token string) string {
+	if token != "" {
+		s = strings.ReplaceAll(s, token, "[REDACTED]")
+	}
+	for _, pattern := range secretPatterns {
+		s = pattern.ReplaceAllString(s, "${1}[REDACTED]")
+	}
+	return s
+}
+
 // import (
 // 	"fmt"
 // 	"net/http"
@@ -0,0 +1,375 @@
+/*
+Copyright (c) 2025 Regi Ellis
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+// Package civitai - Generation Parameter Parsing
+//
+// This file parses the AUTOMATIC1111 "parameters" text block that many images
+// store in their metadata instead of discrete generation fields.
+//
+// # Parsing A1111 Parameters
+//
+//	if raw, ok := image.Meta["parameters"].(string); ok {
+//		params, err := civitai.ParseA1111Parameters(raw)
+//		if err == nil {
+//			fmt.Printf("%s (%d steps, CFG %.1f, seed %d)\n",
+//				params.Sampler, params.Steps, params.CFGScale, params.Seed)
+//		}
+//	}
+//
+// The expected format is the prompt, an optional "Negative prompt:" section,
+// and a final comma-separated settings line:
+//
+//	a castle on a hill, highly detailed
+//	Negative prompt: blurry, lowres
+//	Steps: 20, Sampler: DPM++ 2M Karras, CFG scale: 7, Seed: 1234, Size: 512x768
+//
+// Settings without a dedicated field are kept in GenerationParams.Extra.
+//
+// # Extracting Prompts
+//
+// Prompt reads the prompts from an image's metadata whichever way they are
+// stored, falling back to the A1111 parameters block:
+//
+//	positive, negative := image.Prompt()
+//
+// # Generation Process, Techniques, and Tools
+//
+// DetailedImage reports these as free strings. The Parsed* methods map known
+// spellings to constants for stable comparisons:
+//
+//	switch image.ParsedGenerationProcess() {
+//	case civitai.GenerationProcessTxt2Img, civitai.GenerationProcessTxt2ImgHiRes:
+//		textOnly++
+//	case civitai.GenerationProcessImg2Img, civitai.GenerationProcessInpainting:
+//		derived++
+//	}
+//
+// Unrecognized values come back trimmed but otherwise unchanged, and the raw
+// fields are left as the API sent them.
+
+package civitai
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// GenerationParams holds generation settings parsed from image metadata
+type GenerationParams struct {
+	Prompt         string
+	NegativePrompt string
+	Steps          int
+	Sampler        string
+	CFGScale       float64
+	Seed           int64
+	Size           string
+	Width          int
+	Height         int
+	Model          string
+	ModelHash      string
+
+	// Extra holds any other settings keyed by their A1111 name (e.g. "Clip skip")
+	Extra map[string]string
+}
+
+// a1111ParamRegex matches one "Key: value" pair of the settings line, where the
+// value is either a quoted string or runs up to the next comma
+var a1111ParamRegex = regexp.MustCompile(`\s*(\w[\w \-/+]*):\s*("(?:\\.|[^\\"])+"|[^,]*)(?:,|$)`)
+
+// a1111SettingsRegex recognizes the trailing settings line
+var a1111SettingsRegex = regexp.MustCompile(`^Steps:\s*\d+`)
+
+const a1111NegativePrefix = "Negative prompt:"
+
+// ParseA1111Parameters parses an AUTOMATIC1111 "parameters" string into
+// GenerationParams. A string without a settings line yields only the prompt
+// fields; an error is returned for empty input or malformed numeric settings.
+func ParseA1111Parameters(s string) (*GenerationParams, error) {
+	s = strings.TrimSpace(strings.ReplaceAll(s, "\r\n", "\n"))
+	if s == "" {
+		return nil, errors.New("parameters string is empty")
+	}
+
+	lines := strings.Split(s, "\n")
+	params := &GenerationParams{Extra: make(map[string]string)}
+
+	settings := ""
+	if last := strings.TrimSpace(lines[len(lines)-1]); a1111SettingsRegex.MatchString(last) {
+		settings = last
+		lines = lines[:len(lines)-1]
+	}
+
+	var prompt, negative []string
+	inNegative := false
+	for _, line := range lines {
+		if !inNegative && strings.HasPrefix(line, a1111NegativePrefix) {
+			inNegative = true
+			line = strings.TrimPrefix(line, a1111NegativePrefix)
+		}
+		if inNegative {
+			negative = append(negative, line)
+		} else {
+			prompt = append(prompt, line)
+		}
+	}
+	params.Prompt = strings.TrimSpace(strings.Join(prompt, "\n"))
+	params.NegativePrompt = strings.TrimSpace(strings.Join(negative, "\n"))
+
+	for _, match := range a1111ParamRegex.FindAllStringSubmatch(settings, -1) {
+		key := strings.TrimSpace(match[1])
+		value := strings.TrimSpace(match[2])
+		if strings.HasPrefix(value, `"`) {
+			if unquoted, err := strconv.Unquote(value); err == nil {
+				value = unquoted
+			}
+		}
+		if err := params.setA1111Value(key, value); err != nil {
+			return nil, err
+		}
+	}
+
+	return params, nil
+}
+
+// setA1111Value assigns a single settings entry to its field or to Extra
+func (p *GenerationParams) setA1111Value(key, value string) error {
+	var err error
+	switch key {
+	case "Steps":
+		p.Steps, err = strconv.Atoi(value)
+	case "Sampler":
+		p.Sampler = value
+	case "CFG scale":
+		p.CFGScale, err = strconv.ParseFloat(value, 64)
+	case "Seed":
+		p.Seed, err = strconv.ParseInt(value, 10, 64)
+	case "Size":
+		p.Size = value
+		width, height, found := strings.Cut(value, "x")
+		if !found {
+			return fmt.Errorf("invalid Size %q: expected WIDTHxHEIGHT", value)
+		}
+		if p.Width, err = strconv.Atoi(width); err == nil {
+			p.Height, err = strconv.Atoi(height)
+		}
+	case "Model":
+		p.Model = value
+	case "Model hash":
+		p.ModelHash = value
+	default:
+		p.Extra[key] = value
+	}
+
+	if err != nil {
+		return fmt.Errorf("invalid %s %q: %w", key, value, err)
+	}
+	return nil
+}
+
+// Prompt returns the positive and negative prompts from the image metadata,
+// trimmed, or empty strings when absent. Keys are matched ignoring case,
+// spaces, and punctuation, so "prompt", "Prompt", "negativePrompt", and
+// "Negative prompt" are all recognized. A prompt missing from its own key is
+// taken from an A1111 "parameters" block when one is present.
+func (r *DetailedImageResponse) Prompt() (string, string) {
+	positive := strings.TrimSpace(metaString(r.Meta, "prompt"))
+	negative := strings.TrimSpace(metaString(r.Meta, "negativePrompt"))
+
+	if positive == "" || negative == "" {
+		if raw := metaString(r.Meta, "parameters"); raw != "" {
+			if params, err := ParseA1111Parameters(raw); err == nil {
+				if positive == "" {
+					positive = params.Prompt
+				}
+				if negative == "" {
+					negative = params.NegativePrompt
+				}
+			}
+		}
+	}
+
+	return positive, negative
+}
+
+// metaString returns the string stored under key in meta, falling back to a
+// key that differs only in case, spaces, and punctuation
+func metaString(meta map[string]interface{}, key string) string {
+	if s, ok := meta[key].(string); ok {
+		return s
+	}
+	want := baseModelAliasKey(key)
+	for k, v := range meta {
+		if s, ok := v.(string); ok && baseModelAliasKey(k) == want {
+			return s
+		}
+	}
+	return ""
+}
+
+// GenerationProcess is how an image was generated
+type GenerationProcess string
+
+const (
+	GenerationProcessTxt2Img      GenerationProcess = "txt2img"
+	GenerationProcessTxt2ImgHiRes GenerationProcess = "txt2imgHiRes"
+	GenerationProcessImg2Img      GenerationProcess = "img2img"
+	GenerationProcessInpainting   GenerationProcess = "inpainting"
+)
+
+// Technique is a generation technique tagged on an image
+type Technique string
+
+const (
+	TechniqueTxt2Img    Technique = "txt2img"
+	TechniqueImg2Img    Technique = "img2img"
+	TechniqueInpainting Technique = "inpainting"
+	TechniqueControlNet Technique = "controlnet"
+	TechniqueWorkflow   Technique = "workflow"
+	TechniqueTxt2Vid    Technique = "txt2vid"
+	TechniqueImg2Vid    Technique = "img2vid"
+	TechniqueVid2Vid    Technique = "vid2vid"
+)
+
+// Tool is a piece of software used to create an image
+type Tool string
+
+const (
+	ToolComfyUI       Tool = "ComfyUI"
+	ToolAutomatic1111 Tool = "Automatic1111"
+	ToolForge         Tool = "Forge"
+	ToolFooocus       Tool = "Fooocus"
+	ToolInvokeAI      Tool = "InvokeAI"
+	ToolSDNext        Tool = "SD.Next"
+	ToolPhotoshop     Tool = "Photoshop"
+)
+
+// generationProcessAliases, techniqueAliases, and toolAliases map normalized
+// spellings (see baseModelAliasKey) to their constants
+var (
+	generationProcessAliases = map[string]GenerationProcess{
+		"txt2img":         GenerationProcessTxt2Img,
+		"texttoimage":     GenerationProcessTxt2Img,
+		"txt2imghires":    GenerationProcessTxt2ImgHiRes,
+		"txt2imghiresfix": GenerationProcessTxt2ImgHiRes,
+		"img2img":         GenerationProcessImg2Img,
+		"imagetoimage":    GenerationProcessImg2Img,
+		"inpaint":         GenerationProcessInpainting,
+		"inpainting":      GenerationProcessInpainting,
+	}
+	techniqueAliases = map[string]Technique{
+		"txt2img":      TechniqueTxt2Img,
+		"texttoimage":  TechniqueTxt2Img,
+		"img2img":      TechniqueImg2Img,
+		"imagetoimage": TechniqueImg2Img,
+		"inpaint":      TechniqueInpainting,
+		"inpainting":   TechniqueInpainting,
+		"controlnet":   TechniqueControlNet,
+		"workflow":     TechniqueWorkflow,
+		"txt2vid":      TechniqueTxt2Vid,
+		"texttovideo":  TechniqueTxt2Vid,
+		"img2vid":      TechniqueImg2Vid,
+		"imagetovideo": TechniqueImg2Vid,
+		"vid2vid":      TechniqueVid2Vid,
+		"videotovideo": TechniqueVid2Vid,
+	}
+	toolAliases = map[string]Tool{
+		"comfyui":              ToolComfyUI,
+		"comfy":                ToolComfyUI,
+		"automatic1111":        ToolAutomatic1111,
+		"a1111":                ToolAutomatic1111,
+		"sdwebui":              ToolAutomatic1111,
+		"stablediffusionwebui": ToolAutomatic1111,
+		"forge":                ToolForge,
+		"sdwebuiforge":         ToolForge,
+		"fooocus":              ToolFooocus,
+		"invokeai":             ToolInvokeAI,
+		"invoke":               ToolInvokeAI,
+		"sdnext":               ToolSDNext,
+		"photoshop":            ToolPhotoshop,
+		"adobephotoshop":       ToolPhotoshop,
+	}
+)
+
+// ParseGenerationProcess maps s to a GenerationProcess constant, ignoring case,
+// spaces, and punctuation. For unrecognized values it returns s trimmed and
+// false.
+func ParseGenerationProcess(s string) (GenerationProcess, bool) {
+	return parseAlias(generationProcessAliases, s)
+}
+
+// ParseTechnique maps s to a Technique constant, ignoring case, spaces, and
+// punctuation. For unrecognized values it returns s trimmed and false.
+func ParseTechnique(s string) (Technique, bool) {
+	return parseAlias(techniqueAliases, s)
+}
+
+// ParseTool maps s to a Tool constant, ignoring case, spaces, and punctuation.
+// For unrecognized values it returns s trimmed and false.
+func ParseTool(s string) (Tool, bool) {
+	return parseAlias(toolAliases, s)
+}
+
+// parseAlias looks s up in aliases by its normalized key
+func parseAlias[T ~string](aliases map[string]T, s string) (T, bool) {
+	s = strings.TrimSpace(s)
+	if v, ok := aliases[baseModelAliasKey(s)]; ok {
+		return v, true
+	}
+	return T(s), false
+}
+
+// ParsedGenerationProcess returns GenerationProcess as a constant where
+// recognized. It returns "" when the image has no generation process.
+func (d *DetailedImage) ParsedGenerationProcess() GenerationProcess {
+	if d.GenerationProcess == "" {
+		return ""
+	}
+	process, _ := ParseGenerationProcess(d.GenerationProcess)
+	return process
+}
+
+// ParsedTechniques returns Techniques as constants where recognized
+func (d *DetailedImage) ParsedTechniques() []Technique {
+	if len(d.Techniques) == 0 {
+		return nil
+	}
+	techniques := make([]Technique, len(d.Techniques))
+	for i, raw := range d.Techniques {
+		techniques[i], _ = ParseTechnique(raw)
+	}
+	return techniques
+}
+
+// ParsedTools returns Tools as constants where recognized
+func (d *DetailedImage) ParsedTools() []Tool {
+	if len(d.Tools) == 0 {
+		return nil
+	}
+	tools := make([]Tool, len(d.Tools))
+	for i, raw := range d.Tools {
+		tools[i], _ = ParseTool(raw)
+	}
+	return tools
+}
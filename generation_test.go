@@ -0,0 +1,301 @@
+/*
+Copyright (c) 2025 Regi Ellis
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package civitai
+
+import "testing"
+
+func TestParseA1111Parameters(t *testing.T) {
+	t.Run("Full parameters block", func(t *testing.T) {
+		raw := "masterpiece, best quality, 1girl, <lora:detail_tweaker:0.6>\n" +
+			"looking at viewer\n" +
+			"Negative prompt: (worst quality, low quality:1.4), easynegative\n" +
+			"Steps: 28, Sampler: DPM++ 2M Karras, CFG scale: 7.5, Seed: 3456789012, Size: 512x768, " +
+			"Model hash: 7f96a1a9ca, Model: anything-v5, Denoising strength: 0.45, Clip skip: 2, " +
+			`Lora hashes: "detail_tweaker: 3d3f7ca5a7d9, add_more: 1a2b3c", Version: v1.6.0`
+
+		params, err := ParseA1111Parameters(raw)
+		if err != nil {
+			t.Fatalf("ParseA1111Parameters failed: %v", err)
+		}
+
+		expectedPrompt := "masterpiece, best quality, 1girl, <lora:detail_tweaker:0.6>\nlooking at viewer"
+		if params.Prompt != expectedPrompt {
+			t.Errorf("Expected prompt %q, got %q", expectedPrompt, params.Prompt)
+		}
+		if params.NegativePrompt != "(worst quality, low quality:1.4), easynegative" {
+			t.Errorf("Unexpected negative prompt %q", params.NegativePrompt)
+		}
+		if params.Steps != 28 {
+			t.Errorf("Expected 28 steps, got %d", params.Steps)
+		}
+		if params.Sampler != "DPM++ 2M Karras" {
+			t.Errorf("Expected sampler 'DPM++ 2M Karras', got %q", params.Sampler)
+		}
+		if params.CFGScale != 7.5 {
+			t.Errorf("Expected CFG scale 7.5, got %v", params.CFGScale)
+		}
+		if params.Seed != 3456789012 {
+			t.Errorf("Expected seed 3456789012, got %d", params.Seed)
+		}
+		if params.Size != "512x768" || params.Width != 512 || params.Height != 768 {
+			t.Errorf("Expected size 512x768, got %q (%dx%d)", params.Size, params.Width, params.Height)
+		}
+		if params.ModelHash != "7f96a1a9ca" || params.Model != "anything-v5" {
+			t.Errorf("Unexpected model %q / hash %q", params.Model, params.ModelHash)
+		}
+		if params.Extra["Clip skip"] != "2" {
+			t.Errorf("Expected Clip skip 2, got %q", params.Extra["Clip skip"])
+		}
+		if params.Extra["Lora hashes"] != "detail_tweaker: 3d3f7ca5a7d9, add_more: 1a2b3c" {
+			t.Errorf("Expected quoted Lora hashes to be unquoted, got %q", params.Extra["Lora hashes"])
+		}
+		if params.Extra["Version"] != "v1.6.0" {
+			t.Errorf("Expected Version v1.6.0, got %q", params.Extra["Version"])
+		}
+	})
+
+	t.Run("Multi-line negative prompt", func(t *testing.T) {
+		raw := "a cat\r\nNegative prompt: dog,\r\nblurry\r\nSteps: 20, Sampler: Euler a, CFG scale: 7, Seed: 1, Size: 1024x1024"
+
+		params, err := ParseA1111Parameters(raw)
+		if err != nil {
+			t.Fatalf("ParseA1111Parameters failed: %v", err)
+		}
+		if params.Prompt != "a cat" {
+			t.Errorf("Expected prompt 'a cat', got %q", params.Prompt)
+		}
+		if params.NegativePrompt != "dog,\nblurry" {
+			t.Errorf("Expected multi-line negative prompt, got %q", params.NegativePrompt)
+		}
+		if params.Sampler != "Euler a" || params.Width != 1024 {
+			t.Errorf("Unexpected settings: %+v", params)
+		}
+	})
+
+	t.Run("No negative prompt", func(t *testing.T) {
+		params, err := ParseA1111Parameters("sunset over the sea\nSteps: 30, Sampler: DDIM, CFG scale: 6, Seed: 42, Size: 768x512")
+		if err != nil {
+			t.Fatalf("ParseA1111Parameters failed: %v", err)
+		}
+		if params.Prompt != "sunset over the sea" || params.NegativePrompt != "" {
+			t.Errorf("Unexpected prompts %q / %q", params.Prompt, params.NegativePrompt)
+		}
+		if params.Steps != 30 || params.Seed != 42 {
+			t.Errorf("Unexpected settings: %+v", params)
+		}
+	})
+
+	t.Run("Prompt only", func(t *testing.T) {
+		params, err := ParseA1111Parameters("just a prompt")
+		if err != nil {
+			t.Fatalf("ParseA1111Parameters failed: %v", err)
+		}
+		if params.Prompt != "just a prompt" || params.Steps != 0 {
+			t.Errorf("Unexpected params: %+v", params)
+		}
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		for _, raw := range []string{
+			"",
+			"   \n  ",
+			"prompt\nSteps: 20, Seed: abc",
+			"prompt\nSteps: 20, Size: large",
+		} {
+			if _, err := ParseA1111Parameters(raw); err == nil {
+				t.Errorf("Expected error for %q", raw)
+			}
+		}
+	})
+}
+
+func TestParseGenerationEnums(t *testing.T) {
+	t.Run("Generation processes", func(t *testing.T) {
+		tests := map[string]GenerationProcess{
+			"txt2img":       GenerationProcessTxt2Img,
+			"Text to Image": GenerationProcessTxt2Img,
+			"txt2imgHiRes":  GenerationProcessTxt2ImgHiRes,
+			"IMG2IMG":       GenerationProcessImg2Img,
+			"inpaint":       GenerationProcessInpainting,
+			" inpainting ":  GenerationProcessInpainting,
+		}
+		for raw, expected := range tests {
+			got, ok := ParseGenerationProcess(raw)
+			if !ok || got != expected {
+				t.Errorf("Expected %q to parse as %s, got %s (ok=%v)", raw, expected, got, ok)
+			}
+		}
+	})
+
+	t.Run("Techniques", func(t *testing.T) {
+		tests := map[string]Technique{
+			"txt2img":    TechniqueTxt2Img,
+			"img2img":    TechniqueImg2Img,
+			"Inpainting": TechniqueInpainting,
+			"ControlNet": TechniqueControlNet,
+			"workflow":   TechniqueWorkflow,
+			"txt2vid":    TechniqueTxt2Vid,
+			"img2vid":    TechniqueImg2Vid,
+			"vid2vid":    TechniqueVid2Vid,
+		}
+		for raw, expected := range tests {
+			got, ok := ParseTechnique(raw)
+			if !ok || got != expected {
+				t.Errorf("Expected %q to parse as %s, got %s (ok=%v)", raw, expected, got, ok)
+			}
+		}
+	})
+
+	t.Run("Tools", func(t *testing.T) {
+		tests := map[string]Tool{
+			"ComfyUI":         ToolComfyUI,
+			"comfy ui":        ToolComfyUI,
+			"A1111":           ToolAutomatic1111,
+			"AUTOMATIC1111":   ToolAutomatic1111,
+			"Forge":           ToolForge,
+			"Fooocus":         ToolFooocus,
+			"Invoke AI":       ToolInvokeAI,
+			"sd.next":         ToolSDNext,
+			"Adobe Photoshop": ToolPhotoshop,
+		}
+		for raw, expected := range tests {
+			got, ok := ParseTool(raw)
+			if !ok || got != expected {
+				t.Errorf("Expected %q to parse as %s, got %s (ok=%v)", raw, expected, got, ok)
+			}
+		}
+	})
+
+	t.Run("Unknown values are preserved", func(t *testing.T) {
+		if got, ok := ParseGenerationProcess(" upscale "); ok || got != "upscale" {
+			t.Errorf("Expected unrecognized upscale, got %s (ok=%v)", got, ok)
+		}
+		if got, ok := ParseTechnique("outpainting"); ok || got != "outpainting" {
+			t.Errorf("Expected unrecognized outpainting, got %s (ok=%v)", got, ok)
+		}
+		if got, ok := ParseTool("Krita AI"); ok || got != "Krita AI" {
+			t.Errorf("Expected unrecognized Krita AI, got %s (ok=%v)", got, ok)
+		}
+	})
+
+	t.Run("DetailedImage helpers", func(t *testing.T) {
+		image := DetailedImage{
+			GenerationProcess: "Img2Img",
+			Techniques:        []string{"inpainting", "some new technique"},
+			Tools:             []string{"comfyui", "Krita"},
+		}
+
+		if got := image.ParsedGenerationProcess(); got != GenerationProcessImg2Img {
+			t.Errorf("Expected %s, got %s", GenerationProcessImg2Img, got)
+		}
+		techniques := image.ParsedTechniques()
+		if len(techniques) != 2 || techniques[0] != TechniqueInpainting || techniques[1] != "some new technique" {
+			t.Errorf("Expected [inpainting, some new technique], got %v", techniques)
+		}
+		tools := image.ParsedTools()
+		if len(tools) != 2 || tools[0] != ToolComfyUI || tools[1] != "Krita" {
+			t.Errorf("Expected [ComfyUI, Krita], got %v", tools)
+		}
+		if image.GenerationProcess != "Img2Img" || image.Tools[0] != "comfyui" {
+			t.Error("Expected raw fields to be unchanged")
+		}
+
+		var empty DetailedImage
+		if empty.ParsedGenerationProcess() != "" || empty.ParsedTechniques() != nil || empty.ParsedTools() != nil {
+			t.Error("Expected empty results for an image without generation data")
+		}
+	})
+}
+
+func TestDetailedImageResponsePrompt(t *testing.T) {
+	tests := []struct {
+		name     string
+		meta     map[string]interface{}
+		positive string
+		negative string
+	}{
+		{
+			name:     "Camel case keys",
+			meta:     map[string]interface{}{"prompt": " a cat ", "negativePrompt": "blurry"},
+			positive: "a cat",
+			negative: "blurry",
+		},
+		{
+			name:     "Capitalized keys",
+			meta:     map[string]interface{}{"Prompt": "a dog", "Negative prompt": "lowres"},
+			positive: "a dog",
+			negative: "lowres",
+		},
+		{
+			name:     "Snake case negative",
+			meta:     map[string]interface{}{"prompt": "a fox", "negative_prompt": "jpeg artifacts"},
+			positive: "a fox",
+			negative: "jpeg artifacts",
+		},
+		{
+			name: "A1111 parameters block",
+			meta: map[string]interface{}{
+				"parameters": "a castle\nNegative prompt: fog\nSteps: 20, Sampler: Euler a, CFG scale: 7, Seed: 1",
+			},
+			positive: "a castle",
+			negative: "fog",
+		},
+		{
+			name: "Dedicated key wins over parameters",
+			meta: map[string]interface{}{
+				"prompt":     "explicit prompt",
+				"parameters": "parsed prompt\nNegative prompt: parsed negative\nSteps: 20",
+			},
+			positive: "explicit prompt",
+			negative: "parsed negative",
+		},
+		{
+			name:     "Non-string values ignored",
+			meta:     map[string]interface{}{"prompt": 42, "negativePrompt": nil},
+			positive: "",
+			negative: "",
+		},
+		{
+			name:     "Positive only",
+			meta:     map[string]interface{}{"prompt": "a tree", "seed": 1.0},
+			positive: "a tree",
+			negative: "",
+		},
+		{
+			name: "Nil meta",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			image := DetailedImageResponse{Meta: tt.meta}
+			positive, negative := image.Prompt()
+			if positive != tt.positive {
+				t.Errorf("Expected positive %q, got %q", tt.positive, positive)
+			}
+			if negative != tt.negative {
+				t.Errorf("Expected negative %q, got %q", tt.negative, negative)
+			}
+		})
+	}
+}
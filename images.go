@@ -61,6 +61,53 @@ SOFTWARE.
 //		ModelVersionID: 11111,           // Images from specific model version
 //	}
 //
+// # Cursor Pagination
+//
+// Walk through large result sets by passing each page's NextCursor back:
+//
+//	params := civitai.ImageParams{Limit: 100}
+//	for {
+//		images, metadata, err := client.GetImages(ctx, params)
+//		if err != nil {
+//			break
+//		}
+//		// process images...
+//		if metadata == nil || metadata.NextCursor == "" {
+//			break
+//		}
+//		params.Cursor = metadata.NextCursor
+//	}
+//
+// # Layout Helpers
+//
+// Group images by orientation for gallery layouts:
+//
+//	for _, image := range images {
+//		switch image.Orientation() {
+//		case civitai.OrientationPortrait:
+//			// tall tile
+//		case civitai.OrientationLandscape:
+//			// wide tile, image.AspectRatio() gives the exact ratio
+//		}
+//	}
+//
+// # Thumbnails
+//
+// Request a resized, optimized rendition instead of the full-resolution file:
+//
+//	for _, image := range images {
+//		thumb := image.ThumbnailURL(320) // https://image.civitai.com/.../width=320,optimized=true/123.jpeg
+//	}
+//
+// # Version Galleries
+//
+// Fetch example images for a model version (safe-for-work by default):
+//
+//	images, err := client.GetVersionImages(ctx, versionID, 12)
+//
+//	// Include mature content
+//	images, err = client.GetVersionImages(ctx, versionID, 12, civitai.NSFWLevelMature)
+//
 // # Pagination
 //
 // Images support both cursor and page-based pagination:
@@ -87,18 +134,43 @@ package civitai
 import (
 	"context"
 	"fmt"
+	"math"
+	"net/url"
 	"strconv"
+	"strings"
 )
 
+// imageCDNHost serves CivitAI images; its URLs carry resizing options in a path segment
+const imageCDNHost = "image.civitai.com"
+
+// Image orientations returned by Orientation
+const (
+	OrientationPortrait  = "portrait"
+	OrientationLandscape = "landscape"
+	OrientationSquare    = "square"
+)
+
+// squareAspectTolerance treats images within 1% of a 1:1 ratio as square
+const squareAspectTolerance = 0.01
+
 // GetImages retrieves a list of images from the CivitAI API
 // GET /api/v1/images
 func (c *Client) GetImages(ctx context.Context, params ImageParams) ([]DetailedImageResponse, *Metadata, error) {
+	if params.Period == "" {
+		params.Period = c.defaultPeriod
+	}
+	return c.getImages(ctx, params)
+}
+
+// getImages runs an image search without WithDefaultPeriod, for lookups whose
+// results must not depend on the client's browsing defaults
+func (c *Client) getImages(ctx context.Context, params ImageParams) ([]DetailedImageResponse, *Metadata, error) {
 	if err := c.validateImageParams(params); err != nil {
 		return nil, nil, fmt.Errorf("invalid image parameters: %w", err)
 	}
 
 	queryParams := c.buildImageParams(params)
-	url := c.addQueryParams(c.buildURL("images"), queryParams)
+	url := c.addQueryParams(c.endpointURL(EndpointImages), queryParams)
 
 	resp, err := c.doRequest(ctx, "GET", url, nil)
 	if err != nil {
@@ -117,12 +189,32 @@ func (c *Client) GetImages(ctx context.Context, params ImageParams) ([]DetailedI
 	return apiResp.Items, apiResp.Metadata, nil
 }
 
+// GetVersionImages returns example images for a model version, suitable for a
+// version gallery. Images default to NSFWLevelNone; pass a level to override it.
+func (c *Client) GetVersionImages(ctx context.Context, versionID int, limit int, nsfw ...NSFWLevel) ([]DetailedImageResponse, error) {
+	if err := validateVersionID(versionID); err != nil {
+		return nil, fmt.Errorf("invalid version ID: %w", err)
+	}
+
+	level := NSFWLevelNone
+	if len(nsfw) > 0 {
+		level = nsfw[0]
+	}
+
+	images, _, err := c.getImages(ctx, ImageParams{
+		ModelVersionID: versionID,
+		NSFW:           string(level),
+		Limit:          limit,
+	})
+	return images, err
+}
+
 // buildImageParams converts ImageParams to query parameters
 func (c *Client) buildImageParams(params ImageParams) map[string]string {
 	queryParams := make(map[string]string)
 
-	if params.Limit > 0 {
-		queryParams["limit"] = strconv.Itoa(params.Limit)
+	if limit := c.effectiveLimit(params.Limit); limit > 0 {
+		queryParams["limit"] = strconv.Itoa(limit)
 	}
 	if params.PostID > 0 {
 		queryParams["postId"] = strconv.Itoa(params.PostID)
@@ -136,18 +228,113 @@ func (c *Client) buildImageParams(params ImageParams) map[string]string {
 	if params.Username != "" {
 		queryParams["username"] = params.Username
 	}
-	if params.NSFW != "" {
-		queryParams["nsfw"] = params.NSFW
+	if nsfw, _ := imageNSFWValue(params.NSFW); nsfw != "" {
+		queryParams["nsfw"] = nsfw
 	}
-	if params.Sort != "" {
-		queryParams["sort"] = params.Sort
+	if sort, _ := imageSortValue(params.Sort); sort != "" {
+		queryParams["sort"] = sort
 	}
 	if params.Period != "" {
 		queryParams["period"] = string(params.Period)
 	}
-	if params.Page > 0 {
+	if params.Page > 0 && params.Cursor == "" {
 		queryParams["page"] = strconv.Itoa(params.Page)
 	}
+	if params.Cursor != "" {
+		queryParams["cursor"] = params.Cursor
+	}
 
 	return queryParams
 }
+
+// AspectRatio returns width divided by height, or 0 when a dimension is unknown
+func (r *DetailedImageResponse) AspectRatio() float64 {
+	return aspectRatio(r.Width, r.Height)
+}
+
+// Orientation returns OrientationPortrait, OrientationLandscape, or
+// OrientationSquare, or an empty string when a dimension is unknown
+func (r *DetailedImageResponse) Orientation() string {
+	return orientation(r.Width, r.Height)
+}
+
+// AspectRatio returns width divided by height, or 0 when a dimension is unknown
+func (i *Image) AspectRatio() float64 {
+	return aspectRatio(i.Width, i.Height)
+}
+
+// Orientation returns OrientationPortrait, OrientationLandscape, or
+// OrientationSquare, or an empty string when a dimension is unknown
+func (i *Image) Orientation() string {
+	return orientation(i.Width, i.Height)
+}
+
+// ThumbnailURL returns the image URL rewritten to request an optimized
+// rendition width pixels wide. CivitAI image URLs have the form
+// https://image.civitai.com/{key}/{uuid}/{options}/{name}, where options is
+// a comma-separated list such as "width=450" or "original=true"; the sizing
+// options are replaced and others, like "anim=false", are kept. URLs that
+// don't follow this pattern, and non-positive widths, return the URL unchanged.
+func (i *Image) ThumbnailURL(width int) string {
+	return thumbnailURL(i.URL, width)
+}
+
+// thumbnailURL rewrites the options segment of a CivitAI image URL
+func thumbnailURL(rawURL string, width int) string {
+	if width <= 0 {
+		return rawURL
+	}
+
+	parsed, err := url.Parse(rawURL)
+	if err != nil || !strings.EqualFold(parsed.Host, imageCDNHost) {
+		return rawURL
+	}
+
+	segments := strings.Split(strings.TrimPrefix(parsed.Path, "/"), "/")
+	var kept []string
+	switch {
+	case len(segments) == 4 && strings.Contains(segments[2], "="):
+		for _, option := range strings.Split(segments[2], ",") {
+			switch name, _, _ := strings.Cut(option, "="); name {
+			case "width", "height", "original", "optimized", "":
+			default:
+				kept = append(kept, option)
+			}
+		}
+		segments = append(segments[:2], segments[3])
+	case len(segments) == 3 && !strings.Contains(segments[2], "="):
+	default:
+		return rawURL
+	}
+	if segments[0] == "" || segments[1] == "" || segments[2] == "" {
+		return rawURL
+	}
+
+	options := strings.Join(append(kept, "width="+strconv.Itoa(width), "optimized=true"), ",")
+	parsed.Path = "/" + strings.Join([]string{segments[0], segments[1], options, segments[2]}, "/")
+	parsed.RawPath = ""
+	return parsed.String()
+}
+
+// aspectRatio computes width/height, guarding against missing dimensions
+func aspectRatio(width, height int) float64 {
+	if width <= 0 || height <= 0 {
+		return 0
+	}
+	return float64(width) / float64(height)
+}
+
+// orientation classifies dimensions, treating near 1:1 ratios as square
+func orientation(width, height int) string {
+	ratio := aspectRatio(width, height)
+	switch {
+	case ratio == 0:
+		return ""
+	case math.Abs(ratio-1) <= squareAspectTolerance:
+		return OrientationSquare
+	case ratio > 1:
+		return OrientationLandscape
+	default:
+		return OrientationPortrait
+	}
+}
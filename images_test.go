@@ -0,0 +1,159 @@
+/*
+Copyright (c) 2025 Regi Ellis
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package civitai
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestImageDimensions(t *testing.T) {
+	tests := []struct {
+		name                string
+		width, height       int
+		expectedRatio       float64
+		expectedOrientation string
+	}{
+		{"Portrait", 512, 768, 512.0 / 768.0, OrientationPortrait},
+		{"Landscape", 1216, 832, 1216.0 / 832.0, OrientationLandscape},
+		{"Square", 1024, 1024, 1, OrientationSquare},
+		{"Nearly square", 1024, 1020, 1024.0 / 1020.0, OrientationSquare},
+		{"Zero width", 0, 768, 0, ""},
+		{"Zero height", 512, 0, 0, ""},
+		{"Negative dimension", -1, 512, 0, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			detailed := &DetailedImageResponse{Width: tt.width, Height: tt.height}
+			image := &Image{Width: tt.width, Height: tt.height}
+
+			for _, got := range []struct {
+				ratio       float64
+				orientation string
+			}{
+				{detailed.AspectRatio(), detailed.Orientation()},
+				{image.AspectRatio(), image.Orientation()},
+			} {
+				if math.Abs(got.ratio-tt.expectedRatio) > 1e-9 {
+					t.Errorf("Expected aspect ratio %v, got %v", tt.expectedRatio, got.ratio)
+				}
+				if got.orientation != tt.expectedOrientation {
+					t.Errorf("Expected orientation %q, got %q", tt.expectedOrientation, got.orientation)
+				}
+			}
+		})
+	}
+}
+
+func TestImageThumbnailURL(t *testing.T) {
+	const base = "https://image.civitai.com/xG1nkqKTMzGDvpLrqFT7WA/3f8c2a1e-1b2c-4d5e-8f90-123456789abc"
+
+	tests := []struct {
+		name     string
+		url      string
+		width    int
+		expected string
+	}{
+		{"Width replaced", base + "/width=1024/12345.jpeg", 320, base + "/width=320,optimized=true/12345.jpeg"},
+		{"Original replaced", base + "/original=true/12345.jpeg", 450, base + "/width=450,optimized=true/12345.jpeg"},
+		{"Other options kept", base + "/anim=false,width=1024,optimized=true/12345.jpeg", 200, base + "/anim=false,width=200,optimized=true/12345.jpeg"},
+		{"Missing options segment", base + "/12345.jpeg", 320, base + "/width=320,optimized=true/12345.jpeg"},
+		{"Query preserved", base + "/width=1024/12345.jpeg?token=abc", 320, base + "/width=320,optimized=true/12345.jpeg?token=abc"},
+		{"Zero width", base + "/width=1024/12345.jpeg", 0, base + "/width=1024/12345.jpeg"},
+		{"Other host", "https://example.com/a/b/width=1024/1.jpeg", 320, "https://example.com/a/b/width=1024/1.jpeg"},
+		{"Unexpected path", "https://image.civitai.com/1.jpeg", 320, "https://image.civitai.com/1.jpeg"},
+		{"Too many segments", base + "/width=1024/extra/12345.jpeg", 320, base + "/width=1024/extra/12345.jpeg"},
+		{"Empty", "", 320, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			image := &Image{URL: tt.url}
+			if got := image.ThumbnailURL(tt.width); got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestGetImagesCursorPagination(t *testing.T) {
+	var cursors []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cursor := r.URL.Query().Get("cursor")
+		cursors = append(cursors, cursor)
+		if r.URL.Query().Get("page") != "" {
+			t.Errorf("Expected no page param, got %q", r.URL.Query().Get("page"))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		switch cursor {
+		case "":
+			fmt.Fprint(w, `{"items": [{"id": 1}, {"id": 2}], "metadata": {"nextCursor": "c2"}}`)
+		case "c2":
+			fmt.Fprint(w, `{"items": [{"id": 3}], "metadata": {}}`)
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	client := NewClientWithoutAuth(WithBaseURL(server.URL))
+
+	t.Run("Follows next cursor", func(t *testing.T) {
+		var ids []int
+		params := ImageParams{Limit: 2}
+		for {
+			images, metadata, err := client.GetImages(ctx, params)
+			if err != nil {
+				t.Fatalf("GetImages failed: %v", err)
+			}
+			for _, image := range images {
+				ids = append(ids, image.ID)
+			}
+			if metadata == nil || metadata.NextCursor == "" {
+				break
+			}
+			params.Cursor = metadata.NextCursor
+		}
+
+		if len(ids) != 3 || ids[2] != 3 {
+			t.Errorf("Expected images [1 2 3], got %v", ids)
+		}
+		if len(cursors) != 2 || cursors[1] != "c2" {
+			t.Errorf("Expected cursors [\"\" c2], got %q", cursors)
+		}
+	})
+
+	t.Run("Strict rejects cursor with page", func(t *testing.T) {
+		strict := NewClientWithoutAuth(WithBaseURL(server.URL), WithStrictValidation())
+		if _, _, err := strict.GetImages(ctx, ImageParams{Cursor: "c2", Page: 2}); err == nil {
+			t.Error("Expected error when combining cursor and page")
+		}
+	})
+}
@@ -0,0 +1,320 @@
+/*
+Copyright (c) 2025 Regi Ellis
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+// Package civitai - Pagination Iterators
+//
+// This file provides iterators that follow cursor-based pagination
+// automatically, so callers can range over every result of a search
+// without managing cursors themselves.
+//
+// # Iterating Models
+//
+//	it := client.ModelsIterator(ctx, civitai.SearchParams{Tag: "anime", Limit: 100})
+//	for it.Next() {
+//		model := it.Model()
+//		fmt.Printf("%s (%.0f%%)\n", model.Name, it.Progress()*100)
+//	}
+//	if err := it.Err(); err != nil {
+//		log.Fatal(err)
+//	}
+//
+// # Iterating Images
+//
+//	it := client.ImagesIterator(ctx, civitai.ImageParams{Username: "artist", Limit: 100})
+//	for it.Next() {
+//		image := it.Image()
+//		fmt.Println(image.URL)
+//	}
+//	if err := it.Err(); err != nil {
+//		log.Fatal(err)
+//	}
+//
+// # Iterating Creators and Tags
+//
+//	creators := client.CreatorsIterator(ctx, civitai.CreatorParams{Limit: 100})
+//	for creators.Next() {
+//		fmt.Println(creators.Creator().Username)
+//	}
+//
+//	tags := client.TagsIterator(ctx, civitai.TagParams{Query: "style", Limit: 100})
+//	for tags.Next() {
+//		fmt.Println(tags.Tag().Name)
+//	}
+//
+// Each page request goes through the client's retry policy, which matters for
+// the slower creators and tags endpoints.
+//
+// # Stale Cursors
+//
+// The API occasionally rejects a cursor part way through a long scan. With
+// WithAutoCursorRecovery the iterators fall back to requesting the next page
+// by number and carry on, logging a warning; because pages can shift during a
+// scan, recovered scans may skip or repeat a few items. Scans started from a
+// caller-supplied Cursor can't be mapped to a page number, so they are not
+// recovered and stop with the error.
+//
+// # Progress
+//
+// Progress divides the items seen so far by Metadata.TotalItems. Many cursor
+// responses omit the total, in which case Progress returns -1 so UIs can
+// show an indeterminate progress bar.
+//
+// Clients created with WithProgressReporter also receive a ProgressEvent
+// after each page is fetched, with the number of items fetched so far.
+
+package civitai
+
+import (
+	"context"
+	"net/http"
+)
+
+// pageIterator holds the cursor-following logic shared by the typed iterators.
+// fetchPage loads the page at cursor, or when cursor is empty at page number
+// page (0 for the API default).
+type pageIterator[T any] struct {
+	client    *Client
+	ctx       context.Context
+	operation string
+	fetchPage func(cursor string, page int) ([]T, *Metadata, error)
+	cursor    string
+	pageNum   int
+	startPage int
+	noRecover bool // started at a caller-supplied cursor, which has no page number
+	pageMode  bool
+	pages     int
+	page      []T
+	index     int
+	current   *T
+	meta      *Metadata
+	seen      int
+	fetched   int
+	done      bool
+	err       error
+}
+
+// Next advances to the next item, fetching the next page when needed.
+// It returns false when results are exhausted or an error occurs.
+func (it *pageIterator[T]) Next() bool {
+	if it.err != nil {
+		return false
+	}
+
+	for it.index >= len(it.page) {
+		if it.done {
+			return false
+		}
+		if err := it.ctx.Err(); err != nil {
+			it.err = err
+			return false
+		}
+		if err := it.fetch(); err != nil {
+			it.err = err
+			return false
+		}
+	}
+
+	it.current = &it.page[it.index]
+	it.index++
+	it.seen++
+	return true
+}
+
+// newPageIterator starts an iterator at the given cursor or page
+func newPageIterator[T any](ctx context.Context, c *Client, operation, cursor string, page int, fetchPage func(cursor string, page int) ([]T, *Metadata, error)) pageIterator[T] {
+	return pageIterator[T]{
+		client:    c,
+		ctx:       ctx,
+		operation: operation,
+		fetchPage: fetchPage,
+		cursor:    cursor,
+		pageNum:   page,
+		startPage: max(page, 1),
+		noRecover: cursor != "",
+	}
+}
+
+// fetch loads the next page and advances the cursor
+func (it *pageIterator[T]) fetch() error {
+	items, meta, err := it.fetchPage(it.cursor, it.pageNum)
+	if err != nil && it.cursor != "" && !it.noRecover && it.client.autoCursorRecovery && isCursorRejection(err) {
+		// Resume at the page the cursor pointed to
+		page := it.startPage + it.pages
+		it.client.warningLogger().Printf("civitai: warning: %s cursor rejected after %d pages (%v); resuming from page %d, results may skip or repeat items",
+			it.operation, it.pages, err, page)
+		it.cursor, it.pageNum, it.pageMode = "", page, true
+		items, meta, err = it.fetchPage("", page)
+	}
+	if err != nil {
+		return err
+	}
+
+	it.pages++
+	it.page = items
+	it.index = 0
+	it.fetched += len(items)
+	if meta != nil {
+		it.meta = meta
+	}
+
+	if it.client.progressReporter != nil {
+		total := -1
+		if it.meta != nil && it.meta.TotalItems > 0 {
+			total = it.meta.TotalItems
+		}
+		it.client.reportProgress(it.operation, it.fetched, total)
+	}
+
+	// Stop on empty pages or when there is no cursor (or, after recovery,
+	// no further page) to follow
+	switch {
+	case len(items) == 0 || meta == nil:
+		it.done = true
+	case meta.NextCursor != "":
+		it.cursor, it.pageNum, it.pageMode = meta.NextCursor, 0, false
+	case it.pageMode && (meta.NextPage != "" || meta.CurrentPage < meta.TotalPages):
+		it.pageNum++
+	default:
+		it.done = true
+	}
+
+	return nil
+}
+
+// isCursorRejection reports whether err looks like the API refusing a cursor:
+// a client error other than authentication or rate limiting
+func isCursorRejection(err error) bool {
+	status := responseStatus(err)
+	return status >= 400 && status < 500 &&
+		status != http.StatusUnauthorized &&
+		status != http.StatusForbidden &&
+		status != http.StatusTooManyRequests
+}
+
+// Err returns the error that stopped iteration, if any
+func (it *pageIterator[T]) Err() error {
+	return it.err
+}
+
+// Metadata returns the metadata of the most recently fetched page
+func (it *pageIterator[T]) Metadata() *Metadata {
+	return it.meta
+}
+
+// Progress returns the fraction of items seen (0-1), or -1 when the API
+// did not report a total
+func (it *pageIterator[T]) Progress() float64 {
+	return scanProgress(it.seen, it.meta)
+}
+
+// ModelIterator iterates over model search results, following cursors automatically
+type ModelIterator struct {
+	pageIterator[Model]
+}
+
+// ModelsIterator returns an iterator over all models matching params.
+// Pages are fetched lazily as Next is called.
+func (c *Client) ModelsIterator(ctx context.Context, params SearchParams) *ModelIterator {
+	return &ModelIterator{newPageIterator(ctx, c, "ModelsIterator", params.Cursor, params.Page,
+		func(cursor string, page int) ([]Model, *Metadata, error) {
+			params.Cursor, params.Page = cursor, page
+			return c.SearchModels(ctx, params)
+		})}
+}
+
+// Model returns the current model. It is only valid after Next returns true.
+func (it *ModelIterator) Model() *Model {
+	return it.current
+}
+
+// ImageIterator iterates over image results, following cursors automatically
+type ImageIterator struct {
+	pageIterator[DetailedImageResponse]
+}
+
+// ImagesIterator returns an iterator over all images matching params.
+// Pages are fetched lazily as Next is called.
+func (c *Client) ImagesIterator(ctx context.Context, params ImageParams) *ImageIterator {
+	return &ImageIterator{newPageIterator(ctx, c, "ImagesIterator", params.Cursor, params.Page,
+		func(cursor string, page int) ([]DetailedImageResponse, *Metadata, error) {
+			params.Cursor, params.Page = cursor, page
+			return c.GetImages(ctx, params)
+		})}
+}
+
+// Image returns the current image. It is only valid after Next returns true.
+func (it *ImageIterator) Image() *DetailedImageResponse {
+	return it.current
+}
+
+// CreatorIterator iterates over creators, following cursors automatically
+type CreatorIterator struct {
+	pageIterator[Creator]
+}
+
+// CreatorsIterator returns an iterator over all creators matching params.
+// Pages are fetched lazily as Next is called.
+func (c *Client) CreatorsIterator(ctx context.Context, params CreatorParams) *CreatorIterator {
+	return &CreatorIterator{newPageIterator(ctx, c, "CreatorsIterator", params.Cursor, params.Page,
+		func(cursor string, page int) ([]Creator, *Metadata, error) {
+			params.Cursor, params.Page = cursor, page
+			return c.GetCreators(ctx, params)
+		})}
+}
+
+// Creator returns the current creator. It is only valid after Next returns true.
+func (it *CreatorIterator) Creator() *Creator {
+	return it.current
+}
+
+// TagIterator iterates over tags, following cursors automatically
+type TagIterator struct {
+	pageIterator[TagResponse]
+}
+
+// TagsIterator returns an iterator over all tags matching params.
+// Pages are fetched lazily as Next is called.
+func (c *Client) TagsIterator(ctx context.Context, params TagParams) *TagIterator {
+	return &TagIterator{newPageIterator(ctx, c, "TagsIterator", params.Cursor, params.Page,
+		func(cursor string, page int) ([]TagResponse, *Metadata, error) {
+			params.Cursor, params.Page = cursor, page
+			return c.GetTags(ctx, params)
+		})}
+}
+
+// Tag returns the current tag. It is only valid after Next returns true.
+func (it *TagIterator) Tag() *TagResponse {
+	return it.current
+}
+
+// scanProgress computes items-seen over the reported total, or -1 when unknown
+func scanProgress(seen int, meta *Metadata) float64 {
+	if meta == nil || meta.TotalItems <= 0 {
+		return -1
+	}
+
+	progress := float64(seen) / float64(meta.TotalItems)
+	if progress > 1 {
+		progress = 1
+	}
+	return progress
+}
@@ -0,0 +1,405 @@
+/*
+Copyright (c) 2025 Regi Ellis
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package civitai
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newPagedServer serves pages of items, linking them with cursors "1", "2", ...
+func newPagedServer(pages [][]string, totalItems int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page := 0
+		if cursor := r.URL.Query().Get("cursor"); cursor != "" {
+			fmt.Sscanf(cursor, "%d", &page)
+		}
+
+		items := ""
+		for i, item := range pages[page] {
+			if i > 0 {
+				items += ","
+			}
+			items += item
+		}
+
+		nextCursor := ""
+		if page+1 < len(pages) {
+			nextCursor = fmt.Sprintf(`, "nextCursor": "%d"`, page+1)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"items": [%s], "metadata": {"totalItems": %d%s}}`, items, totalItems, nextCursor)
+	}))
+}
+
+func TestModelsIterator(t *testing.T) {
+	pages := [][]string{
+		{`{"id": 1, "name": "a"}`, `{"id": 2, "name": "b"}`},
+		{`{"id": 3, "name": "c"}`, `{"id": 4, "name": "d"}`},
+	}
+
+	t.Run("Known total", func(t *testing.T) {
+		server := newPagedServer(pages, 4)
+		defer server.Close()
+
+		client := NewClientWithoutAuth(WithBaseURL(server.URL))
+		it := client.ModelsIterator(context.Background(), SearchParams{Limit: 2})
+
+		var ids []int
+		var progress []float64
+		for it.Next() {
+			ids = append(ids, it.Model().ID)
+			progress = append(progress, it.Progress())
+		}
+		if err := it.Err(); err != nil {
+			t.Fatalf("Iterator failed: %v", err)
+		}
+
+		if len(ids) != 4 || ids[0] != 1 || ids[3] != 4 {
+			t.Errorf("Expected IDs [1 2 3 4], got %v", ids)
+		}
+		expected := []float64{0.25, 0.5, 0.75, 1}
+		for i, p := range expected {
+			if progress[i] != p {
+				t.Errorf("Expected progress %v at item %d, got %v", p, i, progress[i])
+			}
+		}
+	})
+
+	t.Run("Unknown total", func(t *testing.T) {
+		server := newPagedServer(pages, 0)
+		defer server.Close()
+
+		client := NewClientWithoutAuth(WithBaseURL(server.URL))
+		it := client.ModelsIterator(context.Background(), SearchParams{Limit: 2})
+
+		count := 0
+		for it.Next() {
+			count++
+			if it.Progress() != -1 {
+				t.Errorf("Expected progress -1 for unknown total, got %v", it.Progress())
+			}
+		}
+		if count != 4 {
+			t.Errorf("Expected 4 models, got %d", count)
+		}
+	})
+
+	t.Run("Cancelled context", func(t *testing.T) {
+		server := newPagedServer(pages, 4)
+		defer server.Close()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		client := NewClientWithoutAuth(WithBaseURL(server.URL))
+		it := client.ModelsIterator(ctx, SearchParams{Limit: 2})
+		if it.Next() {
+			t.Error("Expected no results with cancelled context")
+		}
+		if it.Err() != context.Canceled {
+			t.Errorf("Expected context.Canceled, got %v", it.Err())
+		}
+	})
+}
+
+func TestImagesIterator(t *testing.T) {
+	pages := [][]string{
+		{`{"id": 1, "url": "a"}`, `{"id": 2, "url": "b"}`},
+		{`{"id": 3, "url": "c"}`},
+		{},
+	}
+
+	t.Run("Follows cursors across pages", func(t *testing.T) {
+		server := newPagedServer(pages, 3)
+		defer server.Close()
+
+		client := NewClientWithoutAuth(WithBaseURL(server.URL))
+		it := client.ImagesIterator(context.Background(), ImageParams{Limit: 2, Page: 1})
+
+		var ids []int
+		for it.Next() {
+			ids = append(ids, it.Image().ID)
+		}
+		if err := it.Err(); err != nil {
+			t.Fatalf("Iterator failed: %v", err)
+		}
+
+		if len(ids) != 3 || ids[0] != 1 || ids[2] != 3 {
+			t.Errorf("Expected IDs [1 2 3], got %v", ids)
+		}
+		if it.Progress() != 1 {
+			t.Errorf("Expected progress 1, got %v", it.Progress())
+		}
+	})
+
+	t.Run("Stops on empty page", func(t *testing.T) {
+		server := newPagedServer([][]string{{}, {`{"id": 1}`}}, 0)
+		defer server.Close()
+
+		client := NewClientWithoutAuth(WithBaseURL(server.URL))
+		it := client.ImagesIterator(context.Background(), ImageParams{Limit: 2})
+		if it.Next() {
+			t.Error("Expected no results after an empty first page")
+		}
+		if err := it.Err(); err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+	})
+
+	t.Run("Cancelled context", func(t *testing.T) {
+		server := newPagedServer(pages, 3)
+		defer server.Close()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		client := NewClientWithoutAuth(WithBaseURL(server.URL))
+		it := client.ImagesIterator(ctx, ImageParams{Limit: 2})
+		if it.Next() {
+			t.Error("Expected no results with cancelled context")
+		}
+		if it.Err() != context.Canceled {
+			t.Errorf("Expected context.Canceled, got %v", it.Err())
+		}
+	})
+}
+
+func TestCreatorsAndTagsIterators(t *testing.T) {
+	t.Run("Creators", func(t *testing.T) {
+		server := newPagedServer([][]string{
+			{`{"username": "alice"}`, `{"username": "bob"}`},
+			{`{"username": "carol"}`},
+		}, 3)
+		defer server.Close()
+
+		client := NewClientWithoutAuth(WithBaseURL(server.URL))
+		it := client.CreatorsIterator(context.Background(), CreatorParams{Limit: 2, Page: 1})
+
+		var names []string
+		for it.Next() {
+			names = append(names, it.Creator().Username)
+		}
+		if err := it.Err(); err != nil {
+			t.Fatalf("Iterator failed: %v", err)
+		}
+		if fmt.Sprint(names) != "[alice bob carol]" {
+			t.Errorf("Expected [alice bob carol], got %v", names)
+		}
+		if it.Progress() != 1 {
+			t.Errorf("Expected progress 1, got %v", it.Progress())
+		}
+	})
+
+	t.Run("Tags", func(t *testing.T) {
+		server := newPagedServer([][]string{
+			{`{"name": "anime"}`},
+			{`{"name": "style"}`},
+			{`{"name": "portrait"}`},
+		}, 0)
+		defer server.Close()
+
+		client := NewClientWithoutAuth(WithBaseURL(server.URL))
+		it := client.TagsIterator(context.Background(), TagParams{Limit: 1})
+
+		var names []string
+		for it.Next() {
+			names = append(names, it.Tag().Name)
+		}
+		if err := it.Err(); err != nil {
+			t.Fatalf("Iterator failed: %v", err)
+		}
+		if fmt.Sprint(names) != "[anime style portrait]" {
+			t.Errorf("Expected [anime style portrait], got %v", names)
+		}
+	})
+
+	t.Run("Retries flaky pages", func(t *testing.T) {
+		var calls int
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			if calls == 2 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			if r.URL.Query().Get("cursor") == "" {
+				fmt.Fprint(w, `{"items": [{"username": "alice"}], "metadata": {"nextCursor": "1"}}`)
+				return
+			}
+			fmt.Fprint(w, `{"items": [{"username": "bob"}], "metadata": {}}`)
+		}))
+		defer server.Close()
+
+		client := NewClientWithoutAuth(WithBaseURL(server.URL), WithRetryConfig(2, time.Millisecond, time.Millisecond))
+		it := client.CreatorsIterator(context.Background(), CreatorParams{})
+
+		count := 0
+		for it.Next() {
+			count++
+		}
+		if err := it.Err(); err != nil {
+			t.Fatalf("Iterator failed: %v", err)
+		}
+		if count != 2 {
+			t.Errorf("Expected 2 creators, got %d", count)
+		}
+	})
+}
+
+func TestAutoCursorRecovery(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case query.Get("cursor") == "2":
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"code": "BAD_REQUEST", "message": "Invalid cursor"}`)
+		case query.Get("cursor") == "1":
+			fmt.Fprint(w, `{"items": [{"id": 3}, {"id": 4}], "metadata": {"nextCursor": "2"}}`)
+		case query.Get("page") == "3":
+			fmt.Fprint(w, `{"items": [{"id": 5}, {"id": 6}], "metadata": {"currentPage": 3, "totalPages": 3}}`)
+		case query.Get("cursor") == "" && query.Get("page") == "":
+			fmt.Fprint(w, `{"items": [{"id": 1}, {"id": 2}], "metadata": {"nextCursor": "1"}}`)
+		default:
+			t.Errorf("Unexpected request %s", r.URL.RawQuery)
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	t.Run("Resumes by page", func(t *testing.T) {
+		logger := &recordingLogger{}
+		client := NewClientWithoutAuth(WithBaseURL(server.URL), WithAutoCursorRecovery(), WithLogger(logger))
+		it := client.ModelsIterator(context.Background(), SearchParams{Limit: 2})
+
+		var ids []int
+		for it.Next() {
+			ids = append(ids, it.Model().ID)
+		}
+		if err := it.Err(); err != nil {
+			t.Fatalf("Iterator failed: %v", err)
+		}
+		if fmt.Sprint(ids) != "[1 2 3 4 5 6]" {
+			t.Errorf("Expected [1 2 3 4 5 6], got %v", ids)
+		}
+
+		warned := false
+		for _, line := range logger.lines {
+			if strings.Contains(line, "cursor rejected") && strings.Contains(line, "page 3") {
+				warned = true
+			}
+		}
+		if !warned {
+			t.Errorf("Expected cursor recovery warning, got lines: %v", logger.lines)
+		}
+	})
+
+	t.Run("Not recovered when started from a cursor", func(t *testing.T) {
+		client := NewClientWithoutAuth(WithBaseURL(server.URL), WithAutoCursorRecovery(), WithLogger(&recordingLogger{}))
+		it := client.ModelsIterator(context.Background(), SearchParams{Limit: 2, Cursor: "1"})
+
+		count := 0
+		for it.Next() {
+			count++
+		}
+		if it.Err() == nil {
+			t.Error("Expected rejected cursor to stop a scan started from a cursor")
+		}
+		if count != 2 {
+			t.Errorf("Expected 2 models before the rejected cursor, got %d", count)
+		}
+	})
+
+	t.Run("Fails without recovery", func(t *testing.T) {
+		client := NewClientWithoutAuth(WithBaseURL(server.URL))
+		it := client.ModelsIterator(context.Background(), SearchParams{Limit: 2})
+
+		count := 0
+		for it.Next() {
+			count++
+		}
+		if it.Err() == nil {
+			t.Error("Expected rejected cursor to stop the scan")
+		}
+		if count != 4 {
+			t.Errorf("Expected 4 models before the rejected cursor, got %d", count)
+		}
+	})
+}
+
+func TestProgressReporter(t *testing.T) {
+	pages := [][]string{
+		{`{"id": 1, "name": "a"}`, `{"id": 2, "name": "b"}`},
+		{`{"id": 3, "name": "c"}`, `{"id": 4, "name": "d"}`},
+		{`{"id": 5, "name": "e"}`},
+	}
+
+	tests := []struct {
+		name          string
+		totalItems    int
+		expectedTotal int
+	}{
+		{"Known total", 5, 5},
+		{"Unknown total", 0, -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newPagedServer(pages, tt.totalItems)
+			defer server.Close()
+
+			var events []ProgressEvent
+			client := NewClientWithoutAuth(WithBaseURL(server.URL), WithProgressReporter(func(event ProgressEvent) {
+				events = append(events, event)
+			}))
+
+			it := client.ModelsIterator(context.Background(), SearchParams{Limit: 2})
+			for it.Next() {
+			}
+			if err := it.Err(); err != nil {
+				t.Fatalf("Iterator failed: %v", err)
+			}
+
+			expected := []ProgressEvent{
+				{Operation: "ModelsIterator", Processed: 2, Total: tt.expectedTotal},
+				{Operation: "ModelsIterator", Processed: 4, Total: tt.expectedTotal},
+				{Operation: "ModelsIterator", Processed: 5, Total: tt.expectedTotal},
+			}
+			if len(events) != len(expected) {
+				t.Fatalf("Expected %d events, got %d: %+v", len(expected), len(events), events)
+			}
+			for i := range expected {
+				if events[i] != expected[i] {
+					t.Errorf("Event %d: expected %+v, got %+v", i, expected[i], events[i])
+				}
+			}
+		})
+	}
+}
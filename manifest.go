@@ -0,0 +1,288 @@
+/*
+Copyright (c) 2025 Regi Ellis
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+// Package civitai - Model Manifests
+//
+// This file implements a compact, versioned JSON manifest for sharing
+// reproducible sets of models. Each entry pins a model to one version and
+// records the primary file's SHA256 hash and file name, along with an AIR
+// identifier so the manifest can be consumed by AIR-aware tools.
+//
+// # Exporting
+//
+//	manifest, err := civitai.ExportManifest(models)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	os.WriteFile("models.json", manifest, 0o644)
+//
+// # Importing
+//
+//	entries, err := civitai.ImportManifest(data)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//
+// # Resolving
+//
+// ResolveManifest fetches the pinned versions concurrently, ready for
+// download. Entries that fail to resolve are nil in the result and reported
+// in the returned error:
+//
+//	versions, err := client.ResolveManifest(ctx, entries)
+//	if err != nil {
+//		log.Printf("some entries did not resolve: %v", err)
+//	}
+//	for _, version := range versions {
+//		if version == nil {
+//			continue
+//		}
+//		if file := version.GetPrimaryFile(); file != nil {
+//			client.DownloadToPath(ctx, *file, filepath.Join(destDir, filepath.Base(file.Name)))
+//		}
+//	}
+//
+// # Schema
+//
+// The manifest is a JSON object with a "version" field (currently 1) and a
+// "models" array. Readers reject manifests with a newer schema version, so
+// fields are only added to the schema alongside a version bump.
+//
+//	{"version":1,"models":[{"modelId":4201,"versionId":130072,
+//	  "sha256":"A1B2...","air":"urn:air:sd1:model:civitai:4201@130072",
+//	  "fileName":"realisticVision.safetensors"}]}
+
+package civitai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// ManifestVersion is the manifest schema version written by ExportManifest
+const ManifestVersion = 1
+
+// manifestResolveConcurrency bounds the concurrent lookups in ResolveManifest
+const manifestResolveConcurrency = 4
+
+// ManifestEntry pins one model version in a manifest
+type ManifestEntry struct {
+	ModelID   int    `json:"modelId"`
+	VersionID int    `json:"versionId,omitempty"`
+	SHA256    string `json:"sha256,omitempty"`
+	AIR       string `json:"air,omitempty"`
+	FileName  string `json:"fileName,omitempty"`
+}
+
+// manifest is the on-disk manifest document
+type manifest struct {
+	Version int             `json:"version"`
+	Models  []ManifestEntry `json:"models"`
+}
+
+// ExportManifest encodes models as a compact JSON manifest. Each model is
+// pinned to its latest version and that version's primary file; models
+// without versions are recorded by model ID alone. Entries keep the order of
+// models.
+func ExportManifest(models []Model) ([]byte, error) {
+	doc := manifest{Version: ManifestVersion, Models: make([]ManifestEntry, 0, len(models))}
+
+	for i := range models {
+		model := &models[i]
+		if model.ID <= 0 {
+			return nil, fmt.Errorf("model at index %d has no ID", i)
+		}
+
+		entry := ManifestEntry{ModelID: model.ID}
+		if latest := model.GetLatestVersion(); latest != nil {
+			entry.VersionID = latest.ID
+			if file := latest.GetPrimaryFile(); file != nil {
+				entry.SHA256 = strings.ToUpper(file.Hashes.SHA256)
+				entry.FileName = file.Name
+			}
+		}
+		if air := ConvertModelToAIR(model, "", entry.VersionID); air != nil {
+			entry.AIR = air.String()
+		}
+
+		doc.Models = append(doc.Models, entry)
+	}
+
+	return json.Marshal(doc)
+}
+
+// ImportManifest decodes a manifest produced by ExportManifest. Model and
+// version IDs missing from an entry are recovered from its AIR, and an entry
+// whose IDs disagree with its AIR is rejected. SHA256 hashes are normalized
+// to upper case.
+func ImportManifest(data []byte) ([]ManifestEntry, error) {
+	var doc manifest
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to decode manifest: %w", err)
+	}
+
+	switch {
+	case doc.Version == 0:
+		return nil, errors.New("manifest has no schema version")
+	case doc.Version > ManifestVersion:
+		return nil, fmt.Errorf("unsupported manifest schema version %d (max %d)", doc.Version, ManifestVersion)
+	}
+
+	entries := make([]ManifestEntry, 0, len(doc.Models))
+	for i, entry := range doc.Models {
+		if entry.AIR != "" {
+			if err := entry.reconcileAIR(); err != nil {
+				return nil, fmt.Errorf("manifest entry %d: %w", i, err)
+			}
+		}
+		if entry.ModelID <= 0 {
+			return nil, fmt.Errorf("manifest entry %d: missing model ID", i)
+		}
+		entry.SHA256 = strings.ToUpper(entry.SHA256)
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// reconcileAIR fills missing IDs from the entry's AIR and checks that the
+// IDs it already has agree with it
+func (e *ManifestEntry) reconcileAIR() error {
+	air, err := ParseAIR(e.AIR)
+	if err != nil {
+		return err
+	}
+
+	modelID, err := air.GetModelID()
+	if err != nil {
+		return err
+	}
+	switch {
+	case e.ModelID == 0:
+		e.ModelID = modelID
+	case e.ModelID != modelID:
+		return fmt.Errorf("model ID %d does not match AIR %s", e.ModelID, e.AIR)
+	}
+
+	if !air.IsVersionSpecific() {
+		return nil
+	}
+	versionID, err := air.GetVersionID()
+	if err != nil {
+		return err
+	}
+	switch {
+	case e.VersionID == 0:
+		e.VersionID = versionID
+	case e.VersionID != versionID:
+		return fmt.Errorf("version ID %d does not match AIR %s", e.VersionID, e.AIR)
+	}
+
+	return nil
+}
+
+// ResolveManifest fetches the model version each entry pins, running at most
+// 4 lookups at once. An entry is resolved by its version ID, then its SHA256
+// hash, then a version-specific AIR, and finally by its model ID, which
+// resolves to the model's latest version. The result has one element per
+// entry in the same order; entries that fail to resolve are nil and reported
+// in the returned error alongside the versions that did resolve.
+func (c *Client) ResolveManifest(ctx context.Context, entries []ManifestEntry) ([]*ModelVersion, error) {
+	versions := make([]*ModelVersion, len(entries))
+	errs := make([]error, len(entries))
+	sem := make(chan struct{}, manifestResolveConcurrency)
+	var (
+		wg        sync.WaitGroup
+		completed int32
+	)
+
+	for i := range entries {
+		wg.Add(1)
+		go func(i int, entry ManifestEntry) {
+			defer wg.Done()
+			if c.progressReporter != nil {
+				defer func() {
+					c.reportProgress("ResolveManifest", int(atomic.AddInt32(&completed, 1)), len(entries))
+				}()
+			}
+
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-ctx.Done():
+				errs[i] = fmt.Errorf("manifest entry %d: %w", i, ctx.Err())
+				return
+			}
+
+			version, err := c.resolveManifestEntry(ctx, entry)
+			if err != nil {
+				errs[i] = fmt.Errorf("manifest entry %d: %w", i, err)
+				return
+			}
+			versions[i] = version
+		}(i, entries[i])
+	}
+
+	wg.Wait()
+
+	if err := errors.Join(errs...); err != nil {
+		return versions, fmt.Errorf("failed to resolve some manifest entries: %w", err)
+	}
+
+	return versions, nil
+}
+
+// resolveManifestEntry fetches the version a single entry pins
+func (c *Client) resolveManifestEntry(ctx context.Context, entry ManifestEntry) (*ModelVersion, error) {
+	switch {
+	case entry.VersionID > 0:
+		return c.GetModelVersion(ctx, entry.VersionID)
+	case entry.SHA256 != "":
+		resp, err := c.GetModelVersionByHash(ctx, entry.SHA256)
+		if err != nil {
+			return nil, err
+		}
+		version := resp.ModelVersion
+		if version.ModelID == 0 {
+			version.ModelID = resp.ModelID
+		}
+		return &version, nil
+	case entry.AIR != "":
+		air, err := ParseAIR(entry.AIR)
+		if err != nil {
+			return nil, err
+		}
+		if air.IsVersionSpecific() {
+			return c.GetModelVersionByAIR(ctx, air)
+		}
+	}
+
+	if entry.ModelID <= 0 {
+		return nil, errors.New("entry has no model ID, version ID, hash, or AIR")
+	}
+	return c.GetLatestVersion(ctx, entry.ModelID)
+}
@@ -0,0 +1,180 @@
+/*
+Copyright (c) 2025 Regi Ellis
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package civitai
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestManifestRoundTrip(t *testing.T) {
+	models := []Model{
+		{
+			ID:   4201,
+			Type: ModelTypeCheckpoint,
+			Tags: []string{"sd 1.5"},
+			ModelVersions: []ModelVersion{
+				{
+					ID: 130072,
+					Files: []File{
+						{Name: "config.yaml"},
+						{Name: "realisticVision.safetensors", Primary: true, Hashes: Hashes{SHA256: "a1b2c3"}},
+					},
+				},
+			},
+		},
+		{ID: 77, Type: ModelTypeLORA},
+	}
+
+	data, err := ExportManifest(models)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !strings.HasPrefix(string(data), `{"version":1,`) {
+		t.Errorf("Expected versioned manifest, got %s", data)
+	}
+
+	entries, err := ImportManifest(data)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 entries, got %d", len(entries))
+	}
+
+	want := ManifestEntry{
+		ModelID:   4201,
+		VersionID: 130072,
+		SHA256:    "A1B2C3",
+		AIR:       "urn:air:sd1:model:civitai:4201@130072",
+		FileName:  "realisticVision.safetensors",
+	}
+	if entries[0] != want {
+		t.Errorf("Expected %+v, got %+v", want, entries[0])
+	}
+	if entries[1].ModelID != 77 || entries[1].VersionID != 0 || entries[1].AIR == "" {
+		t.Errorf("Expected version-less LoRA entry with AIR, got %+v", entries[1])
+	}
+
+	again, err := ExportManifest(models)
+	if err != nil || string(again) != string(data) {
+		t.Errorf("Expected stable output, got %s", again)
+	}
+}
+
+func TestImportManifest(t *testing.T) {
+	t.Run("IDs recovered from AIR", func(t *testing.T) {
+		entries, err := ImportManifest([]byte(`{"version":1,"models":[{"air":"urn:air:sdxl:lora:civitai:5@9","sha256":"ff"}]}`))
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if entries[0].ModelID != 5 || entries[0].VersionID != 9 || entries[0].SHA256 != "FF" {
+			t.Errorf("Expected model 5 version 9 hash FF, got %+v", entries[0])
+		}
+	})
+
+	errorCases := []struct {
+		name string
+		data string
+	}{
+		{"invalid JSON", `{`},
+		{"missing schema version", `{"models":[]}`},
+		{"newer schema version", `{"version":2,"models":[]}`},
+		{"missing model ID", `{"version":1,"models":[{"versionId":3}]}`},
+		{"model ID mismatch", `{"version":1,"models":[{"modelId":6,"air":"urn:air:sdxl:model:civitai:5"}]}`},
+		{"version ID mismatch", `{"version":1,"models":[{"modelId":5,"versionId":8,"air":"urn:air:sdxl:model:civitai:5@9"}]}`},
+		{"invalid AIR", `{"version":1,"models":[{"modelId":5,"air":"not-an-air"}]}`},
+	}
+	for _, tc := range errorCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := ImportManifest([]byte(tc.data)); err == nil {
+				t.Error("Expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestResolveManifest(t *testing.T) {
+	hash := strings.Repeat("AB", 32)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch strings.TrimPrefix(r.URL.Path, "/api/v1") {
+		case "/model-versions/100":
+			w.Write([]byte(`{"id": 100, "modelId": 1, "name": "by id"}`))
+		case "/model-versions/by-hash/" + hash:
+			w.Write([]byte(`{"id": 200, "name": "by hash", "modelId": 2, "model": {"name": "Hashed"}}`))
+		case "/model-versions/300":
+			w.Write([]byte(`{"id": 300, "modelId": 3, "name": "by AIR"}`))
+		case "/models/4/versions":
+			w.Write([]byte(`[{"id": 400, "name": "old", "createdAt": "2024-01-01T00:00:00Z"}, {"id": 401, "name": "latest", "createdAt": "2024-06-01T00:00:00Z"}]`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClientWithoutAuth(WithBaseURL(server.URL), WithRetryConfig(0, 0, 0))
+	entries := []ManifestEntry{
+		{ModelID: 1, VersionID: 100},
+		{SHA256: hash},
+		{AIR: "urn:air:sdxl:lora:civitai:3@300"},
+		{ModelID: 4},
+		{ModelID: 5, VersionID: 500},
+	}
+
+	versions, err := client.ResolveManifest(context.Background(), entries)
+	if err == nil {
+		t.Fatal("Expected error for unresolvable entry, got nil")
+	}
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected error to match ErrNotFound, got %v", err)
+	}
+	if len(versions) != len(entries) {
+		t.Fatalf("Expected %d results, got %d", len(entries), len(versions))
+	}
+
+	wantIDs := []int{100, 200, 300, 401}
+	for i, want := range wantIDs {
+		if versions[i] == nil || versions[i].ID != want {
+			t.Errorf("Expected entry %d to resolve to version %d, got %+v", i, want, versions[i])
+		}
+	}
+	if versions[1] != nil && versions[1].ModelID != 2 {
+		t.Errorf("Expected hash lookup to carry model ID 2, got %d", versions[1].ModelID)
+	}
+	if versions[4] != nil {
+		t.Errorf("Expected failed entry to be nil, got %+v", versions[4])
+	}
+
+	versions, err = client.ResolveManifest(context.Background(), entries[:4])
+	if err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+	if len(versions) != 4 {
+		t.Errorf("Expected 4 results, got %d", len(versions))
+	}
+}
@@ -75,6 +75,11 @@ SOFTWARE.
 //	cleanFiles := version.GetCleanFiles()
 //	fmt.Printf("Found %d verified clean files\n", len(cleanFiles))
 //
+//	// Check just the main download
+//	if !version.IsPrimaryFileSafe() {
+//		fmt.Println("Primary file failed security scans")
+//	}
+//
 // # Version Metadata
 //
 // Extract version information and statistics:
@@ -124,16 +129,36 @@ SOFTWARE.
 //			fmt.Printf("⚠️ %s has scan issues\n", file.Name)
 //		}
 //	}
+//
+// # Fetching Several Versions
+//
+// Hydrate a list of version IDs concurrently; IDs that fail are reported in
+// the error while the rest are still returned:
+//
+//	versions, err := client.GetModelVersionsByIDs(ctx, []int{130072, 128713})
+//	if err != nil {
+//		log.Printf("some versions failed: %v", err)
+//	}
+//	if version, ok := versions[130072]; ok {
+//		fmt.Println(version.Name)
+//	}
 
 package civitai
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"sort"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
+// versionBatchConcurrency bounds the concurrent lookups in GetModelVersionsByIDs
+const versionBatchConcurrency = 4
+
 // VersionFilter provides filtering options for model version collections
 type VersionFilter struct {
 	BaseModels         []BaseModel
@@ -232,9 +257,9 @@ func SortVersions(versions []ModelVersion, newestFirst bool) []ModelVersion {
 
 	sort.Slice(sorted, func(i, j int) bool {
 		if newestFirst {
-			return sorted[i].CreatedAt.After(sorted[j].CreatedAt)
+			return sorted[i].CreatedAt.After(sorted[j].CreatedAt.Time)
 		}
-		return sorted[i].CreatedAt.Before(sorted[j].CreatedAt)
+		return sorted[i].CreatedAt.Before(sorted[j].CreatedAt.Time)
 	})
 
 	return sorted
@@ -282,6 +307,14 @@ func (mv *ModelVersion) GetCleanFiles() []File {
 	return cleanFiles
 }
 
+// IsPrimaryFileSafe reports whether the version's primary file (see
+// GetPrimaryFile) has passed security scans. It returns false when the
+// version has no files.
+func (mv *ModelVersion) IsPrimaryFileSafe() bool {
+	file := mv.GetPrimaryFile()
+	return file != nil && isFileClean(*file)
+}
+
 // isFileClean checks if a file has passed security scans
 func isFileClean(file File) bool {
 	// Check pickle scan result
@@ -297,28 +330,52 @@ func isFileClean(file File) bool {
 	return true
 }
 
-// GetCompatibleBaseModels returns a list of base models this version is compatible with
+// defaultCompatibilityGraph lists base models whose LoRAs and embeddings can be
+// used interchangeably. SD 1.x and SD 2.x use different text encoders, so they
+// are not compatible with each other.
+var defaultCompatibilityGraph = map[BaseModel][]BaseModel{
+	BaseModelSD1_5: {"SD 1.4", "SD 1.5 LCM", "SD 1.5 Hyper"},
+	BaseModelSD2_0: {BaseModelSD2_1},
+	BaseModelSD2_1: {BaseModelSD2_0},
+	BaseModelSDXL:  {"SDXL Turbo", "SDXL Lightning", "SDXL Hyper"},
+}
+
+// DefaultCompatibilityGraph returns a copy of the default base model
+// compatibility graph:
+//   - SD 1.5: SD 1.4, SD 1.5 LCM, SD 1.5 Hyper
+//   - SD 2.0: SD 2.1
+//   - SD 2.1: SD 2.0
+//   - SDXL 1.0: SDXL Turbo, SDXL Lightning, SDXL Hyper
+func DefaultCompatibilityGraph() map[BaseModel][]BaseModel {
+	return copyCompatibilityGraph(defaultCompatibilityGraph)
+}
+
+// copyCompatibilityGraph deep-copies a compatibility graph
+func copyCompatibilityGraph(graph map[BaseModel][]BaseModel) map[BaseModel][]BaseModel {
+	copied := make(map[BaseModel][]BaseModel, len(graph))
+	for baseModel, compatible := range graph {
+		copied[baseModel] = append([]BaseModel(nil), compatible...)
+	}
+	return copied
+}
+
+// GetCompatibleBaseModels returns a list of base models this version is compatible
+// with, using the default compatibility graph. The version's own base model is first.
 func (mv *ModelVersion) GetCompatibleBaseModels() []BaseModel {
+	return mv.CompatibleBaseModelsIn(defaultCompatibilityGraph)
+}
+
+// CompatibleBaseModelsIn returns the version's base model followed by the base
+// models the graph lists for it. Edges are not assumed to be symmetric.
+func (mv *ModelVersion) CompatibleBaseModelsIn(graph map[BaseModel][]BaseModel) []BaseModel {
 	var models []BaseModel
 
-	// Add the primary base model
 	if mv.BaseModel != "" {
 		models = append(models, mv.BaseModel)
 	}
-
-	// For certain model types, add compatible variants
-	switch mv.BaseModel {
-	case BaseModelSD1_5:
-		// SD 1.5 models might work with SD 2.0 with some compatibility
-		models = append(models, BaseModelSD2_0)
-	case BaseModelSDXL:
-		// SDXL is generally standalone
-	case BaseModelSD2_0, BaseModelSD2_1:
-		// SD 2.x models are generally compatible with each other
-		if mv.BaseModel == BaseModelSD2_0 {
-			models = append(models, BaseModelSD2_1)
-		} else {
-			models = append(models, BaseModelSD2_0)
+	for _, compatible := range graph[mv.BaseModel] {
+		if compatible != mv.BaseModel {
+			models = append(models, compatible)
 		}
 	}
 
@@ -355,9 +412,64 @@ func (mv *ModelVersion) GetRecommendedFile() *File {
 	return nil
 }
 
+// SelectFile returns the file best matching the preference, or nil if none match.
+// The primary file is preferred when it matches; otherwise files are checked in order.
+func (mv *ModelVersion) SelectFile(pref FilePreference) *File {
+	primary := mv.GetPrimaryFile()
+	if primary != nil && fileMatchesPreference(*primary, pref) {
+		return primary
+	}
+
+	for i := range mv.Files {
+		if fileMatchesPreference(mv.Files[i], pref) {
+			return &mv.Files[i]
+		}
+	}
+
+	return nil
+}
+
+// fileMatchesPreference checks if a file satisfies every set field of the preference
+func fileMatchesPreference(file File, pref FilePreference) bool {
+	if pref.Format != "" && !strings.EqualFold(string(file.Metadata.Format), string(pref.Format)) {
+		return false
+	}
+	if pref.Precision != "" && !strings.EqualFold(file.Metadata.FP, pref.Precision) {
+		return false
+	}
+	if pref.Size != "" && !strings.EqualFold(file.Metadata.Size, pref.Size) {
+		return false
+	}
+	if pref.CleanOnly && !isFileClean(file) {
+		return false
+	}
+	return true
+}
+
+// String returns a human-readable description of the preference
+func (p FilePreference) String() string {
+	var parts []string
+	if p.Format != "" {
+		parts = append(parts, "format="+string(p.Format))
+	}
+	if p.Precision != "" {
+		parts = append(parts, "precision="+p.Precision)
+	}
+	if p.Size != "" {
+		parts = append(parts, "size="+p.Size)
+	}
+	if p.CleanOnly {
+		parts = append(parts, "clean only")
+	}
+	if len(parts) == 0 {
+		return "any file"
+	}
+	return strings.Join(parts, ", ")
+}
+
 // GetVersionAge returns how long ago the version was created
 func (mv *ModelVersion) GetVersionAge() time.Duration {
-	return time.Since(mv.CreatedAt)
+	return time.Since(mv.CreatedAt.Time)
 }
 
 // GetVersionAgeString returns a human-readable age string
@@ -401,6 +513,45 @@ func (mv *ModelVersion) GetFileStats() map[string]interface{} {
 	return stats
 }
 
+// FileStat is a single key/value entry from GetFileStatsOrdered
+type FileStat struct {
+	Key   string
+	Value interface{}
+}
+
+// FormatCount is the number of files in a given format
+type FormatCount struct {
+	Format FileFormat
+	Count  int
+}
+
+// GetFileStatsOrdered returns the same statistics as GetFileStats as a slice
+// sorted by key. The "format_counts" value is a []FormatCount sorted by format
+// name, so printing the result is stable across runs.
+func (mv *ModelVersion) GetFileStatsOrdered() []FileStat {
+	stats := mv.GetFileStats()
+
+	ordered := make([]FileStat, 0, len(stats))
+	for key, value := range stats {
+		if counts, ok := value.(map[FileFormat]int); ok {
+			formats := make([]FormatCount, 0, len(counts))
+			for format, count := range counts {
+				formats = append(formats, FormatCount{Format: format, Count: count})
+			}
+			sort.Slice(formats, func(i, j int) bool {
+				return formats[i].Format < formats[j].Format
+			})
+			value = formats
+		}
+		ordered = append(ordered, FileStat{Key: key, Value: value})
+	}
+
+	sort.Slice(ordered, func(i, j int) bool {
+		return ordered[i].Key < ordered[j].Key
+	})
+	return ordered
+}
+
 // HasTrainedWords checks if the version has any trained words
 func (mv *ModelVersion) HasTrainedWords() bool {
 	return len(mv.TrainedWords) > 0
@@ -435,3 +586,136 @@ func GroupVersionsByBaseModel(versions []ModelVersion) map[BaseModel][]ModelVers
 
 	return groups
 }
+
+// BaseModelGroup is a base model and its versions from GroupVersionsByBaseModelOrdered
+type BaseModelGroup struct {
+	BaseModel BaseModel
+	Versions  []ModelVersion
+}
+
+// GroupVersionsByBaseModelOrdered groups versions like GroupVersionsByBaseModel
+// but returns the groups sorted by base model name. Versions keep their input
+// order within each group.
+func GroupVersionsByBaseModelOrdered(versions []ModelVersion) []BaseModelGroup {
+	groups := GroupVersionsByBaseModel(versions)
+
+	ordered := make([]BaseModelGroup, 0, len(groups))
+	for baseModel, group := range groups {
+		ordered = append(ordered, BaseModelGroup{BaseModel: baseModel, Versions: group})
+	}
+
+	sort.Slice(ordered, func(i, j int) bool {
+		return ordered[i].BaseModel < ordered[j].BaseModel
+	})
+	return ordered
+}
+
+// SameAs reports whether two versions are the same version at the same revision,
+// comparing by ID and UpdatedAt.
+func (mv *ModelVersion) SameAs(other *ModelVersion) bool {
+	if mv == nil || other == nil {
+		return mv == other
+	}
+	return mv.ID == other.ID && mv.UpdatedAt.Equal(other.UpdatedAt.Time)
+}
+
+// ContentHash returns a SHA256 hex digest over the version's stable fields,
+// including file names and hashes but excluding statistics.
+func (mv *ModelVersion) ContentHash() string {
+	type fileFields struct {
+		Name   string
+		Format FileFormat
+		Hashes Hashes
+	}
+	files := make([]fileFields, len(mv.Files))
+	for i, file := range mv.Files {
+		files[i] = fileFields{Name: file.Name, Format: file.Metadata.Format, Hashes: file.Hashes}
+	}
+
+	return hashStableFields(struct {
+		ID           int
+		ModelID      int
+		Name         string
+		Description  string
+		BaseModel    BaseModel
+		TrainedWords []string
+		Files        []fileFields
+		Availability string
+	}{
+		ID:           mv.ID,
+		ModelID:      mv.ModelID,
+		Name:         mv.Name,
+		Description:  mv.Description,
+		BaseModel:    mv.BaseModel,
+		TrainedWords: mv.TrainedWords,
+		Files:        files,
+		Availability: mv.Availability,
+	})
+}
+
+// GetModelVersionsByIDs fetches several model versions concurrently, running
+// at most 4 requests at once, and returns them keyed by ID. Duplicate IDs are
+// fetched once. IDs that fail, including versions that don't exist, are
+// missing from the map and reported in the returned error alongside the
+// versions that were fetched; errors.Is(err, ErrNotFound) reports whether any
+// ID was not found.
+func (c *Client) GetModelVersionsByIDs(ctx context.Context, ids []int) (map[int]*ModelVersion, error) {
+	unique := make([]int, 0, len(ids))
+	seen := make(map[int]bool, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+
+	versions := make([]*ModelVersion, len(unique))
+	errs := make([]error, len(unique))
+	sem := make(chan struct{}, versionBatchConcurrency)
+	var (
+		wg        sync.WaitGroup
+		completed int32
+	)
+
+	for i, id := range unique {
+		wg.Add(1)
+		go func(i, id int) {
+			defer wg.Done()
+			if c.progressReporter != nil {
+				defer func() {
+					c.reportProgress("GetModelVersionsByIDs", int(atomic.AddInt32(&completed, 1)), len(unique))
+				}()
+			}
+
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-ctx.Done():
+				errs[i] = fmt.Errorf("version %d: %w", id, ctx.Err())
+				return
+			}
+
+			version, err := c.GetModelVersion(ctx, id)
+			if err != nil {
+				errs[i] = fmt.Errorf("version %d: %w", id, err)
+				return
+			}
+			versions[i] = version
+		}(i, id)
+	}
+
+	wg.Wait()
+
+	result := make(map[int]*ModelVersion, len(unique))
+	for i, id := range unique {
+		if versions[i] != nil {
+			result[id] = versions[i]
+		}
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return result, fmt.Errorf("failed to fetch some model versions: %w", err)
+	}
+
+	return result, nil
+}
@@ -24,6 +24,13 @@ SOFTWARE.
 package civitai
 
 import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -143,9 +150,9 @@ func TestFilterVersions(t *testing.T) {
 func TestSortVersions(t *testing.T) {
 	now := time.Now()
 	versions := []ModelVersion{
-		{ID: 1, Name: "Version A", CreatedAt: now.Add(-time.Hour)},
-		{ID: 2, Name: "Version B", CreatedAt: now},
-		{ID: 3, Name: "Version C", CreatedAt: now.Add(-30 * time.Minute)},
+		{ID: 1, Name: "Version A", CreatedAt: CivitaiTime{now.Add(-time.Hour)}},
+		{ID: 2, Name: "Version B", CreatedAt: CivitaiTime{now}},
+		{ID: 3, Name: "Version C", CreatedAt: CivitaiTime{now.Add(-30 * time.Minute)}},
 	}
 
 	t.Run("Sort newest first", func(t *testing.T) {
@@ -187,7 +194,7 @@ func TestVersionMethods(t *testing.T) {
 		ID:           1,
 		Name:         "Test Version",
 		BaseModel:    BaseModelSD1_5,
-		CreatedAt:    time.Now().Add(-time.Hour),
+		CreatedAt:    CivitaiTime{time.Now().Add(-time.Hour)},
 		TrainedWords: []string{"character", "anime"},
 		Files: []File{
 			{
@@ -277,28 +284,16 @@ func TestVersionMethods(t *testing.T) {
 
 	t.Run("GetCompatibleBaseModels", func(t *testing.T) {
 		compatible := version.GetCompatibleBaseModels()
-		
-		if len(compatible) != 2 {
-			t.Errorf("Expected 2 compatible models, got %d", len(compatible))
+
+		if len(compatible) == 0 || compatible[0] != BaseModelSD1_5 {
+			t.Fatalf("Expected SD 1.5 first in compatible models, got %v", compatible)
 		}
-		
-		// Should include SD 1.5 and SD 2.0
-		hasSD15 := false
-		hasSD20 := false
+
+		// SD 1.x and SD 2.x use different text encoders
 		for _, model := range compatible {
-			if model == BaseModelSD1_5 {
-				hasSD15 = true
+			if model == BaseModelSD2_0 || model == BaseModelSD2_1 {
+				t.Errorf("Expected SD 1.5 not to be compatible with %s", model)
 			}
-			if model == BaseModelSD2_0 {
-				hasSD20 = true
-			}
-		}
-		
-		if !hasSD15 {
-			t.Error("Expected SD 1.5 to be included in compatible models")
-		}
-		if !hasSD20 {
-			t.Error("Expected SD 2.0 to be included in compatible models")
 		}
 	})
 
@@ -344,7 +339,7 @@ func TestVersionMethods(t *testing.T) {
 		}
 		
 		for _, tc := range testCases {
-			testVersion := ModelVersion{CreatedAt: time.Now().Add(-tc.age)}
+			testVersion := ModelVersion{CreatedAt: CivitaiTime{time.Now().Add(-tc.age)}}
 			result := testVersion.GetVersionAgeString()
 			if result != tc.expected {
 				t.Errorf("Expected '%s', got '%s'", tc.expected, result)
@@ -478,3 +473,305 @@ func TestIsFileClean(t *testing.T) {
 		}
 	})
 }
+
+func TestSelectFile(t *testing.T) {
+	version := ModelVersion{
+		ID: 10,
+		Files: []File{
+			{ID: 1, Name: "model-fp32.ckpt", Metadata: FileMetadata{Format: FileFormatPickleTensor, FP: "fp32", Size: "full"}, Primary: true},
+			{ID: 2, Name: "model-fp16.safetensors", Metadata: FileMetadata{Format: FileFormatSafeTensors, FP: "fp16", Size: "pruned"}, PickleScanResult: "Failed"},
+			{ID: 3, Name: "model-fp32.safetensors", Metadata: FileMetadata{Format: FileFormatSafeTensors, FP: "fp32", Size: "full"}},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		pref   FilePreference
+		wantID int
+	}{
+		{"empty preference picks primary", FilePreference{}, 1},
+		{"format match", FilePreference{Format: FileFormatSafeTensors}, 2},
+		{"format and precision", FilePreference{Format: FileFormatSafeTensors, Precision: "FP32"}, 3},
+		{"clean only skips failed scan", FilePreference{Format: FileFormatSafeTensors, CleanOnly: true}, 3},
+		{"size match", FilePreference{Size: "pruned"}, 2},
+		{"no match", FilePreference{Format: FileFormatSafeTensors, Precision: "bf16"}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := version.SelectFile(tt.pref)
+			if tt.wantID == 0 {
+				if file != nil {
+					t.Errorf("Expected no file, got %d", file.ID)
+				}
+				return
+			}
+			if file == nil {
+				t.Fatalf("Expected file %d, got nil", tt.wantID)
+			}
+			if file.ID != tt.wantID {
+				t.Errorf("Expected file %d, got %d", tt.wantID, file.ID)
+			}
+		})
+	}
+}
+
+func TestModelVersionSameAs(t *testing.T) {
+	updated := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	version := &ModelVersion{ID: 1, Name: "v1", UpdatedAt: CivitaiTime{updated}, Files: []File{{Name: "a.safetensors", Hashes: Hashes{SHA256: "AAAA"}}}}
+
+	tests := []struct {
+		name  string
+		other *ModelVersion
+		same  bool
+	}{
+		{"identical", &ModelVersion{ID: 1, Name: "v1", UpdatedAt: CivitaiTime{updated}}, true},
+		{"same ID different update time", &ModelVersion{ID: 1, Name: "v1", UpdatedAt: CivitaiTime{updated.Add(time.Minute)}}, false},
+		{"different ID", &ModelVersion{ID: 2, Name: "v1", UpdatedAt: CivitaiTime{updated}}, false},
+		{"nil", nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := version.SameAs(tt.other); got != tt.same {
+				t.Errorf("SameAs() = %v, expected %v", got, tt.same)
+			}
+		})
+	}
+
+	t.Run("Content hash tracks file hashes", func(t *testing.T) {
+		other := *version
+		other.Files = []File{{Name: "a.safetensors", Hashes: Hashes{SHA256: "BBBB"}}}
+		if version.ContentHash() == other.ContentHash() {
+			t.Error("Expected different file hashes to change the content hash")
+		}
+	})
+}
+
+func TestOrderedHelpers(t *testing.T) {
+	t.Run("GroupVersionsByBaseModelOrdered", func(t *testing.T) {
+		versions := []ModelVersion{
+			{ID: 1, BaseModel: BaseModelSDXL},
+			{ID: 2, BaseModel: BaseModelSD1_5},
+			{ID: 3},
+			{ID: 4, BaseModel: BaseModelSD2_1},
+			{ID: 5, BaseModel: BaseModelSD1_5},
+		}
+
+		expected := []BaseModel{BaseModelOther, BaseModelSD1_5, BaseModelSD2_1, BaseModelSDXL}
+		for run := 0; run < 20; run++ {
+			groups := GroupVersionsByBaseModelOrdered(versions)
+			if len(groups) != len(expected) {
+				t.Fatalf("Expected %d groups, got %d", len(expected), len(groups))
+			}
+			for i, group := range groups {
+				if group.BaseModel != expected[i] {
+					t.Fatalf("Run %d: expected group %d to be %s, got %s", run, i, expected[i], group.BaseModel)
+				}
+			}
+		}
+
+		sd15 := GroupVersionsByBaseModelOrdered(versions)[1].Versions
+		if len(sd15) != 2 || sd15[0].ID != 2 || sd15[1].ID != 5 {
+			t.Errorf("Expected SD 1.5 versions [2 5] in input order, got %+v", sd15)
+		}
+	})
+
+	t.Run("GetFileStatsOrdered", func(t *testing.T) {
+		version := ModelVersion{Files: []File{
+			{Metadata: FileMetadata{Format: FileFormatSafeTensors}},
+			{Metadata: FileMetadata{Format: FileFormatCKPT}},
+			{Metadata: FileMetadata{Format: FileFormatPickleTensor}},
+			{Metadata: FileMetadata{Format: FileFormatSafeTensors}},
+		}}
+
+		expectedKeys := []string{"clean_files", "format_counts", "scan_pass_rate", "total_files", "total_size_kb", "total_size_mb"}
+		for run := 0; run < 20; run++ {
+			stats := version.GetFileStatsOrdered()
+			if len(stats) != len(expectedKeys) {
+				t.Fatalf("Expected %d stats, got %d", len(expectedKeys), len(stats))
+			}
+			for i, stat := range stats {
+				if stat.Key != expectedKeys[i] {
+					t.Fatalf("Run %d: expected key %d to be %s, got %s", run, i, expectedKeys[i], stat.Key)
+				}
+			}
+
+			formats, ok := stats[1].Value.([]FormatCount)
+			if !ok {
+				t.Fatalf("Expected format_counts to be []FormatCount, got %T", stats[1].Value)
+			}
+			expectedFormats := []FormatCount{
+				{FileFormatCKPT, 1},
+				{FileFormatPickleTensor, 1},
+				{FileFormatSafeTensors, 2},
+			}
+			for i, fc := range formats {
+				if fc != expectedFormats[i] {
+					t.Fatalf("Run %d: expected format count %d to be %+v, got %+v", run, i, expectedFormats[i], fc)
+				}
+			}
+		}
+	})
+}
+
+func TestCompatibilityGraph(t *testing.T) {
+	t.Run("Default relationships", func(t *testing.T) {
+		tests := []struct {
+			baseModel BaseModel
+			expected  []BaseModel
+		}{
+			{BaseModelSD1_5, []BaseModel{BaseModelSD1_5, "SD 1.4", "SD 1.5 LCM", "SD 1.5 Hyper"}},
+			{BaseModelSD2_0, []BaseModel{BaseModelSD2_0, BaseModelSD2_1}},
+			{BaseModelSD2_1, []BaseModel{BaseModelSD2_1, BaseModelSD2_0}},
+			{BaseModelSDXL, []BaseModel{BaseModelSDXL, "SDXL Turbo", "SDXL Lightning", "SDXL Hyper"}},
+			{"Flux.1 D", []BaseModel{"Flux.1 D"}},
+			{"", nil},
+		}
+
+		for _, tt := range tests {
+			version := ModelVersion{BaseModel: tt.baseModel}
+			compatible := version.GetCompatibleBaseModels()
+			if !reflect.DeepEqual(compatible, tt.expected) {
+				t.Errorf("%q: expected %v, got %v", tt.baseModel, tt.expected, compatible)
+			}
+		}
+	})
+
+	t.Run("Custom graph override", func(t *testing.T) {
+		graph := map[BaseModel][]BaseModel{
+			BaseModelSDXL: {"Pony", "Illustrious"},
+		}
+		client := NewClientWithoutAuth(WithCompatibilityGraph(graph))
+
+		// The option copies the graph
+		graph[BaseModelSDXL] = nil
+
+		compatible := client.CompatibleBaseModels(&ModelVersion{BaseModel: BaseModelSDXL})
+		expected := []BaseModel{BaseModelSDXL, "Pony", "Illustrious"}
+		if !reflect.DeepEqual(compatible, expected) {
+			t.Errorf("Expected %v, got %v", expected, compatible)
+		}
+
+		// Base models missing from the custom graph only match themselves
+		compatible = client.CompatibleBaseModels(&ModelVersion{BaseModel: BaseModelSD2_0})
+		if !reflect.DeepEqual(compatible, []BaseModel{BaseModelSD2_0}) {
+			t.Errorf("Expected only SD 2.0, got %v", compatible)
+		}
+	})
+
+	t.Run("Default client uses default graph", func(t *testing.T) {
+		compatible := NewClientWithoutAuth().CompatibleBaseModels(&ModelVersion{BaseModel: BaseModelSD2_0})
+		if !reflect.DeepEqual(compatible, []BaseModel{BaseModelSD2_0, BaseModelSD2_1}) {
+			t.Errorf("Expected SD 2.0 and SD 2.1, got %v", compatible)
+		}
+	})
+
+	t.Run("DefaultCompatibilityGraph returns a copy", func(t *testing.T) {
+		graph := DefaultCompatibilityGraph()
+		graph[BaseModelSD2_0][0] = "Mutated"
+
+		if DefaultCompatibilityGraph()[BaseModelSD2_0][0] != BaseModelSD2_1 {
+			t.Error("Expected default graph to be unaffected by caller mutation")
+		}
+	})
+}
+
+func TestIsPrimaryFileSafe(t *testing.T) {
+	older := CivitaiTime{time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	newer := CivitaiTime{time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)}
+
+	clean := ModelVersion{ID: 1, CreatedAt: older, Files: []File{
+		{ID: 1, PickleScanResult: "Failed"},
+		{ID: 2, Primary: true, PickleScanResult: "Success", VirusScanResult: "Success"},
+	}}
+	unclean := ModelVersion{ID: 2, CreatedAt: newer, Files: []File{
+		{ID: 3, Primary: true, PickleScanResult: "Success", VirusScanResult: "Danger"},
+		{ID: 4, PickleScanResult: "Success", VirusScanResult: "Success"},
+	}}
+
+	t.Run("Clean primary file", func(t *testing.T) {
+		if !clean.IsPrimaryFileSafe() {
+			t.Error("Expected clean primary file to be safe")
+		}
+	})
+
+	t.Run("Unclean primary file", func(t *testing.T) {
+		if unclean.IsPrimaryFileSafe() {
+			t.Error("Expected primary file with failed virus scan to be unsafe")
+		}
+	})
+
+	t.Run("No files", func(t *testing.T) {
+		empty := ModelVersion{}
+		if empty.IsPrimaryFileSafe() {
+			t.Error("Expected version without files to be unsafe")
+		}
+	})
+
+	t.Run("LatestIsSafe", func(t *testing.T) {
+		model := Model{ModelVersions: []ModelVersion{clean, unclean}}
+		if model.LatestIsSafe() {
+			t.Error("Expected latest version with unclean primary file to be unsafe")
+		}
+
+		rescanned := unclean
+		rescanned.Files = []File{{ID: 3, Primary: true, PickleScanResult: "Success", VirusScanResult: "Success"}}
+		model = Model{ModelVersions: []ModelVersion{clean, rescanned}}
+		if !model.LatestIsSafe() {
+			t.Error("Expected latest version with clean primary file to be safe")
+		}
+
+		if (&Model{}).LatestIsSafe() {
+			t.Error("Expected model without versions to be unsafe")
+		}
+	})
+}
+
+func TestGetModelVersionsByIDs(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		id := strings.TrimPrefix(r.URL.Path, "/model-versions/")
+		if id == "404" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id": ` + id + `, "name": "v` + id + `"}`))
+	}))
+	defer server.Close()
+
+	client := NewClientWithoutAuth(WithBaseURL(server.URL), WithRetryConfig(0, 0, 0))
+	versions, err := client.GetModelVersionsByIDs(context.Background(), []int{11, 22, 404, 33, 22})
+
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected error matching ErrNotFound, got %v", err)
+	}
+	if err != nil && !strings.Contains(err.Error(), "version 404") {
+		t.Errorf("Expected error to name version 404, got %v", err)
+	}
+	if len(versions) != 3 {
+		t.Fatalf("Expected 3 versions, got %d", len(versions))
+	}
+	for _, id := range []int{11, 22, 33} {
+		if versions[id] == nil || versions[id].ID != id {
+			t.Errorf("Expected version %d, got %+v", id, versions[id])
+		}
+	}
+	if _, ok := versions[404]; ok {
+		t.Error("Expected missing version to be absent from the map")
+	}
+	if got := atomic.LoadInt32(&requests); got != 4 {
+		t.Errorf("Expected duplicate IDs to be fetched once (4 requests), got %d", got)
+	}
+
+	t.Run("Canceled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		versions, err := client.GetModelVersionsByIDs(ctx, []int{1, 2})
+		if !errors.Is(err, context.Canceled) || len(versions) != 0 {
+			t.Errorf("Expected context.Canceled and no versions, got %v and %d", err, len(versions))
+		}
+	})
+}
@@ -57,6 +57,14 @@ SOFTWARE.
 //	// Sort by newest first
 //	civitai.SortModels(models, civitai.ModelSortByNewest)
 //
+// # Merging Search Results
+//
+// Search several ways and combine the results without duplicates:
+//
+//	byQuery, _, _ := client.SearchModels(ctx, civitai.SearchParams{Query: "anime"})
+//	byTag, _, _ := client.SearchModels(ctx, civitai.SearchParams{Tag: "anime"})
+//	models := civitai.MergeModels(byQuery, byTag)
+//
 // # Model Methods
 //
 // Models provide convenient methods for common operations:
@@ -74,19 +82,97 @@ SOFTWARE.
 //		fmt.Printf("Latest version: %s\n", latest.Name)
 //	}
 //
+//	// Look up a version named in a changelog
+//	v2 := model.GetVersionByName("v2.0")
+//
+//	// Inventory every file across all versions
+//	for _, file := range model.AllFiles() {
+//		fmt.Printf("%s (%.1f KB)\n", file.Name, file.SizeKB)
+//	}
+//
 //	// Check for specific tags
 //	if model.HasTag("realistic") {
 //		fmt.Println("This is a realistic model")
 //	}
 //
+//	// Classify content more finely than the NSFW bool
+//	if model.NSFWLevel() == civitai.NSFWLevelX {
+//		fmt.Println("Explicit content")
+//	}
+//
+//	// Classify conservatively, treating person-of-interest models with caution
+//	if model.ContentRating() != civitai.NSFWLevelNone {
+//		fmt.Println("Hide behind a content warning")
+//	}
+//
 //	// Get model summary
 //	summary := model.GetModelSummary()
 //	fmt.Printf("Model: %s (%d downloads)\n", summary.Name, summary.Downloads)
+//
+// # Quality Scores
+//
+// Rank models by a single 0-1 score that combines rating, how many people
+// rated or voted, downloads, and the thumbs-up ratio:
+//
+//	sort.SliceStable(models, func(i, j int) bool {
+//		return models[i].QualityScore() > models[j].QualityScore()
+//	})
+//
+//	// Care only about approval and popularity
+//	score := model.QualityScore(civitai.WithQualityScoreWeights(civitai.QualityScoreWeights{
+//		Downloads: 1,
+//		Approval:  1,
+//	}))
+//
+// # Field Projection
+//
+// The CivitAI API has no field selection, so full models are always
+// downloaded. When storing many models for a list view, Project keeps only the
+// fields the view needs and lets the rest (usually the bulky version data) be
+// garbage collected:
+//
+//	for i := range models {
+//		models[i] = models[i].Project("name", "images", "stats")
+//	}
+//
+// # Trimming Versions
+//
+// Some models have hundreds of versions. List views that only show recent
+// ones can keep memory down with TrimVersions, or have the client trim every
+// model it returns with WithMaxModelVersionsInline:
+//
+//	model.TrimVersions(3)
+//	all, err := client.GetModelVersionsByModelID(ctx, model.ID) // full set on demand
+//
+// # Filling Partial Models
+//
+// Complete a model from search results with the detail endpoint, keeping
+// any fields the search already populated:
+//
+//	if err := client.FillModel(ctx, &models[0]); err != nil {
+//		log.Printf("could not fill model: %v", err)
+//	}
+//
+// # Watching Models
+//
+// Poll a model and get notified when it is updated or gains a new version:
+//
+//	err := client.WatchModel(ctx, 4201, 10*time.Minute, func(old, new *civitai.Model) {
+//		if latest := new.GetLatestVersion(); latest != nil {
+//			fmt.Printf("%s updated, latest version: %s\n", new.Name, latest.Name)
+//		}
+//	})
 
 package civitai
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
 	"sort"
 	"strings"
 	"time"
@@ -164,7 +250,9 @@ func shouldIncludeModel(model Model, filter ModelFilter) bool {
 	return true
 }
 
-// SortModels sorts a slice of models by the specified criteria
+// SortModels sorts a slice of models by the specified criteria. Models that
+// tie on the criteria are ordered by ascending ID, so the result is always
+// reproducible.
 func SortModels(models []Model, sortBy SortType) []Model {
 	if len(models) == 0 {
 		return models
@@ -175,25 +263,80 @@ func SortModels(models []Model, sortBy SortType) []Model {
 	copy(sorted, models)
 
 	sort.Slice(sorted, func(i, j int) bool {
+		a, b := &sorted[i], &sorted[j]
+		var cmp int
 		switch sortBy {
 		case SortHighestRated:
-			return sorted[i].Stats.Rating > sorted[j].Stats.Rating
-		case SortMostDownload:
-			return sorted[i].Stats.DownloadCount > sorted[j].Stats.DownloadCount
+			cmp = compareDescending(a.Stats.Rating, b.Stats.Rating)
 		case SortMostLiked:
-			return sorted[i].Stats.ThumbsUpCount > sorted[j].Stats.ThumbsUpCount
+			cmp = compareDescending(a.Stats.ThumbsUpCount, b.Stats.ThumbsUpCount)
 		case SortNewest:
-			return sorted[i].CreatedAt.After(sorted[j].CreatedAt)
+			cmp = b.CreatedAt.Compare(a.CreatedAt.Time)
 		case SortOldest:
-			return sorted[i].CreatedAt.Before(sorted[j].CreatedAt)
+			cmp = a.CreatedAt.Compare(b.CreatedAt.Time)
 		default:
-			return sorted[i].Stats.DownloadCount > sorted[j].Stats.DownloadCount
+			cmp = compareDescending(a.Stats.DownloadCount, b.Stats.DownloadCount)
 		}
+
+		if cmp != 0 {
+			return cmp < 0
+		}
+		return a.ID < b.ID
 	})
 
 	return sorted
 }
 
+// MergeModels combines model sets into one, keeping a single record per model
+// ID. Models appear in the order their ID was first seen. When the same ID
+// appears more than once, the richest record is kept whole (records are never
+// combined field by field): the one with the most versions, then the most
+// downloads, then the most ratings, with the earliest record winning a full
+// tie. The input slices are not modified.
+func MergeModels(sets ...[]Model) []Model {
+	var merged []Model
+	index := make(map[int]int)
+
+	for _, set := range sets {
+		for _, model := range set {
+			i, seen := index[model.ID]
+			if !seen {
+				index[model.ID] = len(merged)
+				merged = append(merged, model)
+				continue
+			}
+			if richerModel(&model, &merged[i]) {
+				merged[i] = model
+			}
+		}
+	}
+
+	return merged
+}
+
+// richerModel reports whether a should replace b in MergeModels
+func richerModel(a, b *Model) bool {
+	if cmp := compareDescending(len(a.ModelVersions), len(b.ModelVersions)); cmp != 0 {
+		return cmp < 0
+	}
+	if cmp := compareDescending(a.Stats.DownloadCount, b.Stats.DownloadCount); cmp != 0 {
+		return cmp < 0
+	}
+	return a.Stats.RatingCount > b.Stats.RatingCount
+}
+
+// compareDescending orders larger values first, returning -1, 0, or +1
+func compareDescending[T int | float64](a, b T) int {
+	switch {
+	case a > b:
+		return -1
+	case a < b:
+		return 1
+	default:
+		return 0
+	}
+}
+
 // GetLatestVersion returns the most recently created model version
 func (m *Model) GetLatestVersion() *ModelVersion {
 	if len(m.ModelVersions) == 0 {
@@ -202,7 +345,7 @@ func (m *Model) GetLatestVersion() *ModelVersion {
 
 	latest := &m.ModelVersions[0]
 	for i := 1; i < len(m.ModelVersions); i++ {
-		if m.ModelVersions[i].CreatedAt.After(latest.CreatedAt) {
+		if m.ModelVersions[i].CreatedAt.After(latest.CreatedAt.Time) {
 			latest = &m.ModelVersions[i]
 		}
 	}
@@ -210,6 +353,66 @@ func (m *Model) GetLatestVersion() *ModelVersion {
 	return latest
 }
 
+// GetVersionByName returns the version whose name matches name, ignoring case
+// and surrounding whitespace, or nil if there is none. Version names aren't
+// unique, so when several match, the first in ModelVersions (usually the
+// newest) is returned.
+func (m *Model) GetVersionByName(name string) *ModelVersion {
+	name = strings.TrimSpace(name)
+	for i := range m.ModelVersions {
+		if strings.EqualFold(strings.TrimSpace(m.ModelVersions[i].Name), name) {
+			return &m.ModelVersions[i]
+		}
+	}
+	return nil
+}
+
+// LatestPrimaryFileSHA256 returns the SHA256 hash of the latest version's primary
+// file, for matching local files against CivitAI models. The hash is normalized
+// to upper case; ok is false when there is no version, file, or hash.
+func (m *Model) LatestPrimaryFileSHA256() (string, bool) {
+	latest := m.GetLatestVersion()
+	if latest == nil {
+		return "", false
+	}
+
+	file := latest.GetPrimaryFile()
+	if file == nil || file.Hashes.SHA256 == "" {
+		return "", false
+	}
+
+	return strings.ToUpper(file.Hashes.SHA256), true
+}
+
+// LatestIsSafe reports whether the primary file of the model's latest version
+// has passed security scans, returning false when the model has no versions
+func (m *Model) LatestIsSafe() bool {
+	latest := m.GetLatestVersion()
+	return latest != nil && latest.IsPrimaryFileSafe()
+}
+
+// AllFiles returns every file of every version of the model in one slice,
+// ordered by version as in ModelVersions and then by file within each version
+func (m *Model) AllFiles() []File {
+	var files []File
+	for _, version := range m.ModelVersions {
+		files = append(files, version.Files...)
+	}
+	return files
+}
+
+// AllPrimaryFiles returns the primary file (see GetPrimaryFile) of each version
+// of the model, in version order. Versions without files are skipped.
+func (m *Model) AllPrimaryFiles() []File {
+	var files []File
+	for i := range m.ModelVersions {
+		if file := m.ModelVersions[i].GetPrimaryFile(); file != nil {
+			files = append(files, *file)
+		}
+	}
+	return files
+}
+
 // GetPrimaryFile returns the primary file from the model version
 func (mv *ModelVersion) GetPrimaryFile() *File {
 	for i := range mv.Files {
@@ -236,6 +439,25 @@ func (mv *ModelVersion) GetFileByFormat(format FileFormat) *File {
 	return nil
 }
 
+// GetFileByHash returns the first file with any hash (AutoV1, AutoV2, SHA256,
+// CRC32, or BLAKE3) matching hash case-insensitively, or nil if none match
+func (mv *ModelVersion) GetFileByHash(hash string) *File {
+	hash = strings.TrimSpace(hash)
+	if hash == "" {
+		return nil
+	}
+
+	for i := range mv.Files {
+		h := mv.Files[i].Hashes
+		for _, candidate := range []string{h.AutoV1, h.AutoV2, h.SHA256, h.CRC32, h.BLAKE3} {
+			if candidate != "" && strings.EqualFold(candidate, hash) {
+				return &mv.Files[i]
+			}
+		}
+	}
+	return nil
+}
+
 // HasTag checks if the model has a specific tag (case-insensitive)
 func (m *Model) HasTag(tag string) bool {
 	for _, modelTag := range m.Tags {
@@ -246,6 +468,73 @@ func (m *Model) HasTag(tag string) bool {
 	return false
 }
 
+// NSFWLevel returns a best-effort content level for the model. The models
+// endpoint only reports the NSFW bool, so the level is derived from the NSFW
+// levels of the model's images and its versions' images, taking the most
+// explicit one. A model flagged NSFW is reported as at least NSFWLevelSoft, and
+// images with missing or unknown levels are ignored.
+func (m *Model) NSFWLevel() NSFWLevel {
+	level := NSFWLevelNone
+
+	consider := func(images []Image) {
+		for _, image := range images {
+			rank := nsfwLevelRank(NSFWLevel(image.NSFW))
+			if rank > nsfwLevelRank(level) {
+				level = NSFWLevel(image.NSFW)
+			}
+		}
+	}
+
+	consider(m.Images)
+	for _, version := range m.ModelVersions {
+		consider(version.Images)
+	}
+
+	if m.NSFW && level == NSFWLevelNone {
+		return NSFWLevelSoft
+	}
+	return level
+}
+
+// ContentRating returns a single conservative content rating for the model,
+// combining every sensitivity signal the API provides. It starts from
+// NSFWLevel, the most explicit level among the model's NSFW flag and its
+// images, and then raises the rating one step for POI (person of interest)
+// models, since content depicting a real person warrants more caution than
+// the same content otherwise would. The rating never exceeds NSFWLevelX.
+func (m *Model) ContentRating() NSFWLevel {
+	level := m.NSFWLevel()
+	if !m.POI {
+		return level
+	}
+
+	switch level {
+	case NSFWLevelNone:
+		return NSFWLevelSoft
+	case NSFWLevelSoft:
+		return NSFWLevelMature
+	default:
+		return NSFWLevelX
+	}
+}
+
+// nsfwLevelRank orders NSFW levels from least to most explicit, returning -1
+// for unknown levels
+func nsfwLevelRank(level NSFWLevel) int {
+	switch level {
+	case NSFWLevelNone:
+		return 0
+	case NSFWLevelSoft:
+		return 1
+	case NSFWLevelMature:
+		return 2
+	case NSFWLevelX:
+		return 3
+	default:
+		return -1
+	}
+}
+
 // IsCommercialUseAllowed checks if the model allows commercial use
 func (m *Model) IsCommercialUseAllowed() bool {
 	for _, use := range m.AllowCommercialUse {
@@ -284,15 +573,23 @@ func (mv *ModelVersion) IsEarlyAccess() bool {
 	return time.Now().Before(earlyAccessEnd)
 }
 
-// GetModelSummary returns a formatted summary string for the model
+// GetModelSummary returns a formatted summary string for the model, including
+// the size of the latest version's primary file when known
 func (m *Model) GetModelSummary() string {
-	return fmt.Sprintf("%s (%s) - %d downloads, %.1f rating, %d versions",
+	summary := fmt.Sprintf("%s (%s) - %d downloads, %.1f rating, %d versions",
 		m.Name,
 		m.Type,
 		m.Stats.DownloadCount,
 		m.Stats.Rating,
 		len(m.ModelVersions),
 	)
+
+	if latest := m.GetLatestVersion(); latest != nil {
+		if primaryFile := latest.GetPrimaryFile(); primaryFile != nil {
+			summary += ", latest " + FormatSize(primaryFile.SizeKB)
+		}
+	}
+	return summary
 }
 
 // GetVersionSummary returns a formatted summary string for the model version
@@ -300,7 +597,7 @@ func (mv *ModelVersion) GetVersionSummary() string {
 	primaryFile := mv.GetPrimaryFile()
 	fileInfo := "no files"
 	if primaryFile != nil {
-		fileInfo = fmt.Sprintf("%.1f MB", primaryFile.SizeKB/1024)
+		fileInfo = FormatSize(primaryFile.SizeKB)
 	}
 
 	return fmt.Sprintf("%s (%s) - %s, %d images",
@@ -310,3 +607,406 @@ func (mv *ModelVersion) GetVersionSummary() string {
 		len(mv.Images),
 	)
 }
+
+// Project returns a copy of the model with only the named fields set. Fields
+// are named by their JSON keys (e.g. "name", "modelVersions", "images"),
+// case-insensitively; unknown names are ignored. ID is always kept. Projection
+// is done client-side because the API doesn't support field selection; kept
+// slices share storage with m.
+func (m *Model) Project(fields ...string) Model {
+	projected := Model{ID: m.ID}
+	for _, field := range fields {
+		switch strings.ToLower(strings.TrimSpace(field)) {
+		case "name":
+			projected.Name = m.Name
+		case "description":
+			projected.Description = m.Description
+		case "type":
+			projected.Type = m.Type
+		case "poi":
+			projected.POI = m.POI
+		case "nsfw":
+			projected.NSFW = m.NSFW
+		case "allownocredit":
+			projected.AllowNoCredit = m.AllowNoCredit
+		case "allowcommercialuse":
+			projected.AllowCommercialUse = m.AllowCommercialUse
+		case "allowderivatives":
+			projected.AllowDerivatives = m.AllowDerivatives
+		case "allowdifferentlicense":
+			projected.AllowDifferentLicense = m.AllowDifferentLicense
+		case "stats":
+			projected.Stats = m.Stats
+		case "creator":
+			projected.Creator = m.Creator
+		case "tags":
+			projected.Tags = m.Tags
+		case "modelversions":
+			projected.ModelVersions = m.ModelVersions
+		case "images":
+			projected.Images = m.Images
+		case "createdat":
+			projected.CreatedAt = m.CreatedAt
+		case "updatedat":
+			projected.UpdatedAt = m.UpdatedAt
+		case "publishedat":
+			projected.PublishedAt = m.PublishedAt
+		}
+	}
+	return projected
+}
+
+// TrimVersions keeps only the n most recently created versions, in their
+// original order, and drops the rest. The kept versions are copied to a new
+// slice so the dropped ones can be garbage collected. n <= 0 keeps every
+// version. Use GetModelVersionsByModelID to fetch the full set again.
+func (m *Model) TrimVersions(n int) {
+	if n <= 0 || len(m.ModelVersions) <= n {
+		return
+	}
+
+	byAge := make([]int, len(m.ModelVersions))
+	for i := range byAge {
+		byAge[i] = i
+	}
+	sort.SliceStable(byAge, func(a, b int) bool {
+		return m.ModelVersions[byAge[a]].CreatedAt.After(m.ModelVersions[byAge[b]].CreatedAt.Time)
+	})
+
+	keep := byAge[:n]
+	sort.Ints(keep)
+	trimmed := make([]ModelVersion, 0, n)
+	for _, i := range keep {
+		trimmed = append(trimmed, m.ModelVersions[i])
+	}
+	m.ModelVersions = trimmed
+}
+
+const (
+	// qualityConfidenceScale is the number of ratings and votes at which the
+	// confidence component of QualityScore reaches 0.5
+	qualityConfidenceScale = 50
+
+	// qualityDownloadScale is the download count at which the downloads
+	// component of QualityScore reaches 1
+	qualityDownloadScale = 1_000_000
+
+	// qualityApprovalZ is the z-score of the Wilson lower bound used for the
+	// approval component of QualityScore (95% confidence)
+	qualityApprovalZ = 1.96
+)
+
+// QualityScoreWeights sets how much each component contributes to
+// Model.QualityScore. Weights are relative, since the score is divided by
+// their sum; negative weights count as zero.
+type QualityScoreWeights struct {
+	Rating     float64 // Average rating, mapped from 1-5 to 0-1
+	Confidence float64 // How many ratings and thumbs votes back the score
+	Downloads  float64 // Log-scaled download count
+	Approval   float64 // Lower bound of the thumbs-up ratio
+}
+
+// defaultQualityScoreWeights are the weights QualityScore uses by default
+var defaultQualityScoreWeights = QualityScoreWeights{Rating: 0.3, Confidence: 0.2, Downloads: 0.3, Approval: 0.2}
+
+// QualityScoreOption customizes Model.QualityScore
+type QualityScoreOption func(*QualityScoreWeights)
+
+// WithQualityScoreWeights replaces the default QualityScore weights of
+// Rating 0.3, Confidence 0.2, Downloads 0.3, and Approval 0.2
+func WithQualityScoreWeights(weights QualityScoreWeights) QualityScoreOption {
+	return func(w *QualityScoreWeights) {
+		*w = weights
+	}
+}
+
+// QualityScore combines the model's stats into a single score from 0 to 1
+// for ranking. It is the weighted average of four components, each 0 to 1:
+//   - Rating: (Rating-1)/4, or 0 when nobody has rated the model
+//   - Confidence: n/(n+50), where n is RatingCount+ThumbsUpCount+ThumbsDownCount
+//   - Downloads: ln(1+DownloadCount)/ln(1+1,000,000), capped at 1
+//   - Approval: the Wilson score lower bound (95%) of the thumbs-up ratio,
+//     which is 0 without votes and discounts ratios backed by few votes
+//
+// Scores are only comparable between models scored with the same weights.
+// When every weight is zero or negative the score is 0.
+func (m *Model) QualityScore(opts ...QualityScoreOption) float64 {
+	weights := defaultQualityScoreWeights
+	for _, opt := range opts {
+		opt(&weights)
+	}
+
+	stats := m.Stats
+	var rating float64
+	if stats.RatingCount > 0 {
+		rating = clamp01((stats.Rating - 1) / 4)
+	}
+	votes := float64(stats.ThumbsUpCount + stats.ThumbsDownCount)
+	n := float64(stats.RatingCount) + votes
+	confidence := n / (n + qualityConfidenceScale)
+	downloads := clamp01(math.Log1p(float64(max(stats.DownloadCount, 0))) / math.Log1p(qualityDownloadScale))
+	approval := wilsonLowerBound(float64(stats.ThumbsUpCount), votes, qualityApprovalZ)
+
+	components := []struct{ weight, value float64 }{
+		{weights.Rating, rating},
+		{weights.Confidence, confidence},
+		{weights.Downloads, downloads},
+		{weights.Approval, approval},
+	}
+	var score, total float64
+	for _, c := range components {
+		if c.weight > 0 {
+			score += c.weight * c.value
+			total += c.weight
+		}
+	}
+	if total == 0 {
+		return 0
+	}
+	return score / total
+}
+
+// wilsonLowerBound returns the lower bound of the Wilson score interval for
+// positive successes out of total trials, or 0 without trials
+func wilsonLowerBound(positive, total, z float64) float64 {
+	if total <= 0 {
+		return 0
+	}
+	p := positive / total
+	z2 := z * z
+	center := p + z2/(2*total)
+	margin := z * math.Sqrt((p*(1-p)+z2/(4*total))/total)
+	return clamp01((center - margin) / (1 + z2/total))
+}
+
+// clamp01 limits v to the range [0, 1]
+func clamp01(v float64) float64 {
+	return math.Max(0, math.Min(1, v))
+}
+
+// SameAs reports whether two models are the same model at the same revision,
+// comparing by ID and UpdatedAt. Use it to detect whether stored data is stale.
+func (m *Model) SameAs(other *Model) bool {
+	if m == nil || other == nil {
+		return m == other
+	}
+	return m.ID == other.ID && m.UpdatedAt.Equal(other.UpdatedAt.Time)
+}
+
+// ContentHash returns a SHA256 hex digest over the model's stable fields.
+// Volatile statistics (downloads, ratings, etc.) are excluded so the hash only
+// changes when the model's content changes.
+func (m *Model) ContentHash() string {
+	versionHashes := make([]string, len(m.ModelVersions))
+	for i := range m.ModelVersions {
+		versionHashes[i] = m.ModelVersions[i].ContentHash()
+	}
+
+	return hashStableFields(struct {
+		ID                    int
+		Name                  string
+		Description           string
+		Type                  ModelType
+		POI                   bool
+		NSFW                  bool
+		AllowNoCredit         bool
+		AllowCommercialUse    []string
+		AllowDerivatives      bool
+		AllowDifferentLicense bool
+		Creator               string
+		Tags                  []string
+		Versions              []string
+	}{
+		ID:                    m.ID,
+		Name:                  m.Name,
+		Description:           m.Description,
+		Type:                  m.Type,
+		POI:                   m.POI,
+		NSFW:                  m.NSFW,
+		AllowNoCredit:         m.AllowNoCredit,
+		AllowCommercialUse:    m.AllowCommercialUse,
+		AllowDerivatives:      m.AllowDerivatives,
+		AllowDifferentLicense: m.AllowDifferentLicense,
+		Creator:               m.Creator.Username,
+		Tags:                  m.Tags,
+		Versions:              versionHashes,
+	})
+}
+
+// hashStableFields returns the SHA256 hex digest of the JSON encoding of v
+func hashStableFields(v interface{}) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		// Only plain fields are hashed, so marshaling cannot fail in practice
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// maxWatchBackoff caps how many intervals WatchModel waits after failed polls
+const maxWatchBackoff = 8
+
+// WatchModel polls GetModel every interval and calls fn with the previous and
+// current model whenever UpdatedAt or the latest version changes. The first
+// fetch establishes the baseline and does not call fn. Failed polls (including
+// rate limiting) double the wait before the next poll, up to 8 intervals, and
+// the wait resets after a successful poll. WatchModel blocks until ctx is done
+// and then returns nil; it returns an error only if the initial fetch fails.
+func (c *Client) WatchModel(ctx context.Context, modelID int, interval time.Duration, fn func(old, new *Model)) error {
+	if interval <= 0 {
+		return fmt.Errorf("watch interval must be positive, got %v", interval)
+	}
+	if fn == nil {
+		return errors.New("watch callback cannot be nil")
+	}
+
+	current, err := c.GetModel(ctx, modelID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch model %d: %w", modelID, err)
+	}
+
+	backoff := 1
+	timer := time.NewTimer(interval)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-timer.C:
+		}
+
+		latest, err := c.GetModel(ctx, modelID)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			if backoff < maxWatchBackoff {
+				backoff *= 2
+			}
+			timer.Reset(time.Duration(backoff) * interval)
+			continue
+		}
+
+		backoff = 1
+		if modelChanged(current, latest) {
+			fn(current, latest)
+			current = latest
+		}
+		timer.Reset(interval)
+	}
+}
+
+// modelChanged reports whether a model was updated or its latest version changed
+func modelChanged(old, new *Model) bool {
+	if !old.SameAs(new) {
+		return true
+	}
+
+	oldLatest, newLatest := old.GetLatestVersion(), new.GetLatestVersion()
+	if oldLatest == nil || newLatest == nil {
+		return oldLatest != newLatest
+	}
+	return oldLatest.ID != newLatest.ID
+}
+
+// FillModel fetches the full details of a partially populated model, such as
+// one from search results, and copies them into m only where m's field is
+// zero or empty; fields m already has are left untouched. Stats and creator
+// fields are filled individually, and versions are filled by ID, so partial
+// stats or versions are completed rather than replaced. Versions the detail
+// response adds are ignored unless m has none.
+func (c *Client) FillModel(ctx context.Context, m *Model) error {
+	if m == nil {
+		return errors.New("model cannot be nil")
+	}
+
+	detail, err := c.GetModel(ctx, m.ID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch model %d: %w", m.ID, err)
+	}
+
+	fillModel(m, detail)
+	return nil
+}
+
+// fillModel copies src's fields into dst where dst's are zero or empty
+func fillModel(dst, src *Model) {
+	fillZero(&dst.Name, src.Name)
+	fillZero(&dst.Description, src.Description)
+	fillZero(&dst.Type, src.Type)
+	fillZero(&dst.POI, src.POI)
+	fillZero(&dst.NSFW, src.NSFW)
+	fillZero(&dst.AllowNoCredit, src.AllowNoCredit)
+	fillEmpty(&dst.AllowCommercialUse, src.AllowCommercialUse)
+	fillZero(&dst.AllowDerivatives, src.AllowDerivatives)
+	fillZero(&dst.AllowDifferentLicense, src.AllowDifferentLicense)
+	fillStats(&dst.Stats, src.Stats)
+	fillZero(&dst.Creator.ID, src.Creator.ID)
+	fillZero(&dst.Creator.Username, src.Creator.Username)
+	fillZero(&dst.Creator.Image, src.Creator.Image)
+	fillEmpty(&dst.Tags, src.Tags)
+	fillEmpty(&dst.Images, src.Images)
+	fillZero(&dst.CreatedAt, src.CreatedAt)
+	fillZero(&dst.UpdatedAt, src.UpdatedAt)
+	fillZero(&dst.PublishedAt, src.PublishedAt)
+
+	if len(dst.ModelVersions) == 0 {
+		dst.ModelVersions = src.ModelVersions
+		return
+	}
+	for i := range dst.ModelVersions {
+		for j := range src.ModelVersions {
+			if dst.ModelVersions[i].ID == src.ModelVersions[j].ID {
+				fillVersion(&dst.ModelVersions[i], &src.ModelVersions[j])
+				break
+			}
+		}
+	}
+}
+
+// fillVersion copies src's fields into dst where dst's are zero or empty
+func fillVersion(dst, src *ModelVersion) {
+	fillZero(&dst.ModelID, src.ModelID)
+	fillZero(&dst.Name, src.Name)
+	fillZero(&dst.Description, src.Description)
+	fillZero(&dst.BaseModel, src.BaseModel)
+	fillZero(&dst.BaseModelType, src.BaseModelType)
+	fillZero(&dst.CreatedAt, src.CreatedAt)
+	fillZero(&dst.UpdatedAt, src.UpdatedAt)
+	fillZero(&dst.PublishedAt, src.PublishedAt)
+	fillEmpty(&dst.TrainedWords, src.TrainedWords)
+	fillEmpty(&dst.Files, src.Files)
+	fillEmpty(&dst.Images, src.Images)
+	fillZero(&dst.DownloadURL, src.DownloadURL)
+	fillZero(&dst.EarlyAccessTimeFrame, src.EarlyAccessTimeFrame)
+	fillStats(&dst.Stats, src.Stats)
+	fillZero(&dst.Availability, src.Availability)
+}
+
+// fillStats copies each of src's counts into dst where dst's is zero
+func fillStats(dst *Stats, src Stats) {
+	fillZero(&dst.DownloadCount, src.DownloadCount)
+	fillZero(&dst.FavoriteCount, src.FavoriteCount)
+	fillZero(&dst.CommentCount, src.CommentCount)
+	fillZero(&dst.RatingCount, src.RatingCount)
+	fillZero(&dst.Rating, src.Rating)
+	fillZero(&dst.ThumbsUpCount, src.ThumbsUpCount)
+	fillZero(&dst.ThumbsDownCount, src.ThumbsDownCount)
+}
+
+// fillZero sets *dst to src when *dst is the zero value
+func fillZero[T comparable](dst *T, src T) {
+	var zero T
+	if *dst == zero {
+		*dst = src
+	}
+}
+
+// fillEmpty sets *dst to src when *dst has no elements
+func fillEmpty[S ~[]E, E any](dst *S, src S) {
+	if len(*dst) == 0 {
+		*dst = src
+	}
+}
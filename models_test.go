@@ -23,6 +23,14 @@ SOFTWARE.
 package civitai
 
 import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -133,19 +141,19 @@ func TestSortModels(t *testing.T) {
 			ID:        1,
 			Name:      "Model A",
 			Stats:     Stats{Rating: 3.5, DownloadCount: 100, ThumbsUpCount: 50},
-			CreatedAt: now.Add(-time.Hour),
+			CreatedAt: CivitaiTime{now.Add(-time.Hour)},
 		},
 		{
 			ID:        2,
 			Name:      "Model B",
 			Stats:     Stats{Rating: 4.5, DownloadCount: 200, ThumbsUpCount: 30},
-			CreatedAt: now,
+			CreatedAt: CivitaiTime{now},
 		},
 		{
 			ID:        3,
 			Name:      "Model C",
 			Stats:     Stats{Rating: 4.0, DownloadCount: 150, ThumbsUpCount: 80},
-			CreatedAt: now.Add(-30 * time.Minute),
+			CreatedAt: CivitaiTime{now.Add(-30 * time.Minute)},
 		},
 	}
 
@@ -179,6 +187,33 @@ func TestSortModels(t *testing.T) {
 		}
 	})
 
+	t.Run("Ties ordered by ID", func(t *testing.T) {
+		created := CivitaiTime{now}
+		tied := []Model{
+			{ID: 5, Stats: Stats{Rating: 4, DownloadCount: 10, ThumbsUpCount: 1}, CreatedAt: created},
+			{ID: 3, Stats: Stats{Rating: 4, DownloadCount: 10, ThumbsUpCount: 1}, CreatedAt: created},
+			{ID: 9, Stats: Stats{Rating: 5, DownloadCount: 20, ThumbsUpCount: 2}, CreatedAt: CivitaiTime{now.Add(time.Hour)}},
+			{ID: 1, Stats: Stats{Rating: 4, DownloadCount: 10, ThumbsUpCount: 1}, CreatedAt: created},
+			{ID: 4, Stats: Stats{Rating: 4, DownloadCount: 10, ThumbsUpCount: 1}, CreatedAt: created},
+		}
+
+		for _, sortBy := range []SortType{SortHighestRated, SortMostDownload, SortMostLiked, SortNewest} {
+			sorted := SortModels(tied, sortBy)
+			var ids []int
+			for _, model := range sorted {
+				ids = append(ids, model.ID)
+			}
+			if fmt.Sprint(ids) != "[9 1 3 4 5]" {
+				t.Errorf("Expected [9 1 3 4 5] for %s, got %v", sortBy, ids)
+			}
+		}
+
+		sorted := SortModels(tied, SortOldest)
+		if sorted[0].ID != 1 || sorted[4].ID != 9 {
+			t.Errorf("Expected oldest ties by ID then model 9, got %v", sorted)
+		}
+	})
+
 	t.Run("Empty models slice", func(t *testing.T) {
 		sorted := SortModels([]Model{}, SortHighestRated)
 
@@ -188,6 +223,73 @@ func TestSortModels(t *testing.T) {
 	})
 }
 
+func TestMergeModels(t *testing.T) {
+	ids := func(models []Model) []int {
+		var result []int
+		for _, m := range models {
+			result = append(result, m.ID)
+		}
+		return result
+	}
+
+	t.Run("Overlapping sets", func(t *testing.T) {
+		byQuery := []Model{{ID: 1, Name: "A"}, {ID: 2, Name: "B"}}
+		byTag := []Model{{ID: 2, Name: "B"}, {ID: 3, Name: "C"}, {ID: 1, Name: "A"}}
+
+		merged := MergeModels(byQuery, byTag)
+		if got := ids(merged); !reflect.DeepEqual(got, []int{1, 2, 3}) {
+			t.Errorf("Expected IDs [1 2 3], got %v", got)
+		}
+	})
+
+	t.Run("Conflicting duplicates keep the richest record", func(t *testing.T) {
+		sparse := Model{ID: 1, Name: "sparse", ModelVersions: []ModelVersion{{ID: 10}}, Stats: Stats{DownloadCount: 900}}
+		full := Model{ID: 1, Name: "full", ModelVersions: []ModelVersion{{ID: 10}, {ID: 11}}, Stats: Stats{DownloadCount: 100}}
+		stale := Model{ID: 2, Name: "stale", Stats: Stats{DownloadCount: 50, RatingCount: 9}}
+		fresh := Model{ID: 2, Name: "fresh", Stats: Stats{DownloadCount: 60}}
+		older := Model{ID: 3, Name: "older", Stats: Stats{DownloadCount: 5, RatingCount: 1}}
+		newer := Model{ID: 3, Name: "newer", Stats: Stats{DownloadCount: 5, RatingCount: 2}}
+		first := Model{ID: 4, Name: "first"}
+		second := Model{ID: 4, Name: "second"}
+
+		merged := MergeModels(
+			[]Model{sparse, fresh, older, first},
+			[]Model{full, stale, newer, second},
+		)
+
+		expected := map[int]string{1: "full", 2: "fresh", 3: "newer", 4: "first"}
+		if len(merged) != len(expected) {
+			t.Fatalf("Expected %d models, got %d", len(expected), len(merged))
+		}
+		for _, m := range merged {
+			if m.Name != expected[m.ID] {
+				t.Errorf("Expected model %d to be %s, got %s", m.ID, expected[m.ID], m.Name)
+			}
+		}
+		if got := ids(merged); !reflect.DeepEqual(got, []int{1, 2, 3, 4}) {
+			t.Errorf("Expected first-seen order [1 2 3 4], got %v", got)
+		}
+	})
+
+	t.Run("Inputs are not modified", func(t *testing.T) {
+		a := []Model{{ID: 1, Name: "a"}}
+		b := []Model{{ID: 1, Name: "b", ModelVersions: []ModelVersion{{ID: 1}}}}
+		MergeModels(a, b)
+		if a[0].Name != "a" || b[0].Name != "b" {
+			t.Error("Expected input slices to be unchanged")
+		}
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		if merged := MergeModels(); len(merged) != 0 {
+			t.Errorf("Expected no models, got %d", len(merged))
+		}
+		if merged := MergeModels(nil, []Model{}); len(merged) != 0 {
+			t.Errorf("Expected no models, got %d", len(merged))
+		}
+	})
+}
+
 func TestModelMethods(t *testing.T) {
 	model := Model{
 		ID:   1,
@@ -203,7 +305,7 @@ func TestModelMethods(t *testing.T) {
 			{
 				ID:        1,
 				Name:      "Version 1.0",
-				CreatedAt: time.Now().Add(-time.Hour),
+				CreatedAt: CivitaiTime{time.Now().Add(-time.Hour)},
 				Files: []File{
 					{ID: 1, Primary: true, SizeKB: 1000},
 					{ID: 2, Primary: false, SizeKB: 500},
@@ -212,7 +314,7 @@ func TestModelMethods(t *testing.T) {
 			{
 				ID:        2,
 				Name:      "Version 2.0",
-				CreatedAt: time.Now(),
+				CreatedAt: CivitaiTime{time.Now()},
 				Files: []File{
 					{ID: 3, Primary: true, SizeKB: 1200},
 				},
@@ -267,11 +369,16 @@ func TestModelMethods(t *testing.T) {
 
 	t.Run("GetModelSummary", func(t *testing.T) {
 		summary := model.GetModelSummary()
-		expected := "Test Model (Checkpoint) - 1000 downloads, 4.5 rating, 2 versions"
+		expected := "Test Model (Checkpoint) - 1000 downloads, 4.5 rating, 2 versions, latest 1.2 MB"
 
 		if summary != expected {
 			t.Errorf("Expected '%s', got '%s'", expected, summary)
 		}
+
+		bare := &Model{Name: "Bare", Type: ModelTypeLORA}
+		if summary := bare.GetModelSummary(); summary != "Bare (LORA) - 0 downloads, 0.0 rating, 0 versions" {
+			t.Errorf("Expected summary without size for a model without files, got '%s'", summary)
+		}
 	})
 }
 
@@ -287,12 +394,14 @@ func TestModelVersionMethods(t *testing.T) {
 				Primary:  true,
 				SizeKB:   1024,
 				Metadata: FileMetadata{Format: FileFormatSafeTensors},
+				Hashes:   Hashes{AutoV2: "AB12CD34EF", SHA256: "AB12CD34EF56AB12CD34EF56AB12CD34EF56AB12CD34EF56AB12CD34EF56AB12"},
 			},
 			{
 				ID:       2,
 				Primary:  false,
 				SizeKB:   512,
 				Metadata: FileMetadata{Format: FileFormatPickleTensor},
+				Hashes:   Hashes{AutoV2: "9988776655", SHA256: "9988776655443322110099887766554433221100998877665544332211009988"},
 			},
 		},
 		Images: []Image{
@@ -354,6 +463,25 @@ func TestModelVersionMethods(t *testing.T) {
 		}
 	})
 
+	t.Run("GetFileByHash", func(t *testing.T) {
+		file := version.GetFileByHash("9988776655443322110099887766554433221100998877665544332211009988")
+		if file == nil || file.ID != 2 {
+			t.Errorf("Expected file ID 2 for SHA256 match, got %v", file)
+		}
+
+		file = version.GetFileByHash("ab12cd34ef")
+		if file == nil || file.ID != 1 {
+			t.Errorf("Expected file ID 1 for case-insensitive AutoV2 match, got %v", file)
+		}
+
+		if file := version.GetFileByHash("0000000000"); file != nil {
+			t.Errorf("Expected nil for unknown hash, got file %d", file.ID)
+		}
+		if file := version.GetFileByHash(""); file != nil {
+			t.Errorf("Expected nil for empty hash, got file %d", file.ID)
+		}
+	})
+
 	t.Run("GetDownloadSize", func(t *testing.T) {
 		size := version.GetDownloadSize()
 		expected := 1024.0 + 512.0
@@ -382,7 +510,7 @@ func TestModelVersionMethods(t *testing.T) {
 		now := time.Now()
 		earlyVersion := ModelVersion{
 			EarlyAccessTimeFrame: 24, // 24 hours
-			PublishedAt:          &now,
+			PublishedAt:          &CivitaiTime{now},
 		}
 		if !earlyVersion.IsEarlyAccess() {
 			t.Error("Expected early access")
@@ -392,7 +520,7 @@ func TestModelVersionMethods(t *testing.T) {
 		pastTime := now.Add(-48 * time.Hour)
 		expiredVersion := ModelVersion{
 			EarlyAccessTimeFrame: 24,
-			PublishedAt:          &pastTime,
+			PublishedAt:          &CivitaiTime{pastTime},
 		}
 		if expiredVersion.IsEarlyAccess() {
 			t.Error("Expected early access to be expired")
@@ -408,3 +536,654 @@ func TestModelVersionMethods(t *testing.T) {
 		}
 	})
 }
+
+func TestGetVersionByName(t *testing.T) {
+	model := &Model{
+		ModelVersions: []ModelVersion{
+			{ID: 3, Name: "v3.0 Final"},
+			{ID: 2, Name: "v2.0"},
+			{ID: 1, Name: "V2.0"},
+			{ID: 0, Name: "Beta"},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		query      string
+		expectedID int
+		found      bool
+	}{
+		{"Exact match", "Beta", 0, true},
+		{"Case mismatch", "V3.0 FINAL", 3, true},
+		{"Surrounding whitespace", "  beta ", 0, true},
+		{"Duplicate names return first", "v2.0", 2, true},
+		{"Not found", "v4.0", 0, false},
+		{"Partial name", "v3.0", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			version := model.GetVersionByName(tt.query)
+			if !tt.found {
+				if version != nil {
+					t.Errorf("Expected nil, got version %d", version.ID)
+				}
+				return
+			}
+			if version == nil {
+				t.Fatal("Expected a version, got nil")
+			}
+			if version.ID != tt.expectedID {
+				t.Errorf("Expected version %d, got %d", tt.expectedID, version.ID)
+			}
+		})
+	}
+
+	t.Run("Returns pointer into model", func(t *testing.T) {
+		if version := model.GetVersionByName("beta"); version != &model.ModelVersions[3] {
+			t.Error("Expected pointer to the model's version")
+		}
+	})
+
+	t.Run("No versions", func(t *testing.T) {
+		if version := (&Model{}).GetVersionByName("v1"); version != nil {
+			t.Error("Expected nil for model without versions")
+		}
+	})
+}
+
+func TestModelProject(t *testing.T) {
+	published := CivitaiTime{time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	model := &Model{
+		ID:                    42,
+		Name:                  "Projected",
+		Description:           "A long description",
+		Type:                  ModelTypeLORA,
+		POI:                   true,
+		NSFW:                  true,
+		AllowNoCredit:         true,
+		AllowCommercialUse:    FlexibleStringSlice{"Image"},
+		AllowDerivatives:      true,
+		AllowDifferentLicense: true,
+		Stats:                 Stats{DownloadCount: 100},
+		Creator:               User{Username: "creator"},
+		Tags:                  []string{"anime"},
+		ModelVersions:         []ModelVersion{{ID: 1}, {ID: 2}},
+		Images:                []Image{{ID: 7, URL: "https://example.com/7.jpeg"}},
+		CreatedAt:             published,
+		UpdatedAt:             published,
+		PublishedAt:           &published,
+	}
+
+	t.Run("Keeps requested fields", func(t *testing.T) {
+		projected := model.Project("name", "Images")
+
+		if projected.ID != 42 {
+			t.Errorf("Expected ID 42 to be kept, got %d", projected.ID)
+		}
+		if projected.Name != "Projected" {
+			t.Errorf("Expected name Projected, got %q", projected.Name)
+		}
+		if len(projected.Images) != 1 {
+			t.Errorf("Expected 1 image, got %d", len(projected.Images))
+		}
+		if projected.ModelVersions != nil || projected.Description != "" || projected.Stats.DownloadCount != 0 {
+			t.Errorf("Expected unrequested fields to be zero, got %+v", projected)
+		}
+	})
+
+	t.Run("All fields", func(t *testing.T) {
+		// Every JSON field name must be projectable
+		var fields []string
+		modelType := reflect.TypeOf(Model{})
+		for i := 0; i < modelType.NumField(); i++ {
+			fields = append(fields, strings.Split(modelType.Field(i).Tag.Get("json"), ",")[0])
+		}
+
+		if projected := model.Project(fields...); !reflect.DeepEqual(projected, *model) {
+			t.Errorf("Expected full projection to equal the model, got %+v", projected)
+		}
+	})
+
+	t.Run("Unknown fields", func(t *testing.T) {
+		if projected := model.Project("bogus"); !reflect.DeepEqual(projected, Model{ID: 42}) {
+			t.Errorf("Expected only the ID, got %+v", projected)
+		}
+	})
+}
+
+func TestModelSameAs(t *testing.T) {
+	updated := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	model := &Model{ID: 1, Name: "Model", UpdatedAt: CivitaiTime{updated}}
+
+	t.Run("Identical", func(t *testing.T) {
+		other := &Model{ID: 1, Name: "Model", UpdatedAt: CivitaiTime{updated}}
+		if !model.SameAs(other) {
+			t.Error("Expected identical models to be the same")
+		}
+		if model.ContentHash() != other.ContentHash() {
+			t.Error("Expected identical models to have equal content hashes")
+		}
+	})
+
+	t.Run("Same ID different update time", func(t *testing.T) {
+		other := &Model{ID: 1, Name: "Model", UpdatedAt: CivitaiTime{updated.Add(time.Hour)}}
+		if model.SameAs(other) {
+			t.Error("Expected models with different update times to differ")
+		}
+	})
+
+	t.Run("Different ID", func(t *testing.T) {
+		other := &Model{ID: 2, Name: "Model", UpdatedAt: CivitaiTime{updated}}
+		if model.SameAs(other) {
+			t.Error("Expected models with different IDs to differ")
+		}
+		if model.ContentHash() == other.ContentHash() {
+			t.Error("Expected models with different IDs to have different content hashes")
+		}
+	})
+
+	t.Run("Nil handling", func(t *testing.T) {
+		var nilModel *Model
+		if model.SameAs(nil) {
+			t.Error("Expected model not to be the same as nil")
+		}
+		if !nilModel.SameAs(nil) {
+			t.Error("Expected nil to be the same as nil")
+		}
+	})
+
+	t.Run("Content hash ignores stats", func(t *testing.T) {
+		other := *model
+		other.Stats.DownloadCount = 1000
+		if model.ContentHash() != other.ContentHash() {
+			t.Error("Expected stats changes not to affect the content hash")
+		}
+
+		other.ModelVersions = []ModelVersion{{ID: 10, Name: "v2"}}
+		if model.ContentHash() == other.ContentHash() {
+			t.Error("Expected a new version to change the content hash")
+		}
+	})
+}
+
+func TestLatestPrimaryFileSHA256(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name         string
+		model        Model
+		expectedHash string
+		expectedOK   bool
+	}{
+		{
+			name: "Primary file of latest version",
+			model: Model{ModelVersions: []ModelVersion{
+				{ID: 1, CreatedAt: CivitaiTime{now.Add(-48 * time.Hour)}, Files: []File{
+					{Primary: true, Hashes: Hashes{SHA256: "OLDHASH"}},
+				}},
+				{ID: 2, CreatedAt: CivitaiTime{now}, Files: []File{
+					{Name: "vae.pt", Hashes: Hashes{SHA256: "VAEHASH"}},
+					{Name: "model.safetensors", Primary: true, Hashes: Hashes{SHA256: "abc123def456"}},
+				}},
+			}},
+			expectedHash: "ABC123DEF456",
+			expectedOK:   true,
+		},
+		{
+			name: "First file when none is primary",
+			model: Model{ModelVersions: []ModelVersion{
+				{ID: 1, Files: []File{{Hashes: Hashes{SHA256: "FIRSTHASH"}}}},
+			}},
+			expectedHash: "FIRSTHASH",
+			expectedOK:   true,
+		},
+		{
+			name: "Primary file without SHA256",
+			model: Model{ModelVersions: []ModelVersion{
+				{ID: 1, Files: []File{{Primary: true, Hashes: Hashes{AutoV2: "AUTOV2"}}}},
+			}},
+		},
+		{
+			name:  "No files",
+			model: Model{ModelVersions: []ModelVersion{{ID: 1}}},
+		},
+		{
+			name: "No versions",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hash, ok := tt.model.LatestPrimaryFileSHA256()
+			if ok != tt.expectedOK {
+				t.Errorf("Expected ok %v, got %v", tt.expectedOK, ok)
+			}
+			if hash != tt.expectedHash {
+				t.Errorf("Expected hash %q, got %q", tt.expectedHash, hash)
+			}
+		})
+	}
+}
+
+func TestWatchModel(t *testing.T) {
+	// Poll responses in order: baseline, unchanged, updated, new version, then repeated
+	responses := []string{
+		`{"id": 1, "updatedAt": "2024-01-01T00:00:00Z", "modelVersions": [{"id": 10}]}`,
+		`{"id": 1, "updatedAt": "2024-01-01T00:00:00Z", "modelVersions": [{"id": 10}]}`,
+		`{"id": 1, "updatedAt": "2024-02-01T00:00:00Z", "modelVersions": [{"id": 10}]}`,
+		`{"id": 1, "updatedAt": "2024-02-01T00:00:00Z", "modelVersions": [{"id": 11, "createdAt": "2024-02-02T00:00:00Z"}, {"id": 10}]}`,
+	}
+
+	var polls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(atomic.AddInt32(&polls, 1)) - 1
+		if n == 2 {
+			// A failed poll in between should not trigger a notification
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		if n > 2 {
+			n--
+		}
+		if n >= len(responses) {
+			n = len(responses) - 1
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, responses[n])
+	}))
+	defer server.Close()
+
+	client := NewClientWithoutAuth(WithBaseURL(server.URL), WithRetryConfig(0, 0, 0))
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	type change struct{ old, new *Model }
+	var changes []change
+	err := client.WatchModel(ctx, 1, 5*time.Millisecond, func(old, new *Model) {
+		changes = append(changes, change{old, new})
+		if len(changes) == 2 {
+			cancel()
+		}
+	})
+	if err != nil {
+		t.Fatalf("WatchModel failed: %v", err)
+	}
+
+	if len(changes) != 2 {
+		t.Fatalf("Expected 2 changes, got %d", len(changes))
+	}
+	if !changes[0].new.UpdatedAt.After(changes[0].old.UpdatedAt.Time) {
+		t.Errorf("Expected first change to be an update, got %v -> %v", changes[0].old.UpdatedAt, changes[0].new.UpdatedAt)
+	}
+	if latest := changes[1].new.GetLatestVersion(); latest == nil || latest.ID != 11 {
+		t.Errorf("Expected second change to add version 11, got %+v", latest)
+	}
+
+	t.Run("Initial fetch failure", func(t *testing.T) {
+		failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer failing.Close()
+
+		client := NewClientWithoutAuth(WithBaseURL(failing.URL), WithRetryConfig(0, 0, 0))
+		err := client.WatchModel(context.Background(), 1, time.Millisecond, func(old, new *Model) {})
+		if err == nil {
+			t.Error("Expected error when the initial fetch fails")
+		}
+	})
+
+	t.Run("Invalid interval", func(t *testing.T) {
+		if err := client.WatchModel(context.Background(), 1, 0, func(old, new *Model) {}); err == nil {
+			t.Error("Expected error for zero interval")
+		}
+	})
+}
+
+func TestModelNSFWLevel(t *testing.T) {
+	tests := []struct {
+		name     string
+		model    Model
+		expected NSFWLevel
+	}{
+		{
+			name:     "No images",
+			model:    Model{},
+			expected: NSFWLevelNone,
+		},
+		{
+			name:     "Safe images",
+			model:    Model{Images: []Image{{NSFW: "None"}, {NSFW: "None"}}},
+			expected: NSFWLevelNone,
+		},
+		{
+			name:     "Most explicit model image wins",
+			model:    Model{NSFW: true, Images: []Image{{NSFW: "Soft"}, {NSFW: "Mature"}, {NSFW: "None"}}},
+			expected: NSFWLevelMature,
+		},
+		{
+			name: "Version images are considered",
+			model: Model{NSFW: true, ModelVersions: []ModelVersion{
+				{Images: []Image{{NSFW: "Soft"}}},
+				{Images: []Image{{NSFW: "X"}}},
+			}},
+			expected: NSFWLevelX,
+		},
+		{
+			name:     "Unknown levels are ignored",
+			model:    Model{Images: []Image{{NSFW: "Unrated"}, {NSFW: ""}, {NSFW: "Soft"}}},
+			expected: NSFWLevelSoft,
+		},
+		{
+			name:     "NSFW flag without rated images",
+			model:    Model{NSFW: true},
+			expected: NSFWLevelSoft,
+		},
+		{
+			name:     "NSFW flag with safe images",
+			model:    Model{NSFW: true, Images: []Image{{NSFW: "None"}}},
+			expected: NSFWLevelSoft,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if level := tt.model.NSFWLevel(); level != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, level)
+			}
+		})
+	}
+}
+
+func TestModelContentRating(t *testing.T) {
+	tests := []struct {
+		name     string
+		model    Model
+		expected NSFWLevel
+	}{
+		{
+			name:     "No signals",
+			model:    Model{Images: []Image{{NSFW: "None"}}},
+			expected: NSFWLevelNone,
+		},
+		{
+			name:     "NSFW flag without rated images",
+			model:    Model{NSFW: true},
+			expected: NSFWLevelSoft,
+		},
+		{
+			name: "Most explicit image wins over the flag",
+			model: Model{NSFW: true, Images: []Image{{NSFW: "Soft"}}, ModelVersions: []ModelVersion{
+				{Images: []Image{{NSFW: "Mature"}}},
+			}},
+			expected: NSFWLevelMature,
+		},
+		{
+			name:     "POI raises a safe model",
+			model:    Model{POI: true, Images: []Image{{NSFW: "None"}}},
+			expected: NSFWLevelSoft,
+		},
+		{
+			name:     "POI raises a flagged model",
+			model:    Model{POI: true, NSFW: true},
+			expected: NSFWLevelMature,
+		},
+		{
+			name:     "POI raises mature images",
+			model:    Model{POI: true, Images: []Image{{NSFW: "Soft"}, {NSFW: "Mature"}}},
+			expected: NSFWLevelX,
+		},
+		{
+			name:     "POI caps at X",
+			model:    Model{POI: true, Images: []Image{{NSFW: "X"}}},
+			expected: NSFWLevelX,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if rating := tt.model.ContentRating(); rating != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, rating)
+			}
+		})
+	}
+}
+
+func TestModelAllFiles(t *testing.T) {
+	model := Model{ModelVersions: []ModelVersion{
+		{ID: 3, Files: []File{{ID: 31, Name: "v3.safetensors", Primary: true}, {ID: 32, Name: "v3.pt"}}},
+		{ID: 2},
+		{ID: 1, Files: []File{{ID: 11, Name: "v1.ckpt"}, {ID: 12, Name: "v1.safetensors", Primary: true}, {ID: 13, Name: "v1.yaml"}}},
+	}}
+
+	t.Run("AllFiles keeps version then file order", func(t *testing.T) {
+		files := model.AllFiles()
+		expected := []int{31, 32, 11, 12, 13}
+		if len(files) != len(expected) {
+			t.Fatalf("Expected %d files, got %d", len(expected), len(files))
+		}
+		for i, id := range expected {
+			if files[i].ID != id {
+				t.Errorf("File %d: expected ID %d, got %d", i, id, files[i].ID)
+			}
+		}
+	})
+
+	t.Run("AllPrimaryFiles returns one file per version with files", func(t *testing.T) {
+		files := model.AllPrimaryFiles()
+		if len(files) != 2 {
+			t.Fatalf("Expected 2 primary files, got %d", len(files))
+		}
+		if files[0].ID != 31 || files[1].ID != 12 {
+			t.Errorf("Expected primary files 31 and 12, got %d and %d", files[0].ID, files[1].ID)
+		}
+	})
+
+	t.Run("Model without versions", func(t *testing.T) {
+		empty := Model{}
+		if files := empty.AllFiles(); len(files) != 0 {
+			t.Errorf("Expected no files, got %d", len(files))
+		}
+		if files := empty.AllPrimaryFiles(); len(files) != 0 {
+			t.Errorf("Expected no primary files, got %d", len(files))
+		}
+	})
+}
+
+func TestFillModel(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{
+			"id": 42, "name": "Detail Name", "description": "Full description", "type": "LORA",
+			"stats": {"downloadCount": 100, "favoriteCount": 7, "rating": 4.5},
+			"creator": {"username": "artist", "image": "https://example.com/a.png"},
+			"tags": ["anime"],
+			"modelVersions": [
+				{"id": 1, "name": "v1 detail", "baseModel": "SDXL 1.0", "trainedWords": ["trigger"],
+				 "files": [{"name": "v1.safetensors", "primary": true}]},
+				{"id": 2, "name": "v2"}
+			],
+			"createdAt": "2024-01-01T00:00:00Z"
+		}`))
+	}))
+	defer server.Close()
+
+	client := NewClientWithoutAuth(WithBaseURL(server.URL))
+	partial := Model{
+		ID:    42,
+		Name:  "Search Name",
+		Stats: Stats{DownloadCount: 150},
+		ModelVersions: []ModelVersion{
+			{ID: 1, Name: "v1", Files: []File{{Name: "search.safetensors"}}},
+		},
+	}
+
+	if err := client.FillModel(context.Background(), &partial); err != nil {
+		t.Fatalf("FillModel failed: %v", err)
+	}
+
+	if partial.Name != "Search Name" {
+		t.Errorf("Expected existing name to be kept, got %q", partial.Name)
+	}
+	if partial.Description != "Full description" || partial.Type != ModelTypeLORA {
+		t.Errorf("Expected description and type to be filled, got %q and %q", partial.Description, partial.Type)
+	}
+	if partial.Stats.DownloadCount != 150 || partial.Stats.FavoriteCount != 7 || partial.Stats.Rating != 4.5 {
+		t.Errorf("Expected stats merged field by field, got %+v", partial.Stats)
+	}
+	if partial.Creator.Username != "artist" || len(partial.Tags) != 1 || partial.CreatedAt.IsZero() {
+		t.Errorf("Expected creator, tags, and creation date to be filled, got %+v", partial)
+	}
+
+	if len(partial.ModelVersions) != 1 {
+		t.Fatalf("Expected existing versions to be kept, got %d", len(partial.ModelVersions))
+	}
+	version := partial.ModelVersions[0]
+	if version.Name != "v1" || version.Files[0].Name != "search.safetensors" {
+		t.Errorf("Expected existing version fields to be kept, got %q and %q", version.Name, version.Files[0].Name)
+	}
+	if version.BaseModel != "SDXL 1.0" || len(version.TrainedWords) != 1 {
+		t.Errorf("Expected version to be filled from the matching detail version, got %+v", version)
+	}
+
+	t.Run("Empty versions take detail versions", func(t *testing.T) {
+		bare := Model{ID: 42}
+		if err := client.FillModel(context.Background(), &bare); err != nil {
+			t.Fatalf("FillModel failed: %v", err)
+		}
+		if len(bare.ModelVersions) != 2 {
+			t.Errorf("Expected 2 versions, got %d", len(bare.ModelVersions))
+		}
+	})
+
+	t.Run("Nil model", func(t *testing.T) {
+		if err := client.FillModel(context.Background(), nil); err == nil {
+			t.Error("Expected error for nil model")
+		}
+	})
+}
+
+func TestTrimVersions(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	var versionsJSON []string
+	var versions []ModelVersion
+	for i := 1; i <= 50; i++ {
+		created := base.AddDate(0, 0, i)
+		versions = append(versions, ModelVersion{ID: i, CreatedAt: CivitaiTime{created}})
+		versionsJSON = append(versionsJSON, fmt.Sprintf(`{"id": %d, "createdAt": %q}`, i, created.Format(time.RFC3339)))
+	}
+
+	t.Run("Keeps most recent in original order", func(t *testing.T) {
+		model := Model{ModelVersions: []ModelVersion{versions[4], versions[49], versions[0], versions[48], versions[47]}}
+		model.TrimVersions(3)
+
+		var ids []int
+		for _, v := range model.ModelVersions {
+			ids = append(ids, v.ID)
+		}
+		if !reflect.DeepEqual(ids, []int{50, 49, 48}) {
+			t.Errorf("Expected [50 49 48], got %v", ids)
+		}
+	})
+
+	t.Run("Non-positive and short lists unchanged", func(t *testing.T) {
+		model := Model{ModelVersions: versions[:5]}
+		model.TrimVersions(0)
+		model.TrimVersions(10)
+		if len(model.ModelVersions) != 5 {
+			t.Errorf("Expected 5 versions, got %d", len(model.ModelVersions))
+		}
+	})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		model := fmt.Sprintf(`{"id": 1, "name": "Many", "modelVersions": [%s]}`, strings.Join(versionsJSON, ","))
+		if strings.HasSuffix(r.URL.Path, "/models") {
+			fmt.Fprintf(w, `{"items": [%s], "metadata": {}}`, model)
+			return
+		}
+		w.Write([]byte(model))
+	}))
+	defer server.Close()
+
+	client := NewClientWithoutAuth(WithBaseURL(server.URL), WithMaxModelVersionsInline(5))
+	ctx := context.Background()
+
+	model, err := client.GetModel(ctx, 1)
+	if err != nil {
+		t.Fatalf("GetModel failed: %v", err)
+	}
+	if len(model.ModelVersions) != 5 || model.GetLatestVersion().ID != 50 {
+		t.Errorf("Expected 5 versions ending at 50, got %d", len(model.ModelVersions))
+	}
+
+	models, _, err := client.SearchModels(ctx, SearchParams{})
+	if err != nil {
+		t.Fatalf("SearchModels failed: %v", err)
+	}
+	if len(models) != 1 || len(models[0].ModelVersions) != 5 {
+		t.Errorf("Expected 1 model with 5 versions, got %+v", models)
+	}
+	if models[0].ModelVersions[0].ID != 46 {
+		t.Errorf("Expected oldest kept version 46, got %d", models[0].ModelVersions[0].ID)
+	}
+
+	untrimmed, err := NewClientWithoutAuth(WithBaseURL(server.URL)).GetModel(ctx, 1)
+	if err != nil {
+		t.Fatalf("GetModel failed: %v", err)
+	}
+	if len(untrimmed.ModelVersions) != 50 {
+		t.Errorf("Expected all 50 versions by default, got %d", len(untrimmed.ModelVersions))
+	}
+}
+
+func TestQualityScore(t *testing.T) {
+	empty := Model{}
+	newcomer := Model{Stats: Stats{Rating: 5, RatingCount: 1, ThumbsUpCount: 2, DownloadCount: 10}}
+	established := Model{Stats: Stats{Rating: 4.8, RatingCount: 900, ThumbsUpCount: 5000, ThumbsDownCount: 50, DownloadCount: 400000}}
+	divisive := Model{Stats: Stats{Rating: 2.5, RatingCount: 900, ThumbsUpCount: 2500, ThumbsDownCount: 2500, DownloadCount: 400000}}
+	huge := Model{Stats: Stats{Rating: 5, RatingCount: 1e6, ThumbsUpCount: 1e7, DownloadCount: 1e9}}
+
+	t.Run("Range and zero stats", func(t *testing.T) {
+		if got := empty.QualityScore(); got != 0 {
+			t.Errorf("Expected 0 for a model without stats, got %v", got)
+		}
+		for _, model := range []Model{newcomer, established, divisive, huge} {
+			if got := model.QualityScore(); got < 0 || got > 1 || math.IsNaN(got) {
+				t.Errorf("Expected score in [0, 1], got %v for %+v", got, model.Stats)
+			}
+		}
+		if got := huge.QualityScore(); got < 0.95 {
+			t.Errorf("Expected near-perfect score for saturated stats, got %v", got)
+		}
+	})
+
+	t.Run("Ordering", func(t *testing.T) {
+		if established.QualityScore() <= newcomer.QualityScore() {
+			t.Errorf("Expected established model to outrank a perfect rating backed by one vote")
+		}
+		if established.QualityScore() <= divisive.QualityScore() {
+			t.Errorf("Expected well-liked model to outrank a divisive one with the same downloads")
+		}
+	})
+
+	t.Run("Custom weights", func(t *testing.T) {
+		downloadsOnly := WithQualityScoreWeights(QualityScoreWeights{Downloads: 1})
+		want := math.Log1p(400000) / math.Log1p(1_000_000)
+		if got := divisive.QualityScore(downloadsOnly); math.Abs(got-want) > 1e-9 {
+			t.Errorf("Expected %v, got %v", want, got)
+		}
+		if got := established.QualityScore(downloadsOnly); got != divisive.QualityScore(downloadsOnly) {
+			t.Errorf("Expected equal download-only scores, got %v", got)
+		}
+
+		ratingOnly := WithQualityScoreWeights(QualityScoreWeights{Rating: 2, Downloads: -1})
+		if got := newcomer.QualityScore(ratingOnly); got != 1 {
+			t.Errorf("Expected negative weights to be ignored and rating 5 to score 1, got %v", got)
+		}
+
+		if got := established.QualityScore(WithQualityScoreWeights(QualityScoreWeights{})); got != 0 {
+			t.Errorf("Expected 0 with all-zero weights, got %v", got)
+		}
+	})
+}
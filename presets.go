@@ -0,0 +1,74 @@
+/*
+Copyright (c) 2025 Regi Ellis
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+// Package civitai - Safe Browsing Presets
+//
+// This file provides pre-filled parameter presets for integrations that want
+// safe-for-work results without assembling filters by hand.
+//
+// # Model Presets
+//
+//	params := civitai.SafeBrowsingParams()
+//	params.Query = "landscape"
+//	models, _, err := client.SearchModels(ctx, params)
+//
+// # Image Presets
+//
+//	images, _, err := client.GetImages(ctx, civitai.SFWImageParams())
+//
+// Presets return a fresh value on every call, so callers may modify the result freely.
+
+package civitai
+
+// DefaultPresetLimit is the page size used by the browsing presets
+const DefaultPresetLimit = 20
+
+// SafeBrowsingParams returns SearchParams for safe-for-work model browsing.
+// It sets exactly:
+//   - NSFW: false (excludes models flagged NSFW)
+//   - Sort: SortHighestRated
+//   - Period: PeriodMonth
+//   - Limit: DefaultPresetLimit
+func SafeBrowsingParams() SearchParams {
+	nsfw := false
+	return SearchParams{
+		NSFW:   &nsfw,
+		Sort:   SortHighestRated,
+		Period: PeriodMonth,
+		Limit:  DefaultPresetLimit,
+	}
+}
+
+// SFWImageParams returns ImageParams for safe-for-work image browsing.
+// It sets exactly:
+//   - NSFW: NSFWLevelNone
+//   - Sort: ImageSortMostReactions
+//   - Period: PeriodWeek
+//   - Limit: DefaultPresetLimit
+func SFWImageParams() ImageParams {
+	return ImageParams{
+		NSFW:   string(NSFWLevelNone),
+		Sort:   string(ImageSortMostReactions),
+		Period: PeriodWeek,
+		Limit:  DefaultPresetLimit,
+	}
+}
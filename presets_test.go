@@ -0,0 +1,76 @@
+/*
+Copyright (c) 2025 Regi Ellis
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package civitai
+
+import "testing"
+
+func TestSafeBrowsingParams(t *testing.T) {
+	params := SafeBrowsingParams()
+
+	if params.NSFW == nil || *params.NSFW {
+		t.Errorf("Expected NSFW false, got %v", params.NSFW)
+	}
+	if params.Sort != SortHighestRated {
+		t.Errorf("Expected sort %s, got %s", SortHighestRated, params.Sort)
+	}
+	if params.Period != PeriodMonth {
+		t.Errorf("Expected period %s, got %s", PeriodMonth, params.Period)
+	}
+	if params.Limit != DefaultPresetLimit {
+		t.Errorf("Expected limit %d, got %d", DefaultPresetLimit, params.Limit)
+	}
+	if err := validateSearchParams(params); err != nil {
+		t.Errorf("Expected preset to pass validation, got %v", err)
+	}
+
+	query := NewClientWithoutAuth().buildSearchParams(params)
+	if query["nsfw"] != "false" {
+		t.Errorf("Expected nsfw=false query parameter, got %q", query["nsfw"])
+	}
+
+	// Each call returns an independent value
+	*params.NSFW = true
+	if *SafeBrowsingParams().NSFW {
+		t.Error("Expected presets not to share state between calls")
+	}
+}
+
+func TestSFWImageParams(t *testing.T) {
+	params := SFWImageParams()
+
+	if params.NSFW != string(NSFWLevelNone) {
+		t.Errorf("Expected NSFW %s, got %s", NSFWLevelNone, params.NSFW)
+	}
+	if params.Sort != string(ImageSortMostReactions) {
+		t.Errorf("Expected sort %s, got %s", ImageSortMostReactions, params.Sort)
+	}
+	if params.Period != PeriodWeek {
+		t.Errorf("Expected period %s, got %s", PeriodWeek, params.Period)
+	}
+	if params.Limit != DefaultPresetLimit {
+		t.Errorf("Expected limit %d, got %d", DefaultPresetLimit, params.Limit)
+	}
+	if err := NewClientWithoutAuth().validateImageParams(params); err != nil {
+		t.Errorf("Expected preset to pass validation, got %v", err)
+	}
+}
@@ -0,0 +1,297 @@
+/*
+Copyright (c) 2025 Regi Ellis
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+// Package civitai - Recommendations
+//
+// This file builds "because you liked X" recommendations on top of the search
+// API. The liked models are fetched as seeds, their tags and types are combined
+// into a profile, and the top tags are searched for candidates that are then
+// ranked client-side.
+//
+// # Recommending Models
+//
+//	recommended, err := client.RecommendForUser(ctx, []int{4201, 133005}, 20)
+//	if err != nil && len(recommended) == 0 {
+//		log.Fatal(err)
+//	}
+//	for _, model := range recommended {
+//		fmt.Println(model.Name)
+//	}
+//
+// # Ranking
+//
+// Candidates are scored by tag overlap with the liked models, weighted by how
+// many liked models share each tag, with download popularity as a smaller
+// secondary signal. Liked models are never recommended, and each model appears
+// at most once. Seeds or tag searches that fail are skipped and reported in the
+// returned error alongside the recommendations that could still be made.
+
+package civitai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const (
+	// recommendationConcurrency bounds concurrent seed and candidate requests
+	recommendationConcurrency = 4
+
+	// recommendationSeedTags is the number of profile tags searched for candidates
+	recommendationSeedTags = 5
+
+	// recommendationCandidatesPerTag is the number of models fetched per tag
+	recommendationCandidatesPerTag = 20
+
+	// recommendationPopularityWeight is the share of the score from downloads;
+	// the rest comes from tag overlap
+	recommendationPopularityWeight = 0.25
+)
+
+// RecommendForUser recommends up to limit models similar to the liked models.
+// It returns no recommendations and no error when likedModelIDs is empty.
+// Duplicate IDs are ignored. If some seeds or candidate searches fail, the
+// recommendations built from the rest are returned with an error describing
+// the failures; if every seed fails, only the error is returned.
+func (c *Client) RecommendForUser(ctx context.Context, likedModelIDs []int, limit int) ([]Model, error) {
+	if limit <= 0 {
+		return nil, fmt.Errorf("limit must be positive, got %d", limit)
+	}
+
+	liked := make(map[int]bool, len(likedModelIDs))
+	var seedIDs []int
+	for _, id := range likedModelIDs {
+		if err := validateModelID(id); err != nil {
+			return nil, fmt.Errorf("invalid model ID %d: %w", id, err)
+		}
+		if !liked[id] {
+			liked[id] = true
+			seedIDs = append(seedIDs, id)
+		}
+	}
+	if len(seedIDs) == 0 {
+		return nil, nil
+	}
+
+	seeds, seedErr := c.fetchRecommendationSeeds(ctx, seedIDs)
+	if len(seeds) == 0 {
+		return nil, fmt.Errorf("failed to fetch liked models: %w", seedErr)
+	}
+
+	tagWeights, types := recommendationProfile(seeds)
+	tags := topRecommendationTags(tagWeights, recommendationSeedTags)
+	if len(tags) == 0 {
+		return nil, seedErr
+	}
+
+	candidates, searchErr := c.searchRecommendationCandidates(ctx, tags, types)
+
+	var totalWeight float64
+	for _, weight := range tagWeights {
+		totalWeight += weight
+	}
+	var maxDownloads int
+	for _, model := range candidates {
+		maxDownloads = max(maxDownloads, model.Stats.DownloadCount)
+	}
+
+	type scoredModel struct {
+		model Model
+		score float64
+	}
+	var scored []scoredModel
+	seen := make(map[int]bool)
+	for _, model := range candidates {
+		if liked[model.ID] || seen[model.ID] {
+			continue
+		}
+		seen[model.ID] = true
+
+		var overlap float64
+		for _, tag := range uniqueLowerTags(model.Tags) {
+			overlap += tagWeights[tag]
+		}
+		var popularity float64
+		if maxDownloads > 0 {
+			popularity = math.Log1p(float64(model.Stats.DownloadCount)) / math.Log1p(float64(maxDownloads))
+		}
+
+		score := (1-recommendationPopularityWeight)*overlap/totalWeight + recommendationPopularityWeight*popularity
+		scored = append(scored, scoredModel{model: model, score: score})
+	}
+
+	sort.SliceStable(scored, func(i, j int) bool {
+		if scored[i].score != scored[j].score {
+			return scored[i].score > scored[j].score
+		}
+		return scored[i].model.ID < scored[j].model.ID
+	})
+
+	if len(scored) > limit {
+		scored = scored[:limit]
+	}
+	result := make([]Model, len(scored))
+	for i, s := range scored {
+		result[i] = s.model
+	}
+
+	if err := errors.Join(seedErr, searchErr); err != nil {
+		return result, fmt.Errorf("recommendations may be incomplete: %w", err)
+	}
+	return result, nil
+}
+
+// fetchRecommendationSeeds fetches the liked models concurrently, returning the
+// ones that loaded in input order and the joined errors of the rest
+func (c *Client) fetchRecommendationSeeds(ctx context.Context, ids []int) ([]Model, error) {
+	models := make([]*Model, len(ids))
+	errs := make([]error, len(ids))
+	sem := make(chan struct{}, recommendationConcurrency)
+	var wg sync.WaitGroup
+
+	for i, id := range ids {
+		wg.Add(1)
+		go func(i, id int) {
+			defer wg.Done()
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-ctx.Done():
+				errs[i] = fmt.Errorf("model %d: %w", id, ctx.Err())
+				return
+			}
+
+			model, err := c.GetModel(ctx, id)
+			if err != nil {
+				errs[i] = fmt.Errorf("model %d: %w", id, err)
+				return
+			}
+			models[i] = model
+		}(i, id)
+	}
+	wg.Wait()
+
+	var seeds []Model
+	for _, model := range models {
+		if model != nil {
+			seeds = append(seeds, *model)
+		}
+	}
+	return seeds, errors.Join(errs...)
+}
+
+// searchRecommendationCandidates searches each tag for popular models of the
+// given types, returning the candidates in tag order and the joined errors of
+// failed searches
+func (c *Client) searchRecommendationCandidates(ctx context.Context, tags []string, types []ModelType) ([]Model, error) {
+	perTag := make([][]Model, len(tags))
+	errs := make([]error, len(tags))
+	sem := make(chan struct{}, recommendationConcurrency)
+	var wg sync.WaitGroup
+
+	for i, tag := range tags {
+		wg.Add(1)
+		go func(i int, tag string) {
+			defer wg.Done()
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-ctx.Done():
+				errs[i] = fmt.Errorf("tag %q: %w", tag, ctx.Err())
+				return
+			}
+
+			models, _, err := c.searchModels(ctx, SearchParams{
+				Tag:   tag,
+				Types: types,
+				Sort:  SortMostDownload,
+				Limit: recommendationCandidatesPerTag,
+			})
+			if err != nil {
+				errs[i] = fmt.Errorf("tag %q: %w", tag, err)
+				return
+			}
+			perTag[i] = models
+		}(i, tag)
+	}
+	wg.Wait()
+
+	var candidates []Model
+	for _, models := range perTag {
+		candidates = append(candidates, models...)
+	}
+	return candidates, errors.Join(errs...)
+}
+
+// recommendationProfile weights each lowercased tag by the number of seeds
+// carrying it and collects the distinct seed model types
+func recommendationProfile(seeds []Model) (map[string]float64, []ModelType) {
+	weights := make(map[string]float64)
+	var types []ModelType
+	for _, seed := range seeds {
+		for _, tag := range uniqueLowerTags(seed.Tags) {
+			weights[tag]++
+		}
+		if seed.Type != "" {
+			types = appendUnique(types, []ModelType{seed.Type})
+		}
+	}
+	return weights, types
+}
+
+// topRecommendationTags returns up to n tags with the highest weights, breaking
+// ties alphabetically so results are deterministic
+func topRecommendationTags(weights map[string]float64, n int) []string {
+	tags := make([]string, 0, len(weights))
+	for tag := range weights {
+		tags = append(tags, tag)
+	}
+	sort.Slice(tags, func(i, j int) bool {
+		if weights[tags[i]] != weights[tags[j]] {
+			return weights[tags[i]] > weights[tags[j]]
+		}
+		return tags[i] < tags[j]
+	})
+	if len(tags) > n {
+		tags = tags[:n]
+	}
+	return tags
+}
+
+// uniqueLowerTags lowercases and trims tags, dropping blanks and duplicates
+func uniqueLowerTags(tags []string) []string {
+	var unique []string
+	seen := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag != "" && !seen[tag] {
+			seen[tag] = true
+			unique = append(unique, tag)
+		}
+	}
+	return unique
+}
@@ -0,0 +1,152 @@
+/*
+Copyright (c) 2025 Regi Ellis
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package civitai
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestRecommendForUser(t *testing.T) {
+	var (
+		mu         sync.Mutex
+		searchTags []string
+		searchType string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/models/1":
+			w.Write([]byte(`{"id": 1, "name": "Liked A", "type": "LORA", "tags": ["anime", "character"]}`))
+		case "/models/2":
+			w.Write([]byte(`{"id": 2, "name": "Liked B", "type": "LORA", "tags": ["Anime", "style"]}`))
+		case "/models":
+			mu.Lock()
+			searchTags = append(searchTags, r.URL.Query().Get("tag"))
+			searchType = r.URL.Query().Get("types")
+			mu.Unlock()
+
+			switch r.URL.Query().Get("tag") {
+			case "anime":
+				w.Write([]byte(`{"items": [
+					{"id": 1, "name": "Liked A", "tags": ["anime", "character"]},
+					{"id": 10, "name": "Close match", "tags": ["anime", "character"], "stats": {"downloadCount": 100}},
+					{"id": 11, "name": "Popular", "tags": ["anime"], "stats": {"downloadCount": 100000}}
+				], "metadata": {}}`))
+			case "character":
+				w.Write([]byte(`{"items": [{"id": 10, "name": "Close match", "tags": ["anime", "character"], "stats": {"downloadCount": 100}}], "metadata": {}}`))
+			case "style":
+				w.Write([]byte(`{"items": [{"id": 12, "name": "Style only", "tags": ["style"], "stats": {"downloadCount": 10}}], "metadata": {}}`))
+			default:
+				w.Write([]byte(`{"items": [], "metadata": {}}`))
+			}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	client := NewClientWithoutAuth(WithBaseURL(server.URL))
+
+	t.Run("Ranks candidates by overlap and popularity", func(t *testing.T) {
+		models, err := client.RecommendForUser(ctx, []int{1, 2, 1}, 10)
+		if err != nil {
+			t.Fatalf("RecommendForUser failed: %v", err)
+		}
+
+		var ids []int
+		for _, model := range models {
+			ids = append(ids, model.ID)
+		}
+		expected := []int{10, 11, 12}
+		if len(ids) != len(expected) {
+			t.Fatalf("Expected %v, got %v", expected, ids)
+		}
+		for i, id := range expected {
+			if ids[i] != id {
+				t.Errorf("Expected %v, got %v", expected, ids)
+				break
+			}
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		if len(searchTags) != 3 {
+			t.Errorf("Expected 3 tag searches, got %v", searchTags)
+		}
+		if searchType != "LORA" {
+			t.Errorf("Expected types LORA, got %q", searchType)
+		}
+	})
+
+	t.Run("Limit", func(t *testing.T) {
+		models, err := client.RecommendForUser(ctx, []int{1, 2}, 1)
+		if err != nil {
+			t.Fatalf("RecommendForUser failed: %v", err)
+		}
+		if len(models) != 1 || models[0].ID != 10 {
+			t.Errorf("Expected only model 10, got %v", models)
+		}
+	})
+
+	t.Run("Partial seed failure", func(t *testing.T) {
+		models, err := client.RecommendForUser(ctx, []int{1, 99}, 10)
+		if err == nil || !strings.Contains(err.Error(), "model 99") {
+			t.Errorf("Expected error mentioning model 99, got %v", err)
+		}
+		if len(models) == 0 {
+			t.Error("Expected recommendations from the remaining seed")
+		}
+	})
+
+	t.Run("All seeds fail", func(t *testing.T) {
+		models, err := client.RecommendForUser(ctx, []int{98, 99}, 10)
+		if err == nil {
+			t.Error("Expected error when every seed fails")
+		}
+		if models != nil {
+			t.Errorf("Expected no recommendations, got %v", models)
+		}
+	})
+
+	t.Run("Empty input", func(t *testing.T) {
+		models, err := client.RecommendForUser(ctx, nil, 10)
+		if err != nil || models != nil {
+			t.Errorf("Expected no recommendations and no error, got %v, %v", models, err)
+		}
+	})
+
+	t.Run("Invalid arguments", func(t *testing.T) {
+		if _, err := client.RecommendForUser(ctx, []int{1}, 0); err == nil {
+			t.Error("Expected error for zero limit")
+		}
+		if _, err := client.RecommendForUser(ctx, []int{-1}, 10); err == nil {
+			t.Error("Expected error for negative model ID")
+		}
+	})
+}
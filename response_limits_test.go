@@ -25,10 +25,12 @@ package civitai
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestResponseSizeLimits(t *testing.T) {
@@ -151,3 +153,113 @@ func TestResponseSizeLimits(t *testing.T) {
 		}
 	})
 }
+
+func TestResponseTooLargeError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"items": [` + strings.Repeat(`{"id": 1, "name": "test"},`, 100) + `{"id": 2}], "metadata": {}}`))
+	}))
+	defer server.Close()
+
+	t.Run("Typed error when limit exceeded", func(t *testing.T) {
+		client := NewClientWithoutAuth(WithBaseURL(server.URL), WithMaxResponseSize(256))
+
+		_, _, err := client.SearchModels(context.Background(), SearchParams{Limit: 10})
+		if !errors.Is(err, ErrResponseTooLarge) {
+			t.Fatalf("Expected ErrResponseTooLarge, got: %v", err)
+		}
+
+		var tooLarge *ResponseTooLargeError
+		if !errors.As(err, &tooLarge) {
+			t.Fatalf("Expected *ResponseTooLargeError, got: %T", err)
+		}
+		if tooLarge.Limit != 256 {
+			t.Errorf("Expected limit 256, got %d", tooLarge.Limit)
+		}
+	})
+
+	t.Run("Truncated body within limit is a decode error", func(t *testing.T) {
+		truncated := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"items": [{"id": 1`))
+		}))
+		defer truncated.Close()
+
+		client := NewClientWithoutAuth(WithBaseURL(truncated.URL), WithMaxResponseSize(1024))
+
+		_, _, err := client.SearchModels(context.Background(), SearchParams{Limit: 10})
+		if err == nil || errors.Is(err, ErrResponseTooLarge) {
+			t.Errorf("Expected decode error, got: %v", err)
+		}
+	})
+}
+
+func TestEndpointResponseLimit(t *testing.T) {
+	largeResponse := `{"items": [` + strings.Repeat(`{"id": 1, "name": "test"},`, 100) + `{"id": 2, "name": "last"}], "metadata": {}}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if strings.HasPrefix(r.URL.Path, "/api/v1/models/") {
+			w.Write([]byte(`{"id": 1, "name": "` + strings.Repeat("x", 500) + `"}`))
+			return
+		}
+		w.Write([]byte(largeResponse))
+	}))
+	defer server.Close()
+
+	client := NewClientWithoutAuth(
+		WithBaseURL(server.URL+"/api/v1"),
+		WithMaxResponseSize(256),
+		WithEndpointResponseLimit("/models", 1<<20),
+		WithEndpointResponseLimit("models/1", 128),
+	)
+
+	t.Run("Override allows large listing", func(t *testing.T) {
+		models, _, err := client.SearchModels(context.Background(), SearchParams{Limit: 10})
+		if err != nil {
+			t.Fatalf("Expected models override to allow response, got: %v", err)
+		}
+		if len(models) != 101 {
+			t.Errorf("Expected 101 models, got %d", len(models))
+		}
+	})
+
+	t.Run("Other endpoints use global limit", func(t *testing.T) {
+		_, _, err := client.GetTags(context.Background(), TagParams{Limit: 10})
+		var tooLarge *ResponseTooLargeError
+		if !errors.As(err, &tooLarge) {
+			t.Fatalf("Expected *ResponseTooLargeError, got: %v", err)
+		}
+		if tooLarge.Limit != 256 {
+			t.Errorf("Expected limit 256, got %d", tooLarge.Limit)
+		}
+	})
+
+	t.Run("Most specific endpoint wins", func(t *testing.T) {
+		_, err := client.GetModel(context.Background(), 1)
+		var tooLarge *ResponseTooLargeError
+		if !errors.As(err, &tooLarge) {
+			t.Fatalf("Expected *ResponseTooLargeError, got: %v", err)
+		}
+		if tooLarge.Limit != 128 {
+			t.Errorf("Expected limit 128, got %d", tooLarge.Limit)
+		}
+	})
+
+	t.Run("Override applies to cached responses", func(t *testing.T) {
+		cached := NewClientWithoutAuth(
+			WithBaseURL(server.URL+"/api/v1"),
+			WithMaxResponseSize(256),
+			WithEndpointResponseLimit("/models", 1<<20),
+			WithCache(time.Hour),
+		)
+
+		for i := 0; i < 2; i++ {
+			if _, _, err := cached.SearchModels(context.Background(), SearchParams{Limit: 10}); err != nil {
+				t.Fatalf("Request %d: expected models override to allow response, got: %v", i+1, err)
+			}
+		}
+		if hits, _ := cached.CacheStats(); hits != 1 {
+			t.Errorf("Expected the second request to be a cache hit, got %d hits", hits)
+		}
+	})
+}
@@ -59,6 +59,12 @@ SOFTWARE.
 //		nextPageModels, _, _ := client.SearchModels(ctx, params)
 //	}
 //
+//	// Go back from the page metadata describes
+//	prevModels, prevMetadata, err := client.SearchModelsPrev(ctx, params, metadata)
+//	if errors.Is(err, civitai.ErrNoPreviousPage) {
+//		// Already on the first page
+//	}
+//
 // # Error Handling
 //
 // API responses include detailed error information when requests fail:
@@ -69,6 +75,25 @@ SOFTWARE.
 //		}
 //	}
 //
+// With WithErrorResponseSnapshot, errors also carry the raw response body:
+//
+//	var apiErr *civitai.APIError
+//	if errors.As(err, &apiErr) {
+//		log.Printf("server returned: %s", apiErr.Details)
+//	}
+//
+// # Metrics
+//
+// Collect latency and error counts, overall and per endpoint:
+//
+//	metrics := &civitai.ResponseMetrics{}
+//	client := civitai.NewClientWithoutAuth(civitai.WithResponseMetrics(metrics))
+//	// ... make requests ...
+//	for endpoint, stats := range metrics.PerEndpointMetrics() {
+//		fmt.Printf("%s: %d requests, %d errors, avg %v\n",
+//			endpoint, stats.Requests, stats.Errors, stats.AverageResponse)
+//	}
+//
 // # Performance Notes
 //
 // Response handling includes automatic:
@@ -85,6 +110,7 @@ import (
 	"fmt"
 	"net/http"
 	"strconv"
+	"sync"
 	"time"
 )
 
@@ -402,7 +428,10 @@ func GetRetryDelay(err error, attempt int) time.Duration {
 	return delay
 }
 
-// ResponseMetrics contains metrics about API responses
+// ResponseMetrics contains metrics about API responses. Pass one to
+// WithResponseMetrics to have a client fill it in. Its methods are safe for
+// concurrent use, but the exported fields are not synchronized: while requests
+// may be in flight, read them through Snapshot.
 type ResponseMetrics struct {
 	TotalRequests   int64
 	SuccessfulReqs  int64
@@ -411,29 +440,149 @@ type ResponseMetrics struct {
 	ServerErrors    int64
 	AverageResponse time.Duration
 	TotalBytes      int64
-	CacheHits       int64
-	CacheMisses     int64
+	CacheHits       int64 // Lookups served by WithCache
+	CacheMisses     int64 // Lookups WithCache sent to the API
+
+	mu        sync.Mutex
+	endpoints map[string]*EndpointMetrics
 }
 
-// UpdateMetrics updates response metrics (would be called by the client)
+// EndpointMetrics contains request metrics for one logical endpoint
+type EndpointMetrics struct {
+	Requests        int64
+	Errors          int64 // Transport errors and 4xx/5xx responses
+	RateLimitErrors int64
+	ServerErrors    int64
+	TotalResponse   time.Duration
+	AverageResponse time.Duration
+	MaxResponse     time.Duration
+}
+
+// UpdateMetrics updates response metrics, counting info as a cache hit when
+// info.Cached is set and as a cache miss otherwise
 func (m *ResponseMetrics) UpdateMetrics(info *ResponseInfo, err error) {
-	m.TotalRequests++
-	m.TotalBytes += info.Size
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.update(info, err)
 
 	if info.Cached {
 		m.CacheHits++
 	} else {
 		m.CacheMisses++
 	}
+}
+
+// UpdateEndpointMetrics updates the overall metrics and those of endpoint,
+// which is a logical endpoint name such as EndpointModels. Unlike
+// UpdateMetrics it leaves the cache counters alone; clients with WithCache
+// count one hit or miss per lookup instead.
+func (m *ResponseMetrics) UpdateEndpointMetrics(endpoint string, info *ResponseInfo, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.update(info, err)
+
+	if m.endpoints == nil {
+		m.endpoints = make(map[string]*EndpointMetrics)
+	}
+	stats, ok := m.endpoints[endpoint]
+	if !ok {
+		stats = &EndpointMetrics{}
+		m.endpoints[endpoint] = stats
+	}
+
+	stats.Requests++
+	stats.TotalResponse += info.ResponseTime
+	stats.AverageResponse = stats.TotalResponse / time.Duration(stats.Requests)
+	stats.MaxResponse = max(stats.MaxResponse, info.ResponseTime)
+
+	failed, rateLimited, serverError := classifyResponse(info, err)
+	if failed {
+		stats.Errors++
+	}
+	if rateLimited {
+		stats.RateLimitErrors++
+	}
+	if serverError {
+		stats.ServerErrors++
+	}
+}
+
+// Snapshot returns a consistent copy of the metrics, including per-endpoint
+// metrics, that is safe to read while requests continue
+func (m *ResponseMetrics) Snapshot() *ResponseMetrics {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	snapshot := &ResponseMetrics{
+		TotalRequests:   m.TotalRequests,
+		SuccessfulReqs:  m.SuccessfulReqs,
+		FailedRequests:  m.FailedRequests,
+		RateLimitErrors: m.RateLimitErrors,
+		ServerErrors:    m.ServerErrors,
+		AverageResponse: m.AverageResponse,
+		TotalBytes:      m.TotalBytes,
+		CacheHits:       m.CacheHits,
+		CacheMisses:     m.CacheMisses,
+	}
+	if m.endpoints != nil {
+		snapshot.endpoints = make(map[string]*EndpointMetrics, len(m.endpoints))
+		for endpoint, stats := range m.endpoints {
+			copied := *stats
+			snapshot.endpoints[endpoint] = &copied
+		}
+	}
+	return snapshot
+}
+
+// PerEndpointMetrics returns a copy of the metrics of every endpoint that has
+// recorded a request, keyed by logical endpoint name
+func (m *ResponseMetrics) PerEndpointMetrics() map[string]EndpointMetrics {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	snapshot := make(map[string]EndpointMetrics, len(m.endpoints))
+	for endpoint, stats := range m.endpoints {
+		snapshot[endpoint] = *stats
+	}
+	return snapshot
+}
+
+// classifyResponse reports whether a response failed and, if so, whether it
+// was rate limited or a server error. An *APIError decides when present;
+// otherwise the status code does.
+func classifyResponse(info *ResponseInfo, err error) (failed, rateLimited, serverError bool) {
+	if apiErr, ok := err.(*APIError); ok {
+		return true, apiErr.IsRateLimitError(), apiErr.IsServerError()
+	}
+	failed = err != nil || info.StatusCode >= 400
+	rateLimited = info.StatusCode == http.StatusTooManyRequests
+	serverError = info.StatusCode >= 500
+	return failed, rateLimited, serverError
+}
+
+// recordCacheLookup counts one response cache hit or miss
+func (m *ResponseMetrics) recordCacheLookup(hit bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if hit {
+		m.CacheHits++
+	} else {
+		m.CacheMisses++
+	}
+}
+
+// update applies one response to the overall metrics; m.mu must be held
+func (m *ResponseMetrics) update(info *ResponseInfo, err error) {
+	m.TotalRequests++
+	m.TotalBytes += info.Size
 
-	if err != nil {
+	failed, rateLimited, serverError := classifyResponse(info, err)
+	if failed {
 		m.FailedRequests++
-		if apiErr, ok := err.(*APIError); ok {
-			if apiErr.IsRateLimitError() {
-				m.RateLimitErrors++
-			} else if apiErr.IsServerError() {
-				m.ServerErrors++
-			}
+		if rateLimited {
+			m.RateLimitErrors++
+		} else if serverError {
+			m.ServerErrors++
 		}
 	} else {
 		m.SuccessfulReqs++
@@ -25,8 +25,11 @@ package civitai
 
 import (
 	"context"
+	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"strings"
 	"sync/atomic"
 	"testing"
@@ -175,6 +178,12 @@ func TestRetryLogic(t *testing.T) {
 		if !strings.Contains(err.Error(), "after 3 attempts") {
 			t.Errorf("Expected error message about attempts, got: %v", err)
 		}
+		if !errors.Is(err, ErrRetriesExhausted) {
+			t.Errorf("Expected ErrRetriesExhausted, got: %v", err)
+		}
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Expected exhaustion not to look like a context error, got: %v", err)
+		}
 
 		if attempts != 3 { // 2 retries + 1 initial attempt
 			t.Errorf("Expected 3 total attempts, got %d", attempts)
@@ -206,6 +215,9 @@ func TestRetryLogic(t *testing.T) {
 		if err != context.DeadlineExceeded {
 			t.Errorf("Expected context deadline exceeded, got: %v", err)
 		}
+		if errors.Is(err, ErrRetriesExhausted) {
+			t.Errorf("Expected cancellation not to be reported as exhaustion, got: %v", err)
+		}
 
 		// Should have made at least one attempt but not all
 		if attempts == 0 {
@@ -217,6 +229,53 @@ func TestRetryLogic(t *testing.T) {
 	})
 }
 
+func TestRetryContextErrors(t *testing.T) {
+	t.Run("Cancelled during attempt", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			<-r.Context().Done()
+		}))
+		defer server.Close()
+
+		client := NewClientWithoutAuth(WithBaseURL(server.URL), WithRetryConfig(3, 10*time.Millisecond, 50*time.Millisecond))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(50*time.Millisecond, cancel)
+
+		_, err := client.GetModel(ctx, 1)
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Expected context.Canceled, got: %v", err)
+		}
+		if errors.Is(err, ErrRetriesExhausted) {
+			t.Errorf("Expected cancellation not to be reported as exhaustion, got: %v", err)
+		}
+	})
+
+	t.Run("Deadline during final attempt", func(t *testing.T) {
+		var attempts int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// The second and final attempt outlives the caller's deadline
+			if atomic.AddInt32(&attempts, 1) == 2 {
+				time.Sleep(150 * time.Millisecond)
+			}
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		client := NewClientWithoutAuth(WithBaseURL(server.URL), WithRetryConfig(1, 10*time.Millisecond, 10*time.Millisecond))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+
+		_, err := client.GetModel(ctx, 1)
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Expected context.DeadlineExceeded, got: %v", err)
+		}
+		if errors.Is(err, ErrRetriesExhausted) {
+			t.Errorf("Expected deadline not to be reported as exhaustion, got: %v", err)
+		}
+	})
+}
+
 func TestRetryHelperFunctions(t *testing.T) {
 	t.Run("isRetryableError", func(t *testing.T) {
 		testCases := []struct {
@@ -354,3 +413,281 @@ func TestRetryConfiguration(t *testing.T) {
 		}
 	})
 }
+
+// roundTripperFunc adapts a function to http.RoundTripper
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestRetryCallback(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"items": [], "metadata": {"totalItems": 0}}`))
+	}))
+	defer server.Close()
+
+	// Fail the second attempt with a network error before it reaches the server
+	var transportCalls int32
+	transport := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		if atomic.AddInt32(&transportCalls, 1) == 2 {
+			return nil, errors.New("read tcp: connection reset by peer")
+		}
+		return http.DefaultTransport.RoundTrip(req)
+	})
+
+	var callbackAttempts []int
+	var callbackErrs []error
+	client := NewClientWithoutAuth(
+		WithBaseURL(server.URL),
+		WithHTTPClient(&http.Client{Transport: transport}),
+		WithRetryConfig(3, 10*time.Millisecond, 100*time.Millisecond),
+		WithRetryCallback(func(attempt int, err error, delay time.Duration) {
+			callbackAttempts = append(callbackAttempts, attempt)
+			callbackErrs = append(callbackErrs, err)
+			if delay <= 0 {
+				t.Errorf("Expected positive delay, got %v", delay)
+			}
+		}),
+	)
+
+	_, _, err := client.SearchModels(context.Background(), SearchParams{Limit: 10})
+	if err != nil {
+		t.Fatalf("Expected success after retries, got: %v", err)
+	}
+
+	if len(callbackAttempts) != 2 {
+		t.Fatalf("Expected callback to fire 2 times, got %d", len(callbackAttempts))
+	}
+	if callbackAttempts[0] != 1 || callbackAttempts[1] != 2 {
+		t.Errorf("Expected callback attempts [1 2], got %v", callbackAttempts)
+	}
+	if !strings.Contains(callbackErrs[0].Error(), "503") {
+		t.Errorf("Expected first error to be the 503 status, got: %v", callbackErrs[0])
+	}
+	if !strings.Contains(callbackErrs[1].Error(), "connection reset") {
+		t.Errorf("Expected second error to be a network error, got: %v", callbackErrs[1])
+	}
+}
+
+func TestRetryOnDecodeError(t *testing.T) {
+	const body = `{"id": 1, "name": "Test Model"}`
+
+	// truncating servers cut the body short on the first attempt only
+	truncating := map[string]http.HandlerFunc{
+		"Connection dropped mid-body": func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+			w.Write([]byte(body[:10]))
+		},
+		"Truncated JSON": func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(body[:10]))
+		},
+	}
+
+	for name, truncate := range truncating {
+		t.Run(name, func(t *testing.T) {
+			var attempts int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if atomic.AddInt32(&attempts, 1) == 1 {
+					truncate(w, r)
+					return
+				}
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(body))
+			}))
+			defer server.Close()
+
+			client := NewClientWithoutAuth(
+				WithBaseURL(server.URL),
+				WithRetryConfig(2, time.Millisecond, time.Millisecond),
+				WithRetryOnDecodeError(),
+			)
+
+			model, err := client.GetModel(context.Background(), 1)
+			if err != nil {
+				t.Fatalf("Expected retry to recover, got: %v", err)
+			}
+			if model.Name != "Test Model" {
+				t.Errorf("Expected model name 'Test Model', got %q", model.Name)
+			}
+			if attempts != 2 {
+				t.Errorf("Expected 2 attempts, got %d", attempts)
+			}
+		})
+	}
+
+	t.Run("Not retried without option", func(t *testing.T) {
+		var attempts int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&attempts, 1)
+			truncating["Truncated JSON"](w, r)
+		}))
+		defer server.Close()
+
+		client := NewClientWithoutAuth(WithBaseURL(server.URL), WithRetryConfig(2, time.Millisecond, time.Millisecond))
+
+		if _, err := client.GetModel(context.Background(), 1); err == nil {
+			t.Error("Expected decode error")
+		}
+		if attempts != 1 {
+			t.Errorf("Expected 1 attempt, got %d", attempts)
+		}
+	})
+
+	t.Run("Gives up after retry budget", func(t *testing.T) {
+		var attempts int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&attempts, 1)
+			truncating["Truncated JSON"](w, r)
+		}))
+		defer server.Close()
+
+		client := NewClientWithoutAuth(
+			WithBaseURL(server.URL),
+			WithRetryConfig(2, time.Millisecond, time.Millisecond),
+			WithRetryOnDecodeError(),
+		)
+
+		_, err := client.GetModel(context.Background(), 1)
+		if !errors.Is(err, io.ErrUnexpectedEOF) {
+			t.Errorf("Expected error wrapping io.ErrUnexpectedEOF, got: %v", err)
+		}
+		if attempts != 3 {
+			t.Errorf("Expected 3 attempts, got %d", attempts)
+		}
+	})
+}
+
+func TestWithoutJitter(t *testing.T) {
+	t.Run("Exact exponential delays", func(t *testing.T) {
+		client := NewClientWithoutAuth(
+			WithRetryConfig(5, 100*time.Millisecond, time.Second),
+			WithoutJitter(),
+		)
+
+		expected := []time.Duration{
+			100 * time.Millisecond,
+			200 * time.Millisecond,
+			400 * time.Millisecond,
+			800 * time.Millisecond,
+			time.Second,
+		}
+		for i := 0; i < 3; i++ {
+			for attempt, want := range expected {
+				if got := client.calculateBackoffDelay(attempt); got != want {
+					t.Errorf("Expected delay %v for attempt %d, got %v", want, attempt, got)
+				}
+			}
+		}
+	})
+
+	t.Run("Retry callback sees exact delays", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		var delays []time.Duration
+		client := NewClientWithoutAuth(
+			WithBaseURL(server.URL),
+			WithRetryConfig(3, time.Millisecond, time.Second),
+			WithoutJitter(),
+			WithRetryCallback(func(attempt int, err error, delay time.Duration) {
+				delays = append(delays, delay)
+			}),
+		)
+
+		if _, err := client.GetModel(context.Background(), 1); err == nil {
+			t.Fatal("Expected error after retries")
+		}
+
+		expected := []time.Duration{time.Millisecond, 2 * time.Millisecond, 4 * time.Millisecond}
+		if len(delays) != len(expected) {
+			t.Fatalf("Expected %d retries, got %v", len(expected), delays)
+		}
+		for i, want := range expected {
+			if delays[i] != want {
+				t.Errorf("Expected retry %d delay %v, got %v", i+1, want, delays[i])
+			}
+		}
+	})
+}
+
+func TestCreatorsEndpointPolicy(t *testing.T) {
+	t.Run("Creators defaults are more generous", func(t *testing.T) {
+		client := NewClientWithoutAuth()
+		timeout, retries := client.endpointPolicy(client.endpointURL(EndpointCreators), requestOptions{})
+		if timeout != 2*DefaultTimeout || retries != DefaultMaxRetries+2 {
+			t.Errorf("Expected creators timeout %v and %d retries, got %v and %d", 2*DefaultTimeout, DefaultMaxRetries+2, timeout, retries)
+		}
+		timeout, retries = client.endpointPolicy(client.endpointURL(EndpointModels), requestOptions{})
+		if timeout != DefaultTimeout || retries != DefaultMaxRetries {
+			t.Errorf("Expected models timeout %v and %d retries, got %v and %d", DefaultTimeout, DefaultMaxRetries, timeout, retries)
+		}
+	})
+
+	t.Run("Overrides win", func(t *testing.T) {
+		client := NewClientWithoutAuth(
+			WithRequestTimeoutPerEndpoint(EndpointCreators, 5*time.Second),
+			WithMaxRetriesPerEndpoint(EndpointCreators, 0),
+			WithMaxRetriesPerEndpoint(EndpointTags, 7),
+		)
+		timeout, retries := client.endpointPolicy(client.endpointURL(EndpointCreators), requestOptions{})
+		if timeout != 5*time.Second || retries != 0 {
+			t.Errorf("Expected 5s and 0 retries, got %v and %d", timeout, retries)
+		}
+		if _, retries := client.endpointPolicy(client.endpointURL(EndpointTags), requestOptions{}); retries != 7 {
+			t.Errorf("Expected 7 retries for tags, got %d", retries)
+		}
+	})
+
+	var creatorCalls, modelCalls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.Contains(r.URL.Path, "/creators"):
+			atomic.AddInt32(&creatorCalls, 1)
+		case strings.Contains(r.URL.Path, "/models"):
+			atomic.AddInt32(&modelCalls, 1)
+		}
+		if r.URL.Query().Get("query") == "slow" {
+			time.Sleep(150 * time.Millisecond)
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"items": [], "metadata": {}}`))
+			return
+		}
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+
+	t.Run("Creators retried more", func(t *testing.T) {
+		client := NewClientWithoutAuth(WithBaseURL(server.URL), WithRetryConfig(1, time.Millisecond, time.Millisecond))
+		client.GetCreators(ctx, CreatorParams{})
+		client.SearchModels(ctx, SearchParams{})
+		if got := atomic.LoadInt32(&creatorCalls); got != 4 {
+			t.Errorf("Expected 4 creators attempts, got %d", got)
+		}
+		if got := atomic.LoadInt32(&modelCalls); got != 2 {
+			t.Errorf("Expected 2 models attempts, got %d", got)
+		}
+	})
+
+	t.Run("Creators attempts get a longer timeout", func(t *testing.T) {
+		client := NewClientWithoutAuth(WithBaseURL(server.URL), WithTimeout(100*time.Millisecond), WithRetryConfig(0, 0, 0))
+		if _, _, err := client.GetCreators(ctx, CreatorParams{Query: "slow"}); err != nil {
+			t.Errorf("Expected creators to finish within its longer timeout, got %v", err)
+		}
+		if _, _, err := client.SearchModels(ctx, SearchParams{Query: "slow"}); err == nil {
+			t.Error("Expected models request to time out")
+		}
+	})
+}
@@ -0,0 +1,172 @@
+/*
+Copyright (c) 2025 Regi Ellis
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+// Package civitai - Unified Search
+//
+// This file provides a single search entry point that fans out to the
+// models, creators, and tags endpoints and aggregates the results.
+//
+// # Universal Search
+//
+//	results, err := client.Search(ctx, "anime", nil, 10)
+//	if err != nil {
+//		log.Fatal(err) // every sub-search failed
+//	}
+//	fmt.Printf("%d models, %d creators, %d tags\n",
+//		len(results.Models), len(results.Creators), len(results.Tags))
+//
+//	// Inspect partial failures
+//	for resourceType, err := range results.Errors {
+//		log.Printf("%s search failed: %v", resourceType, err)
+//	}
+//
+// # Restricting Resource Types
+//
+//	results, err := client.Search(ctx, "portrait", []civitai.ResourceType{
+//		civitai.ResourceTypeLORA,
+//		civitai.ResourceTypeCreator,
+//	}, 20)
+//
+// Model subtypes (Checkpoint, LORA, TextualInversion, VAE) are combined into a
+// single filtered model search. Images can't be searched because the images
+// endpoint has no text query.
+
+package civitai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// SearchResults aggregates results from a unified Search call
+type SearchResults struct {
+	Models   []Model
+	Creators []Creator
+	Tags     []TagResponse
+
+	// Errors holds sub-search failures keyed by resource type
+	Errors map[ResourceType]error
+}
+
+// defaultSearchResourceTypes are searched when no types are requested
+var defaultSearchResourceTypes = []ResourceType{
+	ResourceTypeModel,
+	ResourceTypeCreator,
+	ResourceTypeTag,
+}
+
+// Search runs the query against every requested resource type concurrently and
+// aggregates the results. Partial failures are reported in SearchResults.Errors;
+// an error is returned only when every sub-search fails. A nil or empty types
+// slice searches models, creators, and tags.
+func (c *Client) Search(ctx context.Context, query string, types []ResourceType, limit int) (*SearchResults, error) {
+	if len(types) == 0 {
+		types = defaultSearchResourceTypes
+	}
+
+	var (
+		searchModels, searchCreators, searchTags bool
+		modelTypes                               []ModelType
+	)
+	for _, t := range types {
+		switch t {
+		case ResourceTypeModel:
+			searchModels = true
+		case ResourceTypeCheckpoint, ResourceTypeLORA, ResourceTypeEmbedding, ResourceTypeVAE:
+			searchModels = true
+			modelTypes = append(modelTypes, ModelType(t))
+		case ResourceTypeCreator:
+			searchCreators = true
+		case ResourceTypeTag:
+			searchTags = true
+		default:
+			return nil, fmt.Errorf("unsupported resource type for search: %s", t)
+		}
+	}
+
+	// A plain Model request means all model types
+	for _, t := range types {
+		if t == ResourceTypeModel {
+			modelTypes = nil
+			break
+		}
+	}
+
+	results := &SearchResults{Errors: make(map[ResourceType]error)}
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		searches int
+	)
+
+	run := func(resourceType ResourceType, search func() error) {
+		searches++
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := search(); err != nil {
+				mu.Lock()
+				results.Errors[resourceType] = err
+				mu.Unlock()
+			}
+		}()
+	}
+
+	if searchModels {
+		run(ResourceTypeModel, func() error {
+			models, _, err := c.SearchModels(ctx, SearchParams{Query: query, Types: modelTypes, Limit: limit})
+			results.Models = models
+			return err
+		})
+	}
+	if searchCreators {
+		run(ResourceTypeCreator, func() error {
+			creators, _, err := c.GetCreators(ctx, CreatorParams{Query: query, Limit: limit})
+			results.Creators = creators
+			return err
+		})
+	}
+	if searchTags {
+		run(ResourceTypeTag, func() error {
+			tags, _, err := c.GetTags(ctx, TagParams{Query: query, Limit: limit})
+			results.Tags = tags
+			return err
+		})
+	}
+
+	wg.Wait()
+
+	if len(results.Errors) == searches {
+		// Report in a fixed order so the message is stable
+		errs := make([]error, 0, len(results.Errors))
+		for _, resourceType := range defaultSearchResourceTypes {
+			if err, ok := results.Errors[resourceType]; ok {
+				errs = append(errs, fmt.Errorf("%s: %w", resourceType, err))
+			}
+		}
+		return results, fmt.Errorf("all searches failed: %w", errors.Join(errs...))
+	}
+
+	return results, nil
+}
@@ -0,0 +1,159 @@
+/*
+Copyright (c) 2025 Regi Ellis
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package civitai
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestSearch(t *testing.T) {
+	var mu sync.Mutex
+	queries := make(map[string]string)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		queries[r.URL.Path] = r.URL.RawQuery
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/models":
+			w.Write([]byte(`{"items": [{"id": 1, "name": "Anime Model", "type": "Checkpoint"}], "metadata": {}}`))
+		case "/images":
+			w.Write([]byte(`{"items": [{"id": 2, "url": "https://example.com/a.jpg"}], "metadata": {}}`))
+		case "/creators":
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"code": "BAD_REQUEST", "message": "creators unavailable"}`))
+		case "/tags":
+			w.Write([]byte(`{"items": [{"name": "anime", "modelCount": 10}], "metadata": {}}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClientWithoutAuth(WithBaseURL(server.URL))
+	ctx := context.Background()
+
+	t.Run("All resource types with partial failure", func(t *testing.T) {
+		results, err := client.Search(ctx, "anime", nil, 5)
+		if err != nil {
+			t.Fatalf("Expected partial success, got error: %v", err)
+		}
+
+		if len(results.Models) != 1 || results.Models[0].ID != 1 {
+			t.Errorf("Expected 1 model, got %v", results.Models)
+		}
+		if len(results.Tags) != 1 || results.Tags[0].Name != "anime" {
+			t.Errorf("Expected anime tag, got %v", results.Tags)
+		}
+		if len(results.Creators) != 0 {
+			t.Errorf("Expected no creators, got %d", len(results.Creators))
+		}
+		if results.Errors[ResourceTypeCreator] == nil {
+			t.Error("Expected creator search error to be recorded")
+		}
+		if len(results.Errors) != 1 {
+			t.Errorf("Expected 1 error, got %d", len(results.Errors))
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		if _, ok := queries["/images"]; ok {
+			t.Errorf("Expected no image search, got %q", queries["/images"])
+		}
+		if !strings.Contains(queries["/models"], "query=anime") {
+			t.Errorf("Expected model query param, got %q", queries["/models"])
+		}
+		if !strings.Contains(queries["/tags"], "limit=5") {
+			t.Errorf("Expected tag limit param, got %q", queries["/tags"])
+		}
+	})
+
+	t.Run("Model subtypes", func(t *testing.T) {
+		results, err := client.Search(ctx, "anime", []ResourceType{ResourceTypeLORA, ResourceTypeCheckpoint}, 5)
+		if err != nil {
+			t.Fatalf("Search failed: %v", err)
+		}
+		if len(results.Models) != 1 || len(results.Tags) != 0 || len(results.Creators) != 0 {
+			t.Errorf("Expected only model results, got %+v", results)
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		if !strings.Contains(queries["/models"], "types=LORA%2CCheckpoint") {
+			t.Errorf("Expected types filter, got %q", queries["/models"])
+		}
+	})
+
+	t.Run("All searches failed", func(t *testing.T) {
+		_, err := client.Search(ctx, "anime", []ResourceType{ResourceTypeCreator}, 5)
+		if err == nil {
+			t.Fatal("Expected error when every search fails")
+		}
+		if !strings.Contains(err.Error(), "all searches failed") {
+			t.Errorf("Unexpected error: %v", err)
+		}
+	})
+
+	t.Run("Failures reported in a fixed order", func(t *testing.T) {
+		failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"code": "BAD_REQUEST", "message": "unavailable"}`))
+		}))
+		defer failing.Close()
+
+		failingClient := NewClientWithoutAuth(WithBaseURL(failing.URL))
+		types := []ResourceType{ResourceTypeTag, ResourceTypeCreator, ResourceTypeModel}
+		var first string
+		for i := 0; i < 5; i++ {
+			_, err := failingClient.Search(ctx, "anime", types, 5)
+			if err == nil {
+				t.Fatal("Expected error when every search fails")
+			}
+			if i == 0 {
+				first = err.Error()
+			} else if err.Error() != first {
+				t.Fatalf("Expected a stable error message, got %q and %q", first, err.Error())
+			}
+		}
+		model, creator, tag := strings.Index(first, "Model:"), strings.Index(first, "Creator:"), strings.Index(first, "Tag:")
+		if model < 0 || !(model < creator && creator < tag) {
+			t.Errorf("Expected model, creator, then tag failures, got %q", first)
+		}
+	})
+
+	t.Run("Unsupported resource type", func(t *testing.T) {
+		for _, resourceType := range []ResourceType{ResourceTypeArticle, ResourceTypeImage} {
+			if _, err := client.Search(ctx, "anime", []ResourceType{resourceType}, 5); err == nil {
+				t.Errorf("Expected error for unsupported resource type %s", resourceType)
+			}
+		}
+	})
+}
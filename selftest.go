@@ -0,0 +1,198 @@
+/*
+Copyright (c) 2025 Regi Ellis
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+// Package civitai - API Self-Test
+//
+// This file provides a one-call probe that exercises each API endpoint with
+// minimal requests and reports which endpoints work, how long they take, and
+// whether they return cursors for pagination. It codifies the checks from
+// examples/api_consistency_check for use in health checks and diagnostics.
+//
+// # Running a Self-Test
+//
+//	report, err := client.SelfTest(ctx)
+//	if err != nil {
+//		log.Fatal(err) // every endpoint failed
+//	}
+//	for _, status := range report.Endpoints {
+//		if status.Skipped {
+//			continue
+//		}
+//		fmt.Printf("%-14s ok=%-5v latency=%v cursor=%v\n",
+//			status.Endpoint, status.OK, status.Latency, status.CursorPagination)
+//	}
+//	if !report.Healthy() {
+//		log.Println("some endpoints are failing")
+//	}
+//
+// Lookups of a single model and model version use IDs from the models search,
+// so they are skipped when that search fails or returns nothing.
+
+package civitai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Endpoints probed by SelfTest
+const (
+	SelfTestEndpointModels       = "models"
+	SelfTestEndpointImages       = "images"
+	SelfTestEndpointCreators     = "creators"
+	SelfTestEndpointTags         = "tags"
+	SelfTestEndpointModel        = "models/{id}"
+	SelfTestEndpointModelVersion = "model-versions/{id}"
+)
+
+// EndpointStatus is the outcome of probing a single endpoint
+type EndpointStatus struct {
+	Endpoint         string
+	OK               bool
+	Skipped          bool          // Not probed because a prerequisite failed
+	Latency          time.Duration // Total time including retries
+	Items            int           // Items returned by list endpoints
+	CursorPagination bool          // A next cursor was returned
+	Err              error
+}
+
+// SelfTestReport summarizes a SelfTest run
+type SelfTestReport struct {
+	Endpoints []EndpointStatus // In probe order
+	Duration  time.Duration
+}
+
+// Healthy reports whether every probed endpoint succeeded
+func (r *SelfTestReport) Healthy() bool {
+	for _, status := range r.Endpoints {
+		if !status.Skipped && !status.OK {
+			return false
+		}
+	}
+	return true
+}
+
+// Endpoint returns the status for the named endpoint, or nil if it was not probed
+func (r *SelfTestReport) Endpoint(name string) *EndpointStatus {
+	for i := range r.Endpoints {
+		if r.Endpoints[i].Endpoint == name {
+			return &r.Endpoints[i]
+		}
+	}
+	return nil
+}
+
+// SelfTest probes the models, images, creators, and tags endpoints, followed by
+// single model and model version lookups, one request each and in sequence so
+// the probe itself adds little load. Failures are recorded in the report; an
+// error is returned only when every probe fails.
+func (c *Client) SelfTest(ctx context.Context) (*SelfTestReport, error) {
+	start := time.Now()
+	report := &SelfTestReport{}
+
+	probe := func(endpoint string, fn func() (items int, cursor bool, err error)) {
+		probeStart := time.Now()
+		items, cursor, err := fn()
+		report.Endpoints = append(report.Endpoints, EndpointStatus{
+			Endpoint:         endpoint,
+			OK:               err == nil,
+			Latency:          time.Since(probeStart),
+			Items:            items,
+			CursorPagination: cursor,
+			Err:              err,
+		})
+	}
+
+	var modelID, versionID int
+	probe(SelfTestEndpointModels, func() (int, bool, error) {
+		models, meta, err := c.SearchModels(ctx, SearchParams{Limit: 1})
+		if err != nil {
+			return 0, false, err
+		}
+		if len(models) > 0 {
+			modelID = models[0].ID
+			if len(models[0].ModelVersions) > 0 {
+				versionID = models[0].ModelVersions[0].ID
+			}
+		}
+		return len(models), hasNextCursor(meta), nil
+	})
+
+	probe(SelfTestEndpointImages, func() (int, bool, error) {
+		images, meta, err := c.GetImages(ctx, ImageParams{Limit: 1, NSFW: string(NSFWLevelNone)})
+		return len(images), hasNextCursor(meta), err
+	})
+
+	probe(SelfTestEndpointCreators, func() (int, bool, error) {
+		creators, meta, err := c.GetCreators(ctx, CreatorParams{Limit: 1})
+		return len(creators), hasNextCursor(meta), err
+	})
+
+	probe(SelfTestEndpointTags, func() (int, bool, error) {
+		tags, meta, err := c.GetTags(ctx, TagParams{Limit: 1})
+		return len(tags), hasNextCursor(meta), err
+	})
+
+	if modelID > 0 {
+		probe(SelfTestEndpointModel, func() (int, bool, error) {
+			_, err := c.GetModel(ctx, modelID)
+			return 0, false, err
+		})
+	} else {
+		report.Endpoints = append(report.Endpoints, EndpointStatus{Endpoint: SelfTestEndpointModel, Skipped: true})
+	}
+
+	if versionID > 0 {
+		probe(SelfTestEndpointModelVersion, func() (int, bool, error) {
+			_, err := c.GetModelVersion(ctx, versionID)
+			return 0, false, err
+		})
+	} else {
+		report.Endpoints = append(report.Endpoints, EndpointStatus{Endpoint: SelfTestEndpointModelVersion, Skipped: true})
+	}
+
+	report.Duration = time.Since(start)
+
+	var errs []error
+	probed := 0
+	for _, status := range report.Endpoints {
+		if status.Skipped {
+			continue
+		}
+		probed++
+		if status.Err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", status.Endpoint, status.Err))
+		}
+	}
+	if len(errs) == probed {
+		return report, fmt.Errorf("all self-test probes failed: %w", errors.Join(errs...))
+	}
+
+	return report, nil
+}
+
+// hasNextCursor reports whether metadata carries a cursor for the next page
+func hasNextCursor(meta *Metadata) bool {
+	return meta != nil && meta.NextCursor != ""
+}
@@ -0,0 +1,123 @@
+/*
+Copyright (c) 2025 Regi Ellis
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package civitai
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestSelfTest(t *testing.T) {
+	t.Run("Mixed healthy and failing endpoints", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			switch {
+			case r.URL.Path == "/models":
+				w.Write([]byte(`{"items": [{"id": 10, "name": "A", "modelVersions": [{"id": 20}]}], "metadata": {"nextCursor": "abc"}}`))
+			case r.URL.Path == "/models/10":
+				w.Write([]byte(`{"id": 10, "name": "A"}`))
+			case r.URL.Path == "/model-versions/20":
+				w.WriteHeader(http.StatusNotFound)
+				w.Write([]byte(`{"error": "not found"}`))
+			case r.URL.Path == "/images":
+				w.Write([]byte(`{"items": [{"id": 1}], "metadata": {"nextCursor": "def"}}`))
+			case r.URL.Path == "/creators":
+				w.WriteHeader(http.StatusInternalServerError)
+			case r.URL.Path == "/tags":
+				w.Write([]byte(`{"items": [{"name": "anime"}], "metadata": {"totalItems": 1}}`))
+			default:
+				http.NotFound(w, r)
+			}
+		}))
+		defer server.Close()
+
+		client := NewClientWithoutAuth(WithBaseURL(server.URL), WithRetryConfig(0, 0, 0))
+		report, err := client.SelfTest(context.Background())
+		if err != nil {
+			t.Fatalf("SelfTest failed: %v", err)
+		}
+
+		if len(report.Endpoints) != 6 {
+			t.Fatalf("Expected 6 endpoint statuses, got %d", len(report.Endpoints))
+		}
+		if report.Healthy() {
+			t.Error("Expected report to be unhealthy")
+		}
+
+		expected := map[string]struct {
+			ok     bool
+			cursor bool
+		}{
+			SelfTestEndpointModels:       {true, true},
+			SelfTestEndpointImages:       {true, true},
+			SelfTestEndpointCreators:     {false, false},
+			SelfTestEndpointTags:         {true, false},
+			SelfTestEndpointModel:        {true, false},
+			SelfTestEndpointModelVersion: {false, false},
+		}
+		for endpoint, want := range expected {
+			status := report.Endpoint(endpoint)
+			if status == nil {
+				t.Errorf("Missing status for %s", endpoint)
+				continue
+			}
+			if status.OK != want.ok || status.CursorPagination != want.cursor {
+				t.Errorf("%s: expected ok=%v cursor=%v, got ok=%v cursor=%v (err: %v)",
+					endpoint, want.ok, want.cursor, status.OK, status.CursorPagination, status.Err)
+			}
+			if status.OK == (status.Err != nil) {
+				t.Errorf("%s: expected Err to be set only on failure, got %v", endpoint, status.Err)
+			}
+			if status.Latency <= 0 {
+				t.Errorf("%s: expected a positive latency", endpoint)
+			}
+		}
+		if report.Endpoint(SelfTestEndpointModels).Items != 1 {
+			t.Errorf("Expected 1 model item, got %d", report.Endpoint(SelfTestEndpointModels).Items)
+		}
+	})
+
+	t.Run("All endpoints failing", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		client := NewClientWithoutAuth(WithBaseURL(server.URL), WithRetryConfig(0, 0, 0))
+		report, err := client.SelfTest(context.Background())
+		if err == nil || !strings.Contains(err.Error(), "all self-test probes failed") {
+			t.Errorf("Expected all-failed error, got %v", err)
+		}
+		if report == nil {
+			t.Fatal("Expected a report even when every probe fails")
+		}
+		for _, endpoint := range []string{SelfTestEndpointModel, SelfTestEndpointModelVersion} {
+			if status := report.Endpoint(endpoint); status == nil || !status.Skipped {
+				t.Errorf("Expected %s to be skipped, got %+v", endpoint, status)
+			}
+		}
+	})
+}
@@ -77,6 +77,37 @@ SOFTWARE.
 //		}
 //	}
 //
+// # Models for Top Tags
+//
+// Fetch the top tags and their most downloaded models in one call:
+//
+//	byTag, err := client.GetModelsForTopTags(ctx, 10, 5)
+//	for tag, models := range byTag {
+//		fmt.Printf("%s: %d models\n", tag, len(models))
+//	}
+//
+//	// Bound concurrency and list each model under its first tag only
+//	byTag, err = client.GetModelsForTopTagsWithOptions(ctx, civitai.TopTagsOptions{
+//		TagLimit:     10,
+//		ModelsPerTag: 5,
+//		Concurrency:  2,
+//		DedupeModels: true,
+//	})
+//
+// # Tag Types
+//
+// Tags carry a semantic type (Concept, Character, Style, ...) when the API
+// reports one. Group or filter them for discovery UIs:
+//
+//	styles := civitai.FilterTagsByType(tags, civitai.TagTypeStyle)
+//
+//	for tagType, group := range civitai.GroupTagsByType(tags) {
+//		fmt.Printf("%s: %d tags\n", tagType, len(group))
+//	}
+//
+// Types are compared case-insensitively; tags without a type are grouped
+// under TagTypeUntyped.
+//
 // # Error Handling
 //
 // The Tags endpoint can experience timeout issues:
@@ -102,10 +133,26 @@ package civitai
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 )
 
+// defaultTopTagsConcurrency bounds per-tag model searches when no concurrency is set
+const defaultTopTagsConcurrency = 4
+
+// TopTagsOptions configures GetModelsForTopTagsWithOptions
+type TopTagsOptions struct {
+	TagLimit     int      // Number of top tags to fetch
+	ModelsPerTag int      // Number of models to fetch per tag
+	Concurrency  int      // Maximum concurrent per-tag searches (default 4)
+	Sort         SortType // Model sort order (default Most Downloaded)
+	DedupeModels bool     // List each model only under the highest ranked tag it appears in
+}
+
 // GetTags retrieves a list of tags from the CivitAI API
 // GET /api/v1/tags
 func (c *Client) GetTags(ctx context.Context, params TagParams) ([]TagResponse, *Metadata, error) {
@@ -114,7 +161,7 @@ func (c *Client) GetTags(ctx context.Context, params TagParams) ([]TagResponse,
 	}
 
 	queryParams := c.buildTagParams(params)
-	url := c.addQueryParams(c.buildURL("tags"), queryParams)
+	url := c.addQueryParams(c.endpointURL(EndpointTags), queryParams)
 
 	resp, err := c.doRequest(ctx, "GET", url, nil)
 	if err != nil {
@@ -137,15 +184,166 @@ func (c *Client) GetTags(ctx context.Context, params TagParams) ([]TagResponse,
 func (c *Client) buildTagParams(params TagParams) map[string]string {
 	queryParams := make(map[string]string)
 
-	if params.Limit > 0 {
-		queryParams["limit"] = strconv.Itoa(params.Limit)
+	if limit := c.effectiveLimit(params.Limit); limit > 0 {
+		queryParams["limit"] = strconv.Itoa(limit)
 	}
-	if params.Page > 0 {
+	if params.Page > 0 && params.Cursor == "" {
 		queryParams["page"] = strconv.Itoa(params.Page)
 	}
 	if params.Query != "" {
 		queryParams["query"] = params.Query
 	}
+	if params.Cursor != "" {
+		queryParams["cursor"] = params.Cursor
+	}
 
 	return queryParams
 }
+
+// GetModelsForTopTags fetches the top tags and then concurrently fetches the
+// top models for each tag, keyed by tag name
+func (c *Client) GetModelsForTopTags(ctx context.Context, tagLimit, modelsPerTag int) (map[string][]Model, error) {
+	return c.GetModelsForTopTagsWithOptions(ctx, TopTagsOptions{
+		TagLimit:     tagLimit,
+		ModelsPerTag: modelsPerTag,
+	})
+}
+
+// GetModelsForTopTagsWithOptions is like GetModelsForTopTags with control over
+// concurrency, sort order, and model deduplication. Tags whose search fails are
+// omitted from the result and reported in the returned error alongside the
+// models that were fetched successfully.
+func (c *Client) GetModelsForTopTagsWithOptions(ctx context.Context, opts TopTagsOptions) (map[string][]Model, error) {
+	if opts.TagLimit <= 0 {
+		return nil, fmt.Errorf("tag limit must be positive, got %d", opts.TagLimit)
+	}
+	if opts.ModelsPerTag <= 0 {
+		return nil, fmt.Errorf("models per tag must be positive, got %d", opts.ModelsPerTag)
+	}
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultTopTagsConcurrency
+	}
+	sort := opts.Sort
+	if sort == "" {
+		sort = SortMostDownload
+	}
+
+	tags, _, err := c.GetTags(ctx, TagParams{Limit: opts.TagLimit})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch tags: %w", err)
+	}
+
+	perTag := make([][]Model, len(tags))
+	errs := make([]error, len(tags))
+	sem := make(chan struct{}, concurrency)
+	var (
+		wg        sync.WaitGroup
+		completed int32
+	)
+
+	for i, tag := range tags {
+		wg.Add(1)
+		go func(i int, name string) {
+			defer wg.Done()
+			if c.progressReporter != nil {
+				defer func() {
+					c.reportProgress("GetModelsForTopTags", int(atomic.AddInt32(&completed, 1)), len(tags))
+				}()
+			}
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-ctx.Done():
+				errs[i] = fmt.Errorf("tag %q: %w", name, ctx.Err())
+				return
+			}
+
+			models, _, err := c.searchModels(ctx, SearchParams{Tag: name, Limit: opts.ModelsPerTag, Sort: sort})
+			if err != nil {
+				errs[i] = fmt.Errorf("tag %q: %w", name, err)
+				return
+			}
+			perTag[i] = models
+		}(i, tag.Name)
+	}
+	wg.Wait()
+
+	// Walk tags in rank order so deduplication keeps models under their top tag
+	result := make(map[string][]Model, len(tags))
+	seen := make(map[int]bool)
+	for i, tag := range tags {
+		if errs[i] != nil {
+			continue
+		}
+		models := perTag[i]
+		if opts.DedupeModels {
+			unique := make([]Model, 0, len(models))
+			for _, model := range models {
+				if !seen[model.ID] {
+					seen[model.ID] = true
+					unique = append(unique, model)
+				}
+			}
+			models = unique
+		}
+		result[tag.Name] = models
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return result, fmt.Errorf("failed to fetch models for some tags: %w", err)
+	}
+	return result, nil
+}
+
+// IsType reports whether the tag has the given type, ignoring case
+func (t Tag) IsType(typ string) bool {
+	return strings.EqualFold(t.Type, typ)
+}
+
+// IsType reports whether the tag has the given type, ignoring case
+func (t TagResponse) IsType(typ string) bool {
+	return strings.EqualFold(t.Type, typ)
+}
+
+// FilterTagsByType returns the tags of the given type, ignoring case.
+// Pass TagTypeUntyped to find tags without a reported type.
+func FilterTagsByType(tags []TagResponse, typ string) []TagResponse {
+	var filtered []TagResponse
+	for _, tag := range tags {
+		if tag.IsType(typ) {
+			filtered = append(filtered, tag)
+		}
+	}
+	return filtered
+}
+
+// GroupTagsByType groups tags by type, preserving their order within each
+// group. Keys use the type as first seen, so "style" and "Style" share the
+// key of whichever appears first; untyped tags are keyed by TagTypeUntyped.
+func GroupTagsByType(tags []TagResponse) map[string][]TagResponse {
+	return groupByType(tags, func(tag TagResponse) string { return tag.Type })
+}
+
+// GroupModelTagsByType groups model or version tags by type, like GroupTagsByType
+func GroupModelTagsByType(tags []Tag) map[string][]Tag {
+	return groupByType(tags, func(tag Tag) string { return tag.Type })
+}
+
+// groupByType groups items by a case-insensitive type, keyed by the first spelling seen
+func groupByType[T any](items []T, typeOf func(T) string) map[string][]T {
+	groups := make(map[string][]T)
+	keys := make(map[string]string)
+
+	for _, item := range items {
+		typ := typeOf(item)
+		key, ok := keys[strings.ToLower(typ)]
+		if !ok {
+			key = typ
+			keys[strings.ToLower(typ)] = key
+		}
+		groups[key] = append(groups[key], item)
+	}
+
+	return groups
+}
@@ -0,0 +1,104 @@
+/*
+Copyright (c) 2025 Regi Ellis
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package civitai
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestTagTypes(t *testing.T) {
+	tags := []TagResponse{
+		{Name: "anime", Type: TagTypeStyle},
+		{Name: "hatsune miku", Type: TagTypeCharacter},
+		{Name: "watercolor", Type: "style"},
+		{Name: "cyberpunk", Type: TagTypeConcept},
+		{Name: "mystery"},
+	}
+
+	t.Run("Decodes type from the tags endpoint", func(t *testing.T) {
+		var tag TagResponse
+		if err := json.Unmarshal([]byte(`{"name": "anime", "modelCount": 10, "type": "Style"}`), &tag); err != nil {
+			t.Fatalf("Failed to decode tag: %v", err)
+		}
+		if tag.Type != TagTypeStyle {
+			t.Errorf("Expected type %q, got %q", TagTypeStyle, tag.Type)
+		}
+	})
+
+	t.Run("FilterTagsByType", func(t *testing.T) {
+		tests := []struct {
+			typ      string
+			expected []string
+		}{
+			{TagTypeStyle, []string{"anime", "watercolor"}},
+			{"CHARACTER", []string{"hatsune miku"}},
+			{TagTypeUntyped, []string{"mystery"}},
+			{TagTypeClothing, nil},
+		}
+
+		for _, tt := range tests {
+			filtered := FilterTagsByType(tags, tt.typ)
+			if len(filtered) != len(tt.expected) {
+				t.Errorf("%q: expected %d tags, got %d", tt.typ, len(tt.expected), len(filtered))
+				continue
+			}
+			for i, tag := range filtered {
+				if tag.Name != tt.expected[i] {
+					t.Errorf("%q: expected tag %q at %d, got %q", tt.typ, tt.expected[i], i, tag.Name)
+				}
+			}
+		}
+	})
+
+	t.Run("GroupTagsByType", func(t *testing.T) {
+		groups := GroupTagsByType(tags)
+
+		if len(groups) != 4 {
+			t.Fatalf("Expected 4 groups, got %d: %v", len(groups), groups)
+		}
+		if styles := groups[TagTypeStyle]; len(styles) != 2 || styles[1].Name != "watercolor" {
+			t.Errorf("Expected case-insensitive Style group of 2, got %v", styles)
+		}
+		if untyped := groups[TagTypeUntyped]; len(untyped) != 1 {
+			t.Errorf("Expected 1 untyped tag, got %v", untyped)
+		}
+	})
+
+	t.Run("Model tags", func(t *testing.T) {
+		modelTags := []Tag{
+			{ID: 1, Name: "portrait", Type: TagTypeConcept},
+			{ID: 2, Name: "oil painting", Type: TagTypeStyle},
+			{ID: 3, Name: "landscape", Type: "concept"},
+		}
+
+		if !modelTags[0].IsType("concept") || modelTags[1].IsType(TagTypeConcept) {
+			t.Error("Expected IsType to match case-insensitively")
+		}
+
+		groups := GroupModelTagsByType(modelTags)
+		if len(groups[TagTypeConcept]) != 2 || len(groups[TagTypeStyle]) != 1 {
+			t.Errorf("Expected 2 concept and 1 style tag, got %v", groups)
+		}
+	})
+}
@@ -0,0 +1,214 @@
+/*
+Copyright (c) 2025 Regi Ellis
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+// Package civitai - Transport Configuration
+//
+// This file builds the client's HTTP client and transport from the options
+// that configure them. Each option only records its setting; NewClient applies
+// them all at once after every option has run, so options compose in any order
+// and none of them undoes another.
+//
+// # Composition Order
+//
+// NewClient starts from the WithHTTPClient client, or a default client, and
+// applies the settings to copies of it and its transport, so a shared client
+// is never modified:
+//
+//  1. WithTimeout replaces the client's timeout
+//  2. WithCookieJar replaces the client's cookie jar
+//  3. The transport is cloned from the client's *http.Transport, or from
+//     http.DefaultTransport when the client has none
+//  4. WithConnectionPooling sets the idle connection limits
+//  5. WithMinTLSVersion sets the minimum TLS version, keeping the rest of
+//     the TLS configuration
+//  6. WithDialTimeout and WithKeepAlive replace the dialer
+//  7. WithPreferIPv4 wraps the dialer
+//  8. WithMaxResponseHeaderBytes, WithMaxConnsPerHost, and WithCompression
+//     set their transport fields
+//
+// For example, these clients are configured identically:
+//
+//	a := civitai.NewClientWithoutAuth(
+//		civitai.WithHTTPClient(shared),
+//		civitai.WithTimeout(10*time.Second),
+//		civitai.WithConnectionPooling(20, 5),
+//		civitai.WithMinTLSVersion(tls.VersionTLS13),
+//	)
+//	b := civitai.NewClientWithoutAuth(
+//		civitai.WithMinTLSVersion(tls.VersionTLS13),
+//		civitai.WithConnectionPooling(20, 5),
+//		civitai.WithTimeout(10*time.Second),
+//		civitai.WithHTTPClient(shared),
+//	)
+//
+// Transports that are not *http.Transport, such as test doubles or
+// instrumented round trippers, are left untouched by steps 3 to 8.
+
+package civitai
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/http"
+	"time"
+)
+
+// defaultIdleConnTimeout is the idle connection timeout set by WithConnectionPooling
+const defaultIdleConnTimeout = 90 * time.Second
+
+// transportSettings records the options that configure the HTTP client and
+// its transport until NewClient builds them
+type transportSettings struct {
+	timeout    time.Duration
+	timeoutSet bool
+	cookieJar  http.CookieJar
+
+	pooling             bool
+	maxIdleConns        int
+	maxIdleConnsPerHost int
+
+	minTLSVersion      uint16
+	preferIPv4         bool
+	maxHeaderBytes     int64
+	maxConnsPerHost    int
+	dialTimeout        time.Duration
+	keepAlive          time.Duration
+	disableCompression bool
+}
+
+// configuresTransport reports whether any setting changes the transport
+func (s *transportSettings) configuresTransport() bool {
+	return s.pooling || s.minTLSVersion != 0 || s.preferIPv4 || s.maxHeaderBytes > 0 ||
+		s.maxConnsPerHost > 0 || s.dialTimeout > 0 || s.keepAlive != 0 || s.disableCompression
+}
+
+// buildHTTPClient applies the settings to a copy of base and its transport,
+// returning base itself when there is nothing to apply
+func (s *transportSettings) buildHTTPClient(base *http.Client) *http.Client {
+	if !s.timeoutSet && s.cookieJar == nil && !s.configuresTransport() {
+		return base
+	}
+
+	httpClient := *base
+	if s.timeoutSet {
+		httpClient.Timeout = s.timeout
+	}
+	if s.cookieJar != nil {
+		httpClient.Jar = s.cookieJar
+	}
+	if s.configuresTransport() {
+		if transport := s.buildTransport(base.Transport); transport != nil {
+			httpClient.Transport = transport
+		}
+	}
+	return &httpClient
+}
+
+// buildTransport applies the transport settings to a clone of base, returning
+// nil when base is not an *http.Transport and can't be configured
+func (s *transportSettings) buildTransport(base http.RoundTripper) *http.Transport {
+	var transport *http.Transport
+	switch t := base.(type) {
+	case nil:
+		defaultTransport, ok := http.DefaultTransport.(*http.Transport)
+		if !ok {
+			return nil
+		}
+		transport = defaultTransport.Clone()
+	case *http.Transport:
+		transport = t.Clone()
+	default:
+		return nil
+	}
+
+	if s.pooling {
+		transport.MaxIdleConns = s.maxIdleConns
+		transport.MaxIdleConnsPerHost = s.maxIdleConnsPerHost
+		transport.IdleConnTimeout = defaultIdleConnTimeout
+	}
+
+	if s.minTLSVersion != 0 {
+		if transport.TLSClientConfig == nil {
+			transport.TLSClientConfig = &tls.Config{}
+		}
+		transport.TLSClientConfig.MinVersion = s.minTLSVersion
+	}
+
+	if s.dialTimeout > 0 || s.keepAlive != 0 {
+		transport.DialContext = s.dialer().DialContext
+	}
+
+	if s.preferIPv4 {
+		transport.DialContext = preferIPv4Dialer(transport.DialContext)
+	}
+
+	if s.maxHeaderBytes > 0 {
+		transport.MaxResponseHeaderBytes = s.maxHeaderBytes
+	}
+
+	if s.maxConnsPerHost > 0 {
+		transport.MaxConnsPerHost = s.maxConnsPerHost
+	}
+
+	if s.disableCompression {
+		transport.DisableCompression = true
+	}
+
+	return transport
+}
+
+// dialer returns the net.Dialer for WithDialTimeout and WithKeepAlive, using
+// http.DefaultTransport's 30 second values for whichever is unset
+func (s *transportSettings) dialer() *net.Dialer {
+	dialer := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second}
+	if s.dialTimeout > 0 {
+		dialer.Timeout = s.dialTimeout
+	}
+	if s.keepAlive != 0 {
+		dialer.KeepAlive = s.keepAlive
+	}
+	return dialer
+}
+
+// preferIPv4Dialer wraps dial so TCP connections try IPv4 before the default
+// network. A nil dial uses a net.Dialer matching http.DefaultTransport.
+func preferIPv4Dialer(dial func(ctx context.Context, network, addr string) (net.Conn, error)) func(ctx context.Context, network, addr string) (net.Conn, error) {
+	if dial == nil {
+		dial = (&net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext
+	}
+
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		if network != "tcp" {
+			return dial(ctx, network, addr)
+		}
+
+		conn, err := dial(ctx, "tcp4", addr)
+		if err == nil || ctx.Err() != nil {
+			return conn, err
+		}
+		return dial(ctx, network, addr)
+	}
+}
@@ -89,14 +89,96 @@ SOFTWARE.
 package civitai
 
 import (
+	"bytes"
 	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
 	"time"
+	"unicode"
 )
 
+// CivitaiTime is a timestamp that tolerates the varying formats returned by the
+// API, such as missing time zones, space separators, differing fractional
+// precision, date-only values, and Unix timestamps. It embeds time.Time, so all
+// time.Time methods are available; ToTime returns the plain time.Time. Values
+// marshal as RFC 3339.
+type CivitaiTime struct {
+	time.Time
+}
+
+// ToTime returns the timestamp as a time.Time
+func (t CivitaiTime) ToTime() time.Time {
+	return t.Time
+}
+
+// civitaiTimeLayouts are tried in order when parsing timestamp strings.
+// Layouts without a zone are interpreted as UTC.
+var civitaiTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999Z0700",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999Z0700",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02",
+}
+
+// UnmarshalJSON accepts strings in any of the supported layouts and numeric
+// Unix timestamps in seconds or milliseconds. Null and empty strings decode to
+// the zero time.
+func (t *CivitaiTime) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		t.Time = time.Time{}
+		return nil
+	}
+
+	if len(data) > 0 && data[0] != '"' {
+		return t.parseUnix(string(data))
+	}
+
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	if value == "" {
+		t.Time = time.Time{}
+		return nil
+	}
+
+	for _, layout := range civitaiTimeLayouts {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			t.Time = parsed
+			return nil
+		}
+	}
+
+	return fmt.Errorf("unsupported timestamp format: %q", value)
+}
+
+// parseUnix interprets value as Unix seconds, or milliseconds for values too
+// large to be seconds
+func (t *CivitaiTime) parseUnix(value string) error {
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return fmt.Errorf("unsupported timestamp value: %s", value)
+	}
+
+	if n > 1e11 || n < -1e11 {
+		t.Time = time.UnixMilli(n).UTC()
+	} else {
+		t.Time = time.Unix(n, 0).UTC()
+	}
+	return nil
+}
+
 // FlexibleStringSlice handles API responses that may return either a string or []string
 type FlexibleStringSlice []string
 
-// UnmarshalJSON handles both string and []string JSON values
+// UnmarshalJSON handles both string and []string JSON values. Null, empty
+// strings, and empty arrays all decode to a nil slice so that values survive
+// a marshal/unmarshal round trip unchanged.
 func (f *FlexibleStringSlice) UnmarshalJSON(data []byte) error {
 	// Try to unmarshal as a string first
 	var str string
@@ -104,20 +186,20 @@ func (f *FlexibleStringSlice) UnmarshalJSON(data []byte) error {
 		if str != "" {
 			*f = []string{str}
 		} else {
-			*f = []string{}
+			*f = nil
 		}
 		return nil
 	}
 
 	// Try to unmarshal as []string
 	var slice []string
-	if err := json.Unmarshal(data, &slice); err == nil {
+	if err := json.Unmarshal(data, &slice); err == nil && len(slice) > 0 {
 		*f = slice
 		return nil
 	}
 
-	// Default to empty slice
-	*f = []string{}
+	// Default to empty
+	*f = nil
 	return nil
 }
 
@@ -170,6 +252,8 @@ const (
 	ResourceTypeCollection ResourceType = "Collection"
 	ResourceTypePost       ResourceType = "Post"
 	ResourceTypeWildcard   ResourceType = "Wildcard"
+	ResourceTypeCreator    ResourceType = "Creator"
+	ResourceTypeTag        ResourceType = "Tag"
 )
 
 // ModelType represents specific model subtypes
@@ -187,6 +271,18 @@ const (
 	ModelTypeVAE              ModelType = "VAE"
 )
 
+// knownModelTypes lists the ModelType constants, for validation
+var knownModelTypes = []ModelType{
+	ModelTypeCheckpoint,
+	ModelTypeLORA,
+	ModelTypeTextualInversion,
+	ModelTypeHypernetwork,
+	ModelTypeAestheticGrad,
+	ModelTypeControlNet,
+	ModelTypePose,
+	ModelTypeVAE,
+}
+
 // BaseModel represents the base model architecture
 type BaseModel string
 
@@ -198,6 +294,62 @@ const (
 	BaseModelOther BaseModel = "Other"
 )
 
+// baseModelAliases maps normalized spellings (see baseModelAliasKey) to
+// canonical base models
+var baseModelAliases = map[string]BaseModel{
+	"sd15":                BaseModelSD1_5,
+	"sdv15":               BaseModelSD1_5,
+	"stablediffusion15":   BaseModelSD1_5,
+	"sdxl":                BaseModelSDXL,
+	"sdxl10":              BaseModelSDXL,
+	"sdxlbase":            BaseModelSDXL,
+	"sdxlbase10":          BaseModelSDXL,
+	"stablediffusionxl":   BaseModelSDXL,
+	"stablediffusionxl10": BaseModelSDXL,
+	"sd2":                 BaseModelSD2_0,
+	"sd20":                BaseModelSD2_0,
+	"stablediffusion20":   BaseModelSD2_0,
+	"sd21":                BaseModelSD2_1,
+	"stablediffusion21":   BaseModelSD2_1,
+	"other":               BaseModelOther,
+}
+
+// NormalizeBaseModel maps the varying base model spellings seen in API
+// responses ("SDXL", "SD XL", "sdxl 1.0", "SD1.5", ...) to the canonical
+// BaseModel constants. Matching ignores case, spaces, and punctuation.
+// Unrecognized values are returned trimmed but otherwise unchanged, so newer
+// base models the SDK has no constant for are preserved, as are variants such
+// as "SD 2.0 768". ModelVersion and other types normalize their BaseModel
+// fields when decoded from JSON; see WithBaseModelAlias for adding aliases.
+func NormalizeBaseModel(s string) BaseModel {
+	s = strings.TrimSpace(s)
+	if canonical, ok := baseModelAliases[baseModelAliasKey(s)]; ok {
+		return canonical
+	}
+	return BaseModel(s)
+}
+
+// UnmarshalJSON decodes a base model string and normalizes it with NormalizeBaseModel
+func (b *BaseModel) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	*b = NormalizeBaseModel(s)
+	return nil
+}
+
+// baseModelAliasKey lowercases s and drops everything but letters and digits
+func baseModelAliasKey(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(s) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
 // SortType represents sorting options
 type SortType string
 
@@ -255,6 +407,15 @@ type FileMetadata struct {
 	Format FileFormat `json:"format,omitempty"`
 }
 
+// FilePreference describes which file of a model version to select.
+// Empty fields match any value.
+type FilePreference struct {
+	Format    FileFormat // e.g. FileFormatSafeTensors
+	Precision string     // e.g. "fp16", "fp32" (matched against FileMetadata.FP)
+	Size      string     // e.g. "pruned", "full" (matched against FileMetadata.Size)
+	CleanOnly bool       // only select files that passed pickle and virus scans
+}
+
 // File represents a downloadable file
 type File struct {
 	ID                int          `json:"id"`
@@ -290,7 +451,7 @@ type Image struct {
 	Height       int                    `json:"height,omitempty"`
 	Hash         string                 `json:"hash,omitempty"`
 	Type         string                 `json:"type,omitempty"`
-	Metadata     map[string]interface{} `json:"meta,omitempty"`
+	Metadata     map[string]interface{} `json:"meta"`
 	Availability string                 `json:"availability,omitempty"`
 }
 
@@ -298,9 +459,20 @@ type Image struct {
 type Tag struct {
 	ID   int    `json:"id"`
 	Name string `json:"name"`
-	Type string `json:"type,omitempty"`
+	Type string `json:"type,omitempty"` // See the TagType constants
 }
 
+// Semantic tag types used to group tags in discovery UIs
+const (
+	TagTypeConcept   = "Concept"
+	TagTypeCharacter = "Character"
+	TagTypeStyle     = "Style"
+	TagTypeClothing  = "Clothing"
+	TagTypeObject    = "Object"
+	TagTypeTool      = "Tool"
+	TagTypeUntyped   = "" // Tags whose type was not reported
+)
+
 // SearchParams represents common search parameters
 type SearchParams struct {
 	Query                 string      `json:"query,omitempty"`
@@ -310,7 +482,7 @@ type SearchParams struct {
 	Rating                int         `json:"rating,omitempty"`
 	Page                  int         `json:"page,omitempty"`
 	Limit                 int         `json:"limit,omitempty"`
-	Cursor                string      `json:"cursor,omitempty"` // Added cursor support for pagination
+	Cursor                string      `json:"cursor,omitempty"` // Takes precedence over Page; rejected alongside it by WithStrictValidation
 	Tag                   string      `json:"tag,omitempty"`
 	Username              string      `json:"username,omitempty"`
 	Favorites             bool        `json:"favorites,omitempty"`
@@ -321,27 +493,128 @@ type SearchParams struct {
 	AllowDifferentLicense bool        `json:"allowDifferentLicense,omitempty"`
 	AllowCommercialUse    []string    `json:"allowCommercialUse,omitempty"`
 	NSFW                  *bool       `json:"nsfw,omitempty"`
-	SupportsGeneration    *bool       `json:"supportsGeneration,omitempty"`
+	SupportsGeneration    *bool       `json:"supportsGeneration,omitempty"` // Only models usable with on-site generation; see GetGenerationModels
+	BaseModels            []BaseModel `json:"baseModels,omitempty"`         // See WithBaseModelFilterClientSide
+}
+
+// Merge returns a copy of p with the non-zero fields of other overlaid on it,
+// so params can be layered (e.g. defaults, then user input, then policy).
+//   - Strings, enums, and numbers in other replace p's when non-zero.
+//   - Bools in other can only switch a filter on; false leaves p unchanged.
+//   - Pointers in other (NSFW, SupportsGeneration) replace p's when non-nil, so
+//     an explicit false can override; the pointed-to values are copied.
+//   - Slices (Types, AllowCommercialUse, BaseModels) are appended, skipping
+//     duplicates.
+//
+// Neither p nor other is modified.
+func (p SearchParams) Merge(other SearchParams) SearchParams {
+	merged := p
+
+	if other.Query != "" {
+		merged.Query = other.Query
+	}
+	if other.Sort != "" {
+		merged.Sort = other.Sort
+	}
+	if other.Period != "" {
+		merged.Period = other.Period
+	}
+	if other.Rating != 0 {
+		merged.Rating = other.Rating
+	}
+	if other.Page != 0 {
+		merged.Page = other.Page
+	}
+	if other.Limit != 0 {
+		merged.Limit = other.Limit
+	}
+	if other.Cursor != "" {
+		merged.Cursor = other.Cursor
+	}
+	if other.Tag != "" {
+		merged.Tag = other.Tag
+	}
+	if other.Username != "" {
+		merged.Username = other.Username
+	}
+
+	merged.Favorites = p.Favorites || other.Favorites
+	merged.Hidden = p.Hidden || other.Hidden
+	merged.PrimaryFileOnly = p.PrimaryFileOnly || other.PrimaryFileOnly
+	merged.AllowNoCredit = p.AllowNoCredit || other.AllowNoCredit
+	merged.AllowDerivatives = p.AllowDerivatives || other.AllowDerivatives
+	merged.AllowDifferentLicense = p.AllowDifferentLicense || other.AllowDifferentLicense
+
+	merged.Types = appendUnique(p.Types, other.Types)
+	merged.AllowCommercialUse = appendUnique(p.AllowCommercialUse, other.AllowCommercialUse)
+	merged.BaseModels = appendUnique(p.BaseModels, other.BaseModels)
+
+	merged.NSFW = mergeBoolPtr(p.NSFW, other.NSFW)
+	merged.SupportsGeneration = mergeBoolPtr(p.SupportsGeneration, other.SupportsGeneration)
+
+	return merged
+}
+
+// appendUnique returns a new slice with the values of b not already in a
+// appended to a. It returns nil when both are empty.
+func appendUnique[T comparable](a, b []T) []T {
+	if len(a) == 0 && len(b) == 0 {
+		return nil
+	}
+
+	result := make([]T, 0, len(a)+len(b))
+	seen := make(map[T]bool, len(a)+len(b))
+	for _, values := range [][]T{a, b} {
+		for _, v := range values {
+			if !seen[v] {
+				seen[v] = true
+				result = append(result, v)
+			}
+		}
+	}
+	return result
+}
+
+// mergeBoolPtr returns a copy of override when set, otherwise a copy of base
+func mergeBoolPtr(base, override *bool) *bool {
+	if override == nil {
+		override = base
+	}
+	if override == nil {
+		return nil
+	}
+	v := *override
+	return &v
 }
 
+// Version availability states returned by RefreshVersionAvailability
+const (
+	VersionAvailabilityAvailable   = "Available"   // Published and downloadable
+	VersionAvailabilityEarlyAccess = "EarlyAccess" // Published but limited to early access supporters
+	VersionAvailabilityPrivate     = "Private"     // Visible only to the creator
+	VersionAvailabilityArchived    = "Archived"    // Parent model archived; files may no longer be hosted
+	VersionAvailabilityTakenDown   = "TakenDown"   // Parent model removed by moderation
+	VersionAvailabilityDeleted     = "Deleted"     // Version no longer exists (404)
+)
+
 // ModelVersion represents a version of a model
 type ModelVersion struct {
-	ID                   int        `json:"id"`
-	ModelID              int        `json:"modelId,omitempty"`
-	Name                 string     `json:"name"`
-	Description          string     `json:"description,omitempty"`
-	BaseModel            BaseModel  `json:"baseModel,omitempty"`
-	BaseModelType        string     `json:"baseModelType,omitempty"`
-	CreatedAt            time.Time  `json:"createdAt"`
-	UpdatedAt            time.Time  `json:"updatedAt"`
-	PublishedAt          *time.Time `json:"publishedAt,omitempty"`
-	TrainedWords         []string   `json:"trainedWords,omitempty"`
-	Files                []File     `json:"files,omitempty"`
-	Images               []Image    `json:"images,omitempty"`
-	DownloadURL          string     `json:"downloadUrl,omitempty"`
-	EarlyAccessTimeFrame int        `json:"earlyAccessTimeFrame,omitempty"`
-	Stats                Stats      `json:"stats,omitempty"`
-	Availability         string     `json:"availability,omitempty"`
+	ID                   int          `json:"id"`
+	ModelID              int          `json:"modelId,omitempty"`
+	Name                 string       `json:"name"`
+	Description          string       `json:"description,omitempty"`
+	BaseModel            BaseModel    `json:"baseModel,omitempty"`
+	BaseModelType        string       `json:"baseModelType,omitempty"`
+	CreatedAt            CivitaiTime  `json:"createdAt"`
+	UpdatedAt            CivitaiTime  `json:"updatedAt"`
+	PublishedAt          *CivitaiTime `json:"publishedAt,omitempty"`
+	TrainedWords         []string     `json:"trainedWords"`
+	Files                []File       `json:"files"`
+	Images               []Image      `json:"images"`
+	DownloadURL          string       `json:"downloadUrl,omitempty"`
+	EarlyAccessTimeFrame int          `json:"earlyAccessTimeFrame,omitempty"`
+	Stats                Stats        `json:"stats,omitempty"`
+	Availability         string       `json:"availability,omitempty"`
 }
 
 // ToAIR converts the model version to an AIR identifier
@@ -354,7 +627,9 @@ func (mv *ModelVersion) GetAIRForEcosystem(ecosystem AIREcosystem) *AIR {
 	return mv.ToAIR(string(ecosystem))
 }
 
-// Model represents a CivitAI model
+// Model represents a CivitAI model. Decoded models round-trip through
+// encoding/json without loss; collection fields keep the nil/empty
+// distinction by always being encoded.
 type Model struct {
 	ID                    int                 `json:"id"`
 	Name                  string              `json:"name"`
@@ -368,12 +643,12 @@ type Model struct {
 	AllowDifferentLicense bool                `json:"allowDifferentLicense,omitempty"`
 	Stats                 Stats               `json:"stats,omitempty"`
 	Creator               User                `json:"creator,omitempty"`
-	Tags                  []string            `json:"tags,omitempty"`
-	ModelVersions         []ModelVersion      `json:"modelVersions,omitempty"`
-	Images                []Image             `json:"images,omitempty"`
-	CreatedAt             time.Time           `json:"createdAt"`
-	UpdatedAt             time.Time           `json:"updatedAt"`
-	PublishedAt           *time.Time          `json:"publishedAt,omitempty"`
+	Tags                  []string            `json:"tags"`
+	ModelVersions         []ModelVersion      `json:"modelVersions"`
+	Images                []Image             `json:"images"`
+	CreatedAt             CivitaiTime         `json:"createdAt"`
+	UpdatedAt             CivitaiTime         `json:"updatedAt"`
+	PublishedAt           *CivitaiTime        `json:"publishedAt,omitempty"`
 }
 
 // ToAIR converts the model to an AIR identifier
@@ -388,14 +663,14 @@ func (m *Model) GetAIRForEcosystem(ecosystem AIREcosystem, versionID ...int) *AI
 
 // Article represents a CivitAI article
 type Article struct {
-	ID          int       `json:"id"`
-	Title       string    `json:"title"`
-	Content     string    `json:"content,omitempty"`
-	CoverImage  Image     `json:"coverImage,omitempty"`
-	PublishedAt time.Time `json:"publishedAt"`
-	User        User      `json:"user"`
-	Stats       Stats     `json:"stats,omitempty"`
-	Tags        []Tag     `json:"tags,omitempty"`
+	ID          int         `json:"id"`
+	Title       string      `json:"title"`
+	Content     string      `json:"content,omitempty"`
+	CoverImage  Image       `json:"coverImage,omitempty"`
+	PublishedAt CivitaiTime `json:"publishedAt"`
+	User        User        `json:"user"`
+	Stats       Stats       `json:"stats,omitempty"`
+	Tags        []Tag       `json:"tags,omitempty"`
 }
 
 // Collection represents a CivitAI collection
@@ -421,7 +696,7 @@ type Post struct {
 	Tags          []Tag          `json:"tags,omitempty"`
 	NSFW          bool           `json:"nsfw,omitempty"`
 	ModelVersions []ModelVersion `json:"modelVersions,omitempty"`
-	PublishedAt   time.Time      `json:"publishedAt"`
+	PublishedAt   CivitaiTime    `json:"publishedAt"`
 }
 
 // DetailedImage represents a detailed image with generation info
@@ -454,8 +729,8 @@ type Workflow struct {
 	Images      []Image                `json:"images,omitempty"`
 	Tags        []Tag                  `json:"tags,omitempty"`
 	Stats       Stats                  `json:"stats,omitempty"`
-	CreatedAt   time.Time              `json:"createdAt"`
-	UpdatedAt   time.Time              `json:"updatedAt"`
+	CreatedAt   CivitaiTime            `json:"createdAt"`
+	UpdatedAt   CivitaiTime            `json:"updatedAt"`
 }
 
 // WorkflowNode represents a node in a workflow
@@ -475,16 +750,16 @@ type VAE struct {
 
 // Wildcard represents a text file for prompt automation
 type Wildcard struct {
-	ID          int       `json:"id"`
-	Name        string    `json:"name"`
-	Content     string    `json:"content"`
-	Category    string    `json:"category,omitempty"`
-	Description string    `json:"description,omitempty"`
-	User        User      `json:"user"`
-	Tags        []Tag     `json:"tags,omitempty"`
-	Stats       Stats     `json:"stats,omitempty"`
-	CreatedAt   time.Time `json:"createdAt"`
-	UpdatedAt   time.Time `json:"updatedAt"`
+	ID          int         `json:"id"`
+	Name        string      `json:"name"`
+	Content     string      `json:"content"`
+	Category    string      `json:"category,omitempty"`
+	Description string      `json:"description,omitempty"`
+	User        User        `json:"user"`
+	Tags        []Tag       `json:"tags,omitempty"`
+	Stats       Stats       `json:"stats,omitempty"`
+	CreatedAt   CivitaiTime `json:"createdAt"`
+	UpdatedAt   CivitaiTime `json:"updatedAt"`
 }
 
 // Creator represents a CivitAI creator/user from the /creators endpoint
@@ -501,24 +776,27 @@ type ImageParams struct {
 	ModelID        int    `json:"modelId,omitempty"`
 	ModelVersionID int    `json:"modelVersionId,omitempty"`
 	Username       string `json:"username,omitempty"`
-	NSFW           string `json:"nsfw,omitempty"` // None, Soft, Mature, X
-	Sort           string `json:"sort,omitempty"` // Most Reactions, Most Comments, Newest
+	NSFW           string `json:"nsfw,omitempty"` // None, Soft, Mature, X, or true/false; case-insensitive
+	Sort           string `json:"sort,omitempty"` // Most Reactions, Most Comments, Newest; case-insensitive
 	Period         Period `json:"period,omitempty"`
 	Page           int    `json:"page,omitempty"`
+	Cursor         string `json:"cursor,omitempty"` // From Metadata.NextCursor; takes precedence over Page
 }
 
 // CreatorParams represents parameters for searching creators
 type CreatorParams struct {
-	Limit int    `json:"limit,omitempty"`
-	Page  int    `json:"page,omitempty"`
-	Query string `json:"query,omitempty"`
+	Limit  int    `json:"limit,omitempty"`
+	Page   int    `json:"page,omitempty"`
+	Query  string `json:"query,omitempty"`
+	Cursor string `json:"cursor,omitempty"` // From Metadata.NextCursor; takes precedence over Page
 }
 
 // TagParams represents parameters for searching tags
 type TagParams struct {
-	Limit int    `json:"limit,omitempty"`
-	Page  int    `json:"page,omitempty"`
-	Query string `json:"query,omitempty"`
+	Limit  int    `json:"limit,omitempty"`
+	Page   int    `json:"page,omitempty"`
+	Query  string `json:"query,omitempty"`
+	Cursor string `json:"cursor,omitempty"` // From Metadata.NextCursor; takes precedence over Page
 }
 
 // ImageStats represents statistics for an image
@@ -539,7 +817,7 @@ type DetailedImageResponse struct {
 	Height    int                    `json:"height"`
 	NSFW      bool                   `json:"nsfw"`
 	NSFWLevel string                 `json:"nsfwLevel"` // None, Soft, Mature, X
-	CreatedAt time.Time              `json:"createdAt"`
+	CreatedAt CivitaiTime            `json:"createdAt"`
 	PostID    int                    `json:"postId"`
 	Stats     ImageStats             `json:"stats"`
 	Meta      map[string]interface{} `json:"meta,omitempty"`
@@ -564,6 +842,7 @@ type TagResponse struct {
 	Name       string `json:"name"`
 	ModelCount int    `json:"modelCount"`
 	Link       string `json:"link"`
+	Type       string `json:"type,omitempty"` // Semantic type when reported, e.g. "Style"
 }
 
 // NSFWLevel represents NSFW content levels
@@ -576,6 +855,14 @@ const (
 	NSFWLevelX      NSFWLevel = "X"
 )
 
+// knownNSFWLevels lists the NSFWLevel constants, for validation
+var knownNSFWLevels = []NSFWLevel{
+	NSFWLevelNone,
+	NSFWLevelSoft,
+	NSFWLevelMature,
+	NSFWLevelX,
+}
+
 // ImageSort represents image sorting options
 type ImageSort string
 
@@ -585,6 +872,13 @@ const (
 	ImageSortNewest        ImageSort = "Newest"
 )
 
+// knownImageSorts lists the ImageSort constants, for validation
+var knownImageSorts = []ImageSort{
+	ImageSortMostReactions,
+	ImageSortMostComments,
+	ImageSortNewest,
+}
+
 // CommercialUse represents commercial use permissions
 type CommercialUse string
 
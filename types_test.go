@@ -23,6 +23,9 @@ SOFTWARE.
 package civitai
 
 import (
+	"bytes"
+	"encoding/json"
+	"reflect"
 	"testing"
 	"time"
 )
@@ -107,8 +110,8 @@ func TestModel(t *testing.T) {
 			Rating:        4.5,
 			RatingCount:   100,
 		},
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
+		CreatedAt: CivitaiTime{time.Now()},
+		UpdatedAt: CivitaiTime{time.Now()},
 	}
 
 	if model.ID != 12345 {
@@ -174,3 +177,280 @@ func TestImageParams(t *testing.T) {
 		t.Errorf("Expected sort 'newest', got '%s'", params.Sort)
 	}
 }
+
+func TestModelJSONRoundTrip(t *testing.T) {
+	samples := map[string]string{
+		"Commercial use as string": `{"id": 4201, "name": "Realistic Vision", "type": "Checkpoint",
+			"allowCommercialUse": "Sell", "tags": ["photorealistic"],
+			"createdAt": "2023-01-31T12:00:00.123+02:00", "updatedAt": "2024-06-01T00:00:00Z"}`,
+		"Commercial use as array":     `{"id": 1, "name": "A", "allowCommercialUse": ["Image", "RentCivit"]}`,
+		"Empty commercial use string": `{"id": 1, "name": "A", "allowCommercialUse": ""}`,
+		"Null and empty collections": `{"id": 1, "name": "A", "allowCommercialUse": null, "tags": [],
+			"modelVersions": null, "images": []}`,
+		"Nested versions and files": `{"id": 1, "name": "A", "modelVersions": [{
+			"id": 2, "name": "v1", "baseModel": "SD 1.5", "trainedWords": [],
+			"publishedAt": "2024-01-01T00:00:00.000Z",
+			"files": [{"id": 3, "name": "a.safetensors", "sizeKB": 2048.5, "primary": true,
+				"scannedAt": "2024-01-01T00:00:00Z", "hashes": {"SHA256": "ABC", "AutoV2": "DEF"},
+				"metadata": {"fp": "fp16", "size": "pruned", "format": "SafeTensor"}}],
+			"images": [{"id": 4, "url": "https://image.civitai.com/4.jpeg",
+				"meta": {"seed": 1234567890, "prompt": "a cat", "resources": [], "empty": {}}}]
+		}]}`,
+	}
+
+	for name, raw := range samples {
+		t.Run(name, func(t *testing.T) {
+			var decoded Model
+			if err := json.Unmarshal([]byte(raw), &decoded); err != nil {
+				t.Fatalf("Failed to decode sample: %v", err)
+			}
+
+			encoded, err := json.Marshal(decoded)
+			if err != nil {
+				t.Fatalf("Failed to encode model: %v", err)
+			}
+
+			var roundTripped Model
+			if err := json.Unmarshal(encoded, &roundTripped); err != nil {
+				t.Fatalf("Failed to decode encoded model: %v", err)
+			}
+			if !reflect.DeepEqual(decoded, roundTripped) {
+				t.Errorf("Model changed across round trip:\nbefore: %+v\nafter:  %+v", decoded, roundTripped)
+			}
+
+			reencoded, _ := json.Marshal(roundTripped)
+			if !bytes.Equal(encoded, reencoded) {
+				t.Errorf("Encoding is not stable:\nfirst:  %s\nsecond: %s", encoded, reencoded)
+			}
+		})
+	}
+
+	t.Run("Constructed model", func(t *testing.T) {
+		published := time.Date(2024, 3, 1, 8, 30, 0, 0, time.UTC)
+		model := Model{
+			ID:                 7,
+			Name:               "Constructed",
+			Type:               ModelTypeLORA,
+			AllowCommercialUse: FlexibleStringSlice{string(CommercialUseSell)},
+			Tags:               []string{},
+			ModelVersions: []ModelVersion{{
+				ID:          8,
+				BaseModel:   BaseModelSDXL,
+				PublishedAt: &CivitaiTime{published},
+				Files:       []File{{ID: 9, Hashes: Hashes{SHA256: "ABC"}}},
+			}},
+			CreatedAt: CivitaiTime{published},
+			UpdatedAt: CivitaiTime{published},
+		}
+
+		encoded, err := json.Marshal(model)
+		if err != nil {
+			t.Fatalf("Failed to encode model: %v", err)
+		}
+		var decoded Model
+		if err := json.Unmarshal(encoded, &decoded); err != nil {
+			t.Fatalf("Failed to decode model: %v", err)
+		}
+		if !reflect.DeepEqual(model, decoded) {
+			t.Errorf("Model changed across round trip:\nbefore: %+v\nafter:  %+v", model, decoded)
+		}
+	})
+}
+
+func TestCivitaiTime(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected time.Time
+	}{
+		{"RFC3339", `"2024-03-01T08:30:00Z"`, time.Date(2024, 3, 1, 8, 30, 0, 0, time.UTC)},
+		{"Milliseconds", `"2024-03-01T08:30:00.123Z"`, time.Date(2024, 3, 1, 8, 30, 0, 123000000, time.UTC)},
+		{"Nanoseconds with offset", `"2024-03-01T10:30:00.123456789+02:00"`, time.Date(2024, 3, 1, 8, 30, 0, 123456789, time.UTC)},
+		{"Compact offset", `"2024-03-01T10:30:00+0200"`, time.Date(2024, 3, 1, 8, 30, 0, 0, time.UTC)},
+		{"No time zone", `"2024-03-01T08:30:00.5"`, time.Date(2024, 3, 1, 8, 30, 0, 500000000, time.UTC)},
+		{"Space separator", `"2024-03-01 08:30:00"`, time.Date(2024, 3, 1, 8, 30, 0, 0, time.UTC)},
+		{"Date only", `"2024-03-01"`, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{"Unix seconds", `1709281800`, time.Date(2024, 3, 1, 8, 30, 0, 0, time.UTC)},
+		{"Unix milliseconds", `1709281800123`, time.Date(2024, 3, 1, 8, 30, 0, 123000000, time.UTC)},
+		{"Null", `null`, time.Time{}},
+		{"Empty string", `""`, time.Time{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ct CivitaiTime
+			if err := json.Unmarshal([]byte(tt.input), &ct); err != nil {
+				t.Fatalf("Failed to unmarshal %s: %v", tt.input, err)
+			}
+			if !ct.Equal(tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, ct.Time)
+			}
+		})
+	}
+
+	t.Run("Invalid format", func(t *testing.T) {
+		var ct CivitaiTime
+		if err := json.Unmarshal([]byte(`"last tuesday"`), &ct); err == nil {
+			t.Error("Expected error for unsupported format")
+		}
+	})
+
+	t.Run("Model with mixed formats", func(t *testing.T) {
+		var model Model
+		data := `{"id": 1, "createdAt": "2024-03-01 08:30:00", "updatedAt": "2024-03-02T08:30:00.1Z",
+			"publishedAt": "2024-03-01", "modelVersions": [{"id": 2, "createdAt": "2024-03-01T08:30:00"}]}`
+		if err := json.Unmarshal([]byte(data), &model); err != nil {
+			t.Fatalf("Failed to unmarshal model: %v", err)
+		}
+		if model.PublishedAt == nil || model.PublishedAt.Day() != 1 {
+			t.Errorf("Expected publishedAt on March 1, got %v", model.PublishedAt)
+		}
+		if !model.UpdatedAt.After(model.CreatedAt.Time) {
+			t.Error("Expected updatedAt after createdAt")
+		}
+		if model.ModelVersions[0].CreatedAt.IsZero() {
+			t.Error("Expected version createdAt to be parsed")
+		}
+	})
+
+	t.Run("ToTime", func(t *testing.T) {
+		var ct CivitaiTime
+		if err := json.Unmarshal([]byte(`"2024-03-01 08:30:00"`), &ct); err != nil {
+			t.Fatalf("Failed to unmarshal: %v", err)
+		}
+		var plain time.Time = ct.ToTime()
+		if expected := time.Date(2024, 3, 1, 8, 30, 0, 0, time.UTC); !plain.Equal(expected) {
+			t.Errorf("Expected %v, got %v", expected, plain)
+		}
+		if !(CivitaiTime{}).ToTime().IsZero() {
+			t.Error("Expected zero CivitaiTime to convert to the zero time")
+		}
+	})
+
+	t.Run("Marshals as RFC3339", func(t *testing.T) {
+		encoded, err := json.Marshal(CivitaiTime{time.Date(2024, 3, 1, 8, 30, 0, 0, time.UTC)})
+		if err != nil {
+			t.Fatalf("Failed to marshal: %v", err)
+		}
+		if string(encoded) != `"2024-03-01T08:30:00Z"` {
+			t.Errorf("Expected RFC3339 output, got %s", encoded)
+		}
+	})
+}
+
+func TestSearchParamsMerge(t *testing.T) {
+	nsfw := true
+	safe := false
+	base := SearchParams{
+		Query:              "anime",
+		Sort:               SortHighestRated,
+		Limit:              20,
+		Favorites:          true,
+		Types:              []ModelType{ModelTypeCheckpoint},
+		AllowCommercialUse: []string{"Image"},
+		NSFW:               &nsfw,
+	}
+
+	t.Run("Overrides non-zero fields", func(t *testing.T) {
+		merged := base.Merge(SearchParams{Query: "realistic", Limit: 50, Period: PeriodWeek, NSFW: &safe})
+
+		if merged.Query != "realistic" || merged.Limit != 50 || merged.Period != PeriodWeek {
+			t.Errorf("Expected overridden query/limit/period, got %q/%d/%q", merged.Query, merged.Limit, merged.Period)
+		}
+		if merged.Sort != SortHighestRated {
+			t.Errorf("Expected sort to be kept, got %q", merged.Sort)
+		}
+		if merged.NSFW == nil || *merged.NSFW {
+			t.Error("Expected explicit NSFW=false to override")
+		}
+	})
+
+	t.Run("Appends slices without duplicates", func(t *testing.T) {
+		merged := base.Merge(SearchParams{
+			Types:              []ModelType{ModelTypeLORA, ModelTypeCheckpoint},
+			AllowCommercialUse: []string{"Sell"},
+		})
+
+		if !reflect.DeepEqual(merged.Types, []ModelType{ModelTypeCheckpoint, ModelTypeLORA}) {
+			t.Errorf("Expected [Checkpoint LORA], got %v", merged.Types)
+		}
+		if !reflect.DeepEqual(merged.AllowCommercialUse, []string{"Image", "Sell"}) {
+			t.Errorf("Expected [Image Sell], got %v", merged.AllowCommercialUse)
+		}
+	})
+
+	t.Run("Zero values leave fields unchanged", func(t *testing.T) {
+		merged := base.Merge(SearchParams{})
+
+		if merged.Query != base.Query || merged.Limit != base.Limit || !merged.Favorites {
+			t.Errorf("Expected base values, got %+v", merged)
+		}
+		if merged.NSFW == nil || !*merged.NSFW {
+			t.Error("Expected nil NSFW to keep base value")
+		}
+		if !reflect.DeepEqual(merged.Types, base.Types) {
+			t.Errorf("Expected types %v, got %v", base.Types, merged.Types)
+		}
+		if (SearchParams{}).Merge(SearchParams{}).Types != nil {
+			t.Error("Expected nil types when both are empty")
+		}
+	})
+
+	t.Run("Does not alias inputs", func(t *testing.T) {
+		merged := base.Merge(SearchParams{Types: []ModelType{ModelTypeVAE}})
+		merged.Types[0] = ModelTypeHypernetwork
+		*merged.NSFW = false
+
+		if base.Types[0] != ModelTypeCheckpoint {
+			t.Error("Expected base types to be unmodified")
+		}
+		if !*base.NSFW {
+			t.Error("Expected base NSFW to be unmodified")
+		}
+	})
+}
+
+func TestNormalizeBaseModel(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected BaseModel
+	}{
+		{"SDXL 1.0", BaseModelSDXL},
+		{"SDXL", BaseModelSDXL},
+		{"SD XL", BaseModelSDXL},
+		{"sdxl 1.0", BaseModelSDXL},
+		{"SDXL-1.0", BaseModelSDXL},
+		{"Stable Diffusion XL", BaseModelSDXL},
+		{"SD 1.5", BaseModelSD1_5},
+		{"SD1.5", BaseModelSD1_5},
+		{"sd_15", BaseModelSD1_5},
+		{"SD v1.5", BaseModelSD1_5},
+		{"SD 2.0", BaseModelSD2_0},
+		{"SD 2.0 768", "SD 2.0 768"},
+		{"SD 2.1", BaseModelSD2_1},
+		{"sd2.1", BaseModelSD2_1},
+		{" other ", BaseModelOther},
+		{"SD 1.4", "SD 1.4"},
+		{"SDXL Turbo", "SDXL Turbo"},
+		{"  Pony  ", "Pony"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := NormalizeBaseModel(tt.input); got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+
+	t.Run("Applied when decoding versions", func(t *testing.T) {
+		var version ModelVersion
+		if err := json.Unmarshal([]byte(`{"id": 1, "baseModel": "SD XL"}`), &version); err != nil {
+			t.Fatalf("Failed to decode version: %v", err)
+		}
+		if version.BaseModel != BaseModelSDXL {
+			t.Errorf("Expected %q, got %q", BaseModelSDXL, version.BaseModel)
+		}
+	})
+}
@@ -24,4 +24,29 @@ SOFTWARE.
 package civitai
 
 // This file contains utility functions for the SDK
-// Implementation will be added as needed
+
+import "fmt"
+
+// sizeUnits are the units FormatSize steps through, starting from kilobytes
+var sizeUnits = []string{"KB", "MB", "GB", "TB"}
+
+// FormatSize formats a size in kilobytes (as reported by File.SizeKB) for
+// display, using binary units: 512 gives "512 KB", 1024 gives "1.0 MB", and
+// 2202009.6 gives "2.1 GB". Megabytes and larger keep one decimal place below
+// 100. Zero and negative sizes give "0 KB".
+func FormatSize(kb float64) string {
+	if kb <= 0 {
+		return "0 KB"
+	}
+
+	unit := 0
+	for kb >= 1024 && unit < len(sizeUnits)-1 {
+		kb /= 1024
+		unit++
+	}
+
+	if unit > 0 && kb < 100 {
+		return fmt.Sprintf("%.1f %s", kb, sizeUnits[unit])
+	}
+	return fmt.Sprintf("%.0f %s", kb, sizeUnits[unit])
+}
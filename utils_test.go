@@ -0,0 +1,53 @@
+/*
+Copyright (c) 2025 Regi Ellis
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package civitai
+
+import "testing"
+
+func TestFormatSize(t *testing.T) {
+	tests := []struct {
+		name     string
+		kb       float64
+		expected string
+	}{
+		{"Zero", 0, "0 KB"},
+		{"Negative", -5, "0 KB"},
+		{"Sub-KB", 0.4, "0 KB"},
+		{"Sub-MB", 512, "512 KB"},
+		{"Exactly 1 MB", 1024, "1.0 MB"},
+		{"Fractional MB", 1200, "1.2 MB"},
+		{"Hundreds of MB", 350 * 1024, "350 MB"},
+		{"Just over 1 GB", 1.2 * 1024 * 1024, "1.2 GB"},
+		{"Multi-GB", 6.5 * 1024 * 1024, "6.5 GB"},
+		{"Terabytes", 2 * 1024 * 1024 * 1024, "2.0 TB"},
+		{"Beyond TB", 2048 * 1024 * 1024 * 1024, "2048 TB"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FormatSize(tt.kb); got != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
@@ -25,7 +25,11 @@ package civitai
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"strings"
+	"sync/atomic"
 	"testing"
 )
 
@@ -166,3 +170,217 @@ func TestValidationInAPIMethods(t *testing.T) {
 		}
 	})
 }
+
+func TestWithStrictValidation(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"items": [], "metadata": {}}`))
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	searchTests := []struct {
+		name   string
+		token  string
+		params SearchParams
+	}{
+		{"Query and tag", "", SearchParams{Query: "anime", Tag: "style"}},
+		{"Page and cursor", "", SearchParams{Page: 2, Cursor: "abc"}},
+		{"Favorites without token", "", SearchParams{Favorites: true}},
+		{"Hidden without token", "", SearchParams{Hidden: true}},
+	}
+
+	for _, tt := range searchTests {
+		t.Run("Lenient "+tt.name, func(t *testing.T) {
+			client := NewClient(tt.token, WithBaseURL(server.URL))
+			if _, _, err := client.SearchModels(ctx, tt.params); err != nil {
+				t.Errorf("Expected lenient validation to allow params, got %v", err)
+			}
+		})
+
+		t.Run("Strict "+tt.name, func(t *testing.T) {
+			client := NewClient(tt.token, WithBaseURL(server.URL), WithStrictValidation())
+			before := atomic.LoadInt32(&requests)
+			_, _, err := client.SearchModels(ctx, tt.params)
+			if err == nil || !strings.Contains(err.Error(), "invalid search parameters") {
+				t.Errorf("Expected invalid search parameters error, got %v", err)
+			}
+			if after := atomic.LoadInt32(&requests); after != before {
+				t.Errorf("Expected no request to be sent, got %d", after-before)
+			}
+		})
+	}
+
+	t.Run("Strict favorites with token", func(t *testing.T) {
+		client := NewClient("token", WithBaseURL(server.URL), WithStrictValidation())
+		if _, _, err := client.SearchModels(ctx, SearchParams{Favorites: true, Hidden: true}); err != nil {
+			t.Errorf("Expected no error with an API token, got %v", err)
+		}
+	})
+
+	imageTests := []struct {
+		name   string
+		params ImageParams
+	}{
+		{"Model and version", ImageParams{ModelID: 1, ModelVersionID: 2}},
+		{"Post and model", ImageParams{PostID: 3, ModelID: 1}},
+		{"Post and version", ImageParams{PostID: 3, ModelVersionID: 2}},
+	}
+
+	for _, tt := range imageTests {
+		t.Run("Lenient images "+tt.name, func(t *testing.T) {
+			client := NewClient("", WithBaseURL(server.URL))
+			if _, _, err := client.GetImages(ctx, tt.params); err != nil {
+				t.Errorf("Expected lenient validation to allow params, got %v", err)
+			}
+		})
+
+		t.Run("Strict images "+tt.name, func(t *testing.T) {
+			client := NewClient("", WithBaseURL(server.URL), WithStrictValidation())
+			_, _, err := client.GetImages(ctx, tt.params)
+			if err == nil || !strings.Contains(err.Error(), "invalid image parameters") {
+				t.Errorf("Expected invalid image parameters error, got %v", err)
+			}
+		})
+	}
+
+	t.Run("Strict allows consistent params", func(t *testing.T) {
+		client := NewClient("", WithBaseURL(server.URL), WithStrictValidation())
+		if _, _, err := client.SearchModels(ctx, SearchParams{Query: "anime", Cursor: "abc"}); err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+		if _, _, err := client.GetImages(ctx, ImageParams{ModelVersionID: 2}); err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+	})
+}
+
+func TestSearchCursorTakesPrecedenceOverPage(t *testing.T) {
+	var query atomic.Value
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query.Store(r.URL.Query())
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"items": [], "metadata": {}}`))
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	params := SearchParams{Page: 3, Cursor: "next-abc"}
+
+	t.Run("Lenient sends only cursor", func(t *testing.T) {
+		client := NewClientWithoutAuth(WithBaseURL(server.URL))
+		if _, _, err := client.SearchModels(ctx, params); err != nil {
+			t.Fatalf("SearchModels failed: %v", err)
+		}
+		sent := query.Load().(url.Values)
+		if sent.Get("cursor") != "next-abc" {
+			t.Errorf("Expected cursor next-abc, got %q", sent.Get("cursor"))
+		}
+		if sent.Has("page") {
+			t.Errorf("Expected page to be omitted, got %q", sent.Get("page"))
+		}
+	})
+
+	t.Run("Lenient sends page without cursor", func(t *testing.T) {
+		client := NewClientWithoutAuth(WithBaseURL(server.URL))
+		if _, _, err := client.SearchModels(ctx, SearchParams{Page: 3}); err != nil {
+			t.Fatalf("SearchModels failed: %v", err)
+		}
+		if got := query.Load().(url.Values).Get("page"); got != "3" {
+			t.Errorf("Expected page 3, got %q", got)
+		}
+	})
+
+	t.Run("Strict rejects both", func(t *testing.T) {
+		client := NewClientWithoutAuth(WithBaseURL(server.URL), WithStrictValidation())
+		_, _, err := client.SearchModels(ctx, params)
+		if err == nil || !strings.Contains(err.Error(), "cursor and page cannot be used together") {
+			t.Errorf("Expected cursor and page error, got %v", err)
+		}
+	})
+
+	// Images, creators, and tags follow the same rule as model searches
+	searches := []struct {
+		name   string
+		search func(c *Client) error
+	}{
+		{"Images", func(c *Client) error {
+			_, _, err := c.GetImages(ctx, ImageParams{Page: 3, Cursor: "next-abc"})
+			return err
+		}},
+		{"Creators", func(c *Client) error {
+			_, _, err := c.GetCreators(ctx, CreatorParams{Page: 3, Cursor: "next-abc"})
+			return err
+		}},
+		{"Tags", func(c *Client) error {
+			_, _, err := c.GetTags(ctx, TagParams{Page: 3, Cursor: "next-abc"})
+			return err
+		}},
+	}
+
+	for _, tt := range searches {
+		t.Run("Lenient "+tt.name+" sends only cursor", func(t *testing.T) {
+			client := NewClientWithoutAuth(WithBaseURL(server.URL))
+			if err := tt.search(client); err != nil {
+				t.Fatalf("Search failed: %v", err)
+			}
+			sent := query.Load().(url.Values)
+			if sent.Get("cursor") != "next-abc" || sent.Has("page") {
+				t.Errorf("Expected only cursor next-abc, got %v", sent)
+			}
+		})
+
+		t.Run("Strict "+tt.name+" rejects both", func(t *testing.T) {
+			client := NewClientWithoutAuth(WithBaseURL(server.URL), WithStrictValidation())
+			err := tt.search(client)
+			if err == nil || !strings.Contains(err.Error(), "cursor and page cannot be used together") {
+				t.Errorf("Expected cursor and page error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestValidateImageNSFWAndSort(t *testing.T) {
+	client := NewClientWithoutAuth()
+
+	tests := []struct {
+		name     string
+		params   ImageParams
+		wantErr  string
+		wantNSFW string
+		wantSort string
+	}{
+		{"unset", ImageParams{}, "", "", ""},
+		{"valid values", ImageParams{NSFW: "Mature", Sort: "Most Reactions"}, "", "Mature", "Most Reactions"},
+		{"wrong case is normalized", ImageParams{NSFW: "soft", Sort: "most comments"}, "", "Soft", "Most Comments"},
+		{"boolean NSFW", ImageParams{NSFW: "FALSE", Sort: "NEWEST"}, "", "false", "Newest"},
+		{"misspelled NSFW", ImageParams{NSFW: "Matur"}, `unknown NSFW level "Matur"`, "", ""},
+		{"misspelled sort", ImageParams{Sort: "Most Reaction"}, `unknown image sort "Most Reaction"`, "", ""},
+		{"model sort rejected", ImageParams{Sort: string(SortMostDownload)}, `unknown image sort "Most Downloaded"`, "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := client.validateImageParams(tt.params)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("Expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected valid params, got %v", err)
+			}
+
+			query := client.buildImageParams(tt.params)
+			if query["nsfw"] != tt.wantNSFW {
+				t.Errorf("Expected nsfw %q, got %q", tt.wantNSFW, query["nsfw"])
+			}
+			if query["sort"] != tt.wantSort {
+				t.Errorf("Expected sort %q, got %q", tt.wantSort, query["sort"])
+			}
+		})
+	}
+}